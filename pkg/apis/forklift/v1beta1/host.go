@@ -30,7 +30,8 @@ type HostSpec struct {
 	// Provider
 	Provider core.ObjectReference `json:"provider" ref:"Provider"`
 	// IP address used for disk transfer.
-	IpAddress string `json:"ipAddress"`
+	// Default: the address of the preferred VMkernel adapter.
+	IpAddress string `json:"ipAddress,omitempty"`
 	// Certificate SHA-1 fingerprint, called thumbprint by VMware.
 	Thumbprint string `json:"thumbprint,omitempty"`
 	// Credentials.
@@ -45,6 +46,21 @@ type HostStatus struct {
 	// The most recent generation observed by the controller.
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Preferred VMkernel adapter.
+	// The adapter with the `ipAddress` when set, otherwise the
+	// adapter with the fastest link (then largest MTU).
+	Adapter *HostAdapter `json:"adapter,omitempty"`
+}
+
+//
+// Host (VMkernel) network adapter.
+type HostAdapter struct {
+	// Name (port group or switch).
+	Name string `json:"name,omitempty"`
+	// IP address.
+	IpAddress string `json:"ipAddress"`
+	// Subnet mask.
+	SubnetMask string `json:"subnetMask,omitempty"`
 }
 
 //
@@ -73,6 +89,19 @@ type HostList struct {
 	Items         []Host `json:"items"`
 }
 
+//
+// IP address used for disk transfer.
+// The `ipAddress` when set, otherwise the address
+// of the preferred VMkernel adapter.
+func (r *Host) TransferIp() (ip string) {
+	ip = r.Spec.IpAddress
+	if ip == "" && r.Status.Adapter != nil {
+		ip = r.Status.Adapter.IpAddress
+	}
+
+	return
+}
+
 func init() {
 	SchemeBuilder.Register(&Host{}, &HostList{})
 }
@@ -34,6 +34,23 @@ type DestinationNetwork struct {
 	Namespace string `json:"namespace,omitempty"`
 	// The name.
 	Name string `json:"name,omitempty"`
+	// Generate the network attachment definition (multus only).
+	// The definition is created and updated by the network map.
+	Generate *GeneratedNetwork `json:"generate,omitempty"`
+}
+
+//
+// Generated network attachment definition.
+type GeneratedNetwork struct {
+	// The node bridge.
+	Bridge string `json:"bridge"`
+	// The VLAN ID.
+	// Default: the source network VLAN.
+	VLan string `json:"vlan,omitempty"`
+	// CNI configuration template (text/template).
+	// Fields: Name, Namespace, Bridge, VLan and Source (network name).
+	// Default: cnv-bridge.
+	Template string `json:"template,omitempty"`
 }
 
 //
@@ -67,6 +84,40 @@ type DestinationStorage struct {
 	AccessMode core.PersistentVolumeAccessMode `json:"accessMode,omitempty"`
 }
 
+//
+// Defaults.
+const (
+	// Minimum auto-map confidence.
+	DefaultMinConfidence = 50
+)
+
+//
+// Automatic mapping.
+// Entries are added to the map for unmapped source
+// networks (or storage) using the inventory suggestions.
+type AutoMap struct {
+	// Map the networks (or storage) used by the VMs.
+	// Default: all.
+	VMs []ref.Ref `json:"vms,omitempty"`
+	// Target namespace.
+	// Limits the suggested network attachment definitions
+	// to the namespace and the `default` namespace.
+	Namespace string `json:"namespace,omitempty"`
+	// Minimum suggestion confidence (0-100).
+	// Default: 50.
+	MinConfidence int `json:"minConfidence,omitempty"`
+}
+
+//
+// Get the minimum confidence.
+func (r *AutoMap) GetMinConfidence() int {
+	if r.MinConfidence > 0 {
+		return r.MinConfidence
+	}
+
+	return DefaultMinConfidence
+}
+
 //
 // Network map spec.
 type NetworkMapSpec struct {
@@ -74,6 +125,8 @@ type NetworkMapSpec struct {
 	Provider provider.Pair `json:"provider"`
 	// Map.
 	Map []NetworkPair `json:"map"`
+	// Automatic mapping.
+	AutoMap *AutoMap `json:"autoMap,omitempty"`
 }
 
 //
@@ -83,6 +136,8 @@ type StorageMapSpec struct {
 	Provider provider.Pair `json:"provider"`
 	// Map.
 	Map []StoragePair `json:"map"`
+	// Automatic mapping.
+	AutoMap *AutoMap `json:"autoMap,omitempty"`
 }
 
 //
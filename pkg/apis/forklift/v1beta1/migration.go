@@ -22,6 +22,7 @@ import (
 	"github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1/ref"
 	core "k8s.io/api/core/v1"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"time"
 )
 
 //
@@ -34,18 +35,103 @@ type MigrationSpec struct {
 	// Date and time to finalize a warm migration.
 	// If present, this will override the value set on the Plan.
 	Cutover *meta.Time `json:"cutover,omitempty"`
+	// Pause the migration.
+	// While paused, VMs are not started, VMs do not advance to the
+	// next pipeline step, and warm migrations are not cut over.
+	// Disk transfers already in progress are allowed to finish
+	// but the next warm precopy is not started.
+	Pause bool `json:"pause,omitempty"`
+	// List of VMs which will have their migrations paused.
+	PauseVMs []ref.Ref `json:"pauseVMs,omitempty"`
+	// Per-VM date and time to finalize a warm migration.
+	// If present, this will override the value set on the Migration.
+	VMCutovers []VMCutover `json:"vmCutovers,omitempty"`
+	// Date and time to start the migration.
+	// Default: immediately.
+	StartAt *meta.Time `json:"startAt,omitempty"`
+	// Deadline after which VMs not yet started are skipped.
+	NotAfter *meta.Time `json:"notAfter,omitempty"`
+}
+
+//
+// Determine whether the migration is due to start.
+// Returns the time remaining when not due.
+func (r *MigrationSpec) Due() (due bool, wait time.Duration) {
+	if r.StartAt == nil {
+		due = true
+		return
+	}
+	wait = time.Until(r.StartAt.Time)
+	due = wait <= 0
+	if due {
+		wait = 0
+	}
+
+	return
+}
+
+//
+// Determine whether the deadline has passed.
+func (r *MigrationSpec) Expired() bool {
+	return r.NotAfter != nil && time.Now().After(r.NotAfter.Time)
+}
+
+//
+// Determine whether the schedule is valid.
+// The deadline must be after the start time.
+func (r *MigrationSpec) ScheduleValid() bool {
+	return r.StartAt == nil || r.NotAfter == nil || r.NotAfter.After(r.StartAt.Time)
+}
+
+//
+// VM cutover.
+type VMCutover struct {
+	// VM reference.
+	ref.Ref `json:",inline"`
+	// Date and time to finalize the warm migration.
+	Cutover *meta.Time `json:"cutover,omitempty"`
+}
+
+//
+// Get the cutover for a VM.
+// The per-VM cutover takes precedence.
+func (r *MigrationSpec) CutoverFor(ref ref.Ref) *meta.Time {
+	if ref.ID != "" {
+		for _, vm := range r.VMCutovers {
+			if vm.ID == ref.ID {
+				return vm.Cutover
+			}
+		}
+	}
+
+	return r.Cutover
 }
 
 //
 // Canceled indicates whether a VM ref is present
 // in the list of VM refs to be canceled.
 func (r *MigrationSpec) Canceled(ref ref.Ref) (found bool) {
+	found = r.hasRef(r.Cancel, ref)
+	return
+}
+
+//
+// Paused indicates whether the migration is paused
+// or a VM ref is present in the list of VM refs to be paused.
+func (r *MigrationSpec) Paused(ref ref.Ref) (found bool) {
+	found = r.Pause || r.hasRef(r.PauseVMs, ref)
+	return
+}
+
+//
+// Find a VM ref in a list by ID.
+func (r *MigrationSpec) hasRef(list []ref.Ref, ref ref.Ref) (found bool) {
 	if ref.ID == "" {
 		return
 	}
 
-	for _, vm := range r.Cancel {
-		// the refs in the Cancel array might not have
+	for _, vm := range list {
+		// the refs in the list might not have
 		// all been resolved successfully, so skip
 		// over any VMs that don't have an ID set.
 		if vm.ID == "" {
@@ -69,8 +155,15 @@ type MigrationStatus struct {
 	// The most recent generation observed by the controller.
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
-	// VM status
+	// Deprecated: VM status recorded prior to the VMMigration CRs.
+	// Cleared when the VM references are reflected. Retained until
+	// the next API version.
 	VMs []*plan.VMStatus `json:"vms,omitempty"`
+	// VM references.
+	// The VM status is stored in the referenced VMMigration CRs.
+	VMRefs []plan.VMRef `json:"vmRefs,omitempty"`
+	// VM counts.
+	Summary plan.VMSummary `json:"summary,omitempty"`
 }
 
 //
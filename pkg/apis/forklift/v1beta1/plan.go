@@ -38,10 +38,37 @@ type PlanSpec struct {
 	Map plan.Map `json:"map"`
 	// List of VMs.
 	VMs []plan.VM `json:"vms"`
+	// Select (additional) VMs by label.
+	// vSphere:
+	//   Tags (category=name) and custom attributes (name=value).
+	VMSelector *meta.LabelSelector `json:"vmSelector,omitempty"`
+	// Target VM overrides.
+	// Applied to all VMs listed on the plan.
+	TargetVM *plan.TargetVM `json:"targetVM,omitempty"`
+	// Source VM lifecycle actions.
+	SourceVM *plan.SourceVM `json:"sourceVM,omitempty"`
+	// Post-migration verification.
+	Verify *plan.Verify `json:"verify,omitempty"`
+	// Target VM naming policy.
+	Naming *plan.Naming `json:"naming,omitempty"`
+	// Static IP preservation.
+	StaticIPs *plan.StaticIPs `json:"staticIPs,omitempty"`
+	// Warm migration settings.
+	WarmPolicy *plan.WarmPolicy `json:"warmPolicy,omitempty"`
+	// Continuous replication (warm).
+	Replication *plan.Replication `json:"replication,omitempty"`
 	// Whether this is a warm migration.
 	Warm bool `json:"warm,omitempty"`
+	// Scheduling priority.
+	// Plans with a higher priority are given precedence
+	// when VMs are started on shared provider capacity.
+	// Default: 0.
+	Priority int `json:"priority,omitempty"`
 	// The network attachment definition that should be used for disk transfer.
 	TransferNetwork *core.ObjectReference `json:"transferNetwork,omitempty"`
+	// Transfer network selection by source host or cluster.
+	// Takes precedence over the `transferNetwork`.
+	TransferNetworks []plan.TransferNetwork `json:"transferNetworks,omitempty"`
 }
 
 //
@@ -58,6 +85,53 @@ func (r *PlanSpec) FindVM(ref ref.Ref) (v *plan.VM, found bool) {
 	return
 }
 
+//
+// Target VM overrides for a planned VM.
+// The VM overrides are merged with the plan overrides.
+func (r *PlanSpec) FindTargetVM(vm *plan.VM) *plan.TargetVM {
+	return r.TargetVM.Merge(vm.TargetVM)
+}
+
+//
+// Whether the source VM is shut down before the migration.
+// Only cold migrations are supported.
+func (r *PlanSpec) ShutdownSource() bool {
+	return !r.Warm &&
+		r.SourceVM != nil &&
+		r.SourceVM.Shutdown != nil
+}
+
+//
+// Whether actions are performed on the source VM after the migration.
+func (r *PlanSpec) UpdateSource() bool {
+	return r.SourceVM != nil &&
+		r.SourceVM.AfterMigration != nil &&
+		r.SourceVM.AfterMigration.HasActions()
+}
+
+//
+// Whether the VMs are continuously replicated.
+func (r *PlanSpec) Replicating() bool {
+	return r.Warm && r.Replication != nil
+}
+
+//
+// Whether the source VM is snapshot before the migration.
+// Only cold migrations are supported.
+func (r *PlanSpec) SnapshotSource() bool {
+	return !r.Warm &&
+		r.SourceVM != nil &&
+		r.SourceVM.Snapshot != nil
+}
+
+//
+// Whether the source VM snapshot is removed after
+// a successful migration.
+func (r *PlanSpec) RemoveSnapshot() bool {
+	return r.SnapshotSource() &&
+		!r.SourceVM.Snapshot.Retain
+}
+
 //
 // PlanStatus defines the observed state of Plan.
 type PlanStatus struct {
@@ -68,6 +142,11 @@ type PlanStatus struct {
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 	// Migration
 	Migration plan.MigrationStatus `json:"migration,omitempty"`
+	// VMs matched by the VM selector.
+	// The selection is resolved while the plan is not
+	// executing and retained for the duration of the migration.
+	// +optional
+	SelectedVMs []ref.Ref `json:"selectedVMs,omitempty"`
 }
 
 //
@@ -90,6 +169,18 @@ type Plan struct {
 	Referenced `json:"-"`
 }
 
+//
+// Add the VMs matched by the VM selector to the VM list.
+// The selection is recorded in the status and must be added
+// again after the plan has been updated.
+func (r *Plan) AddSelectedVMs() {
+	for _, vmRef := range r.Status.SelectedVMs {
+		if _, found := r.Spec.FindVM(vmRef); !found {
+			r.Spec.VMs = append(r.Spec.VMs, plan.VM{Ref: vmRef})
+		}
+	}
+}
+
 //
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 type PlanList struct {
@@ -38,8 +38,77 @@ type MigrationStatus struct {
 	Timed `json:",inline,omitempty"`
 	// History
 	History []Snapshot `json:"history,omitempty"`
-	// VM status
-	VMs []*VMStatus `json:"vms,omitempty"`
+	// VM status.
+	// Not persisted on the plan. Loaded from (and stored
+	// in) the VMMigration CRs.
+	VMs []*VMStatus `json:"-"`
+	// Deprecated: VM status recorded on the plan prior to the
+	// VMMigration CRs. Converted to VMMigration CRs and cleared.
+	// Retained until the next API version.
+	LegacyVMs []*VMStatus `json:"vms,omitempty"`
+	// VM references.
+	VMRefs []VMRef `json:"vmRefs,omitempty"`
+	// VM counts.
+	Summary VMSummary `json:"summary,omitempty"`
+}
+
+//
+// Reflect the VM status in the references and summary.
+// The resource is the name of the VMMigration CR.
+func (r *MigrationStatus) Reflect(resource func(vm *VMStatus) string) {
+	r.VMRefs = []VMRef{}
+	r.Summary = VMSummary{}
+	for _, vm := range r.VMs {
+		r.VMRefs = append(
+			r.VMRefs,
+			VMRef{
+				Ref:      vm.Ref,
+				Phase:    vm.Phase,
+				Resource: resource(vm),
+			})
+		r.Summary.Add(vm)
+	}
+}
+
+//
+// VM reference.
+// The VM migration status is stored in the
+// referenced VMMigration CR.
+type VMRef struct {
+	ref.Ref `json:",inline"`
+	// Phase.
+	Phase string `json:"phase,omitempty"`
+	// The VMMigration CR name.
+	Resource string `json:"resource,omitempty"`
+}
+
+//
+// VM migration counts.
+type VMSummary struct {
+	Total     int `json:"total"`
+	Pending   int `json:"pending"`
+	Running   int `json:"running"`
+	Succeeded int `json:"succeeded"`
+	Failed    int `json:"failed"`
+	Canceled  int `json:"canceled"`
+}
+
+//
+// Count the VM.
+func (r *VMSummary) Add(vm *VMStatus) {
+	r.Total++
+	switch {
+	case vm.HasCondition("Succeeded"):
+		r.Succeeded++
+	case vm.HasCondition("Failed"):
+		r.Failed++
+	case vm.HasCondition("Canceled"):
+		r.Canceled++
+	case vm.Running():
+		r.Running++
+	default:
+		r.Pending++
+	}
 }
 
 //
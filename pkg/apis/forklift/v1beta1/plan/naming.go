@@ -0,0 +1,18 @@
+package plan
+
+//
+// Target VM naming policy.
+type Naming struct {
+	// Name template (text/template).
+	// Fields: Name, ID, Folder, Plan, Namespace.
+	// Folder: the (vSphere) folder or (oVirt) cluster name.
+	// Example: {{.Folder}}-{{.Name}}
+	// Default: {{.Name}}
+	Template string `json:"template,omitempty"`
+	// Sanitize the name as a valid DNS-1123 label: lowercase,
+	// invalid characters replaced and truncated with a hash suffix.
+	Sanitize bool `json:"sanitize,omitempty"`
+	// Resolve collisions with existing VMs (and other VMs
+	// listed on the plan) by appending a numeric suffix.
+	Unique bool `json:"unique,omitempty"`
+}
@@ -0,0 +1,23 @@
+package plan
+
+//
+// Continuous replication.
+// The VMs are precopied (warm) until failed over. Automatic
+// cutover is disabled; the (real) failover is triggered by
+// setting the cutover on the Migration. Test failovers clone
+// the latest synced disks into the test namespace.
+type Replication struct {
+	// Namespace for test failovers.
+	// Default: <target namespace>-test.
+	TestNamespace string `json:"testNamespace,omitempty"`
+}
+
+//
+// Get the test namespace.
+func (r *Replication) GetTestNamespace(targetNamespace string) string {
+	if r.TestNamespace != "" {
+		return r.TestNamespace
+	}
+
+	return targetNamespace + "-test"
+}
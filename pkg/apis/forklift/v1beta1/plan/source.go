@@ -0,0 +1,82 @@
+package plan
+
+//
+// Defaults.
+const (
+	// Guest shutdown timeout (seconds).
+	DefaultShutdownTimeout = 300
+)
+
+//
+// Source VM lifecycle actions.
+type SourceVM struct {
+	// Shut down the source VM before a cold migration.
+	Shutdown *Shutdown `json:"shutdown,omitempty"`
+	// Snapshot the source VM before the copy begins.
+	Snapshot *SourceSnapshot `json:"snapshot,omitempty"`
+	// Actions performed on the source VM after
+	// the VM has been successfully migrated.
+	AfterMigration *AfterMigration `json:"afterMigration,omitempty"`
+}
+
+//
+// Guest shutdown.
+type Shutdown struct {
+	// Guest shutdown timeout (seconds).
+	// Default: 300.
+	Timeout int `json:"timeout,omitempty"`
+	// Power off the VM when the guest has not
+	// shut down within the timeout.
+	Force bool `json:"force,omitempty"`
+}
+
+//
+// Get the timeout (seconds).
+func (r *Shutdown) GetTimeout() int {
+	if r.Timeout > 0 {
+		return r.Timeout
+	}
+
+	return DefaultShutdownTimeout
+}
+
+//
+// Source VM (safety) snapshot.
+// Created before the disks are copied and removed after
+// the VM has been successfully migrated and verified.
+// Retained when the VM migration (or verification) fails.
+type SourceSnapshot struct {
+	// Snapshot name.
+	// Default: forklift-<plan name>.
+	Name string `json:"name,omitempty"`
+	// Retain the snapshot after a successful migration.
+	Retain bool `json:"retain,omitempty"`
+}
+
+//
+// Source VM actions after migration.
+type AfterMigration struct {
+	// Leave the source VM powered off.
+	PowerOff bool `json:"powerOff,omitempty"`
+	// Suffix appended to the source VM name.
+	NameSuffix string `json:"nameSuffix,omitempty"`
+	// Folder (path) the source VM is moved into.
+	// vSphere only.
+	Folder string `json:"folder,omitempty"`
+	// Name of an existing tag attached to the source VM.
+	Tag string `json:"tag,omitempty"`
+	// Disable automatic start of the source VM.
+	// vSphere: host autostart.
+	// oVirt: high availability.
+	DisableAutostart bool `json:"disableAutostart,omitempty"`
+}
+
+//
+// Has actions.
+func (r *AfterMigration) HasActions() bool {
+	return r.PowerOff ||
+		r.NameSuffix != "" ||
+		r.Folder != "" ||
+		r.Tag != "" ||
+		r.DisableAutostart
+}
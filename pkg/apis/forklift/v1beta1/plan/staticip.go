@@ -0,0 +1,132 @@
+package plan
+
+import (
+	"fmt"
+	"net"
+)
+
+//
+// Guest OS families.
+const (
+	GuestLinux   = "linux"
+	GuestWindows = "windows"
+)
+
+//
+// Static IP preservation.
+// The static IP configuration of the source guest is captured
+// before the source VM is shut down and injected (cloud-init NoCloud
+// network data) into the target VM. The addresses are assigned to the
+// NICs by MAC address and the interface names reported by the guest
+// agent are preserved. Requires cloud-init (Linux) or
+// cloudbase-init (Windows) to be installed in the source guest;
+// neither is installed by the migration.
+// The addresses are reported by the guest agent (VMware Tools or
+// the oVirt guest agent) which must be running when the migration
+// is started.
+type StaticIPs struct {
+	// IP address mappings.
+	// Source addresses not mapped are preserved.
+	Map []IPMapping `json:"map,omitempty"`
+	// Fail the VM migration when the guest network cannot be
+	// configured: no static addresses captured or the guest OS
+	// is not supported. Otherwise, a warning is reported.
+	Required bool `json:"required,omitempty"`
+	// cloudbase-init is installed in the Windows guests.
+	// The installed software is not reported by the guest agents.
+	// Otherwise, the network of Windows guests is not configured.
+	CloudbaseInit bool `json:"cloudbaseInit,omitempty"`
+}
+
+//
+// Find the mapping for a source IP address.
+func (r *StaticIPs) Find(address string) (mapping *IPMapping, found bool) {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return
+	}
+	for i := range r.Map {
+		m := &r.Map[i]
+		if ip.Equal(net.ParseIP(m.Source)) {
+			found = true
+			mapping = m
+			break
+		}
+	}
+
+	return
+}
+
+//
+// IP address mapping.
+type IPMapping struct {
+	// Source IP address.
+	Source string `json:"source"`
+	// Destination IP address (CIDR).
+	Destination string `json:"destination"`
+	// Default gateway.
+	// Default: the source gateway.
+	Gateway string `json:"gateway,omitempty"`
+	// DNS servers.
+	// Default: the source DNS servers.
+	DNS []string `json:"dns,omitempty"`
+}
+
+//
+// Destination IP address (without the prefix length).
+// Not valid when the destination is not CIDR notation.
+func (r *IPMapping) DestinationIP() (address string, valid bool) {
+	ip, _, err := net.ParseCIDR(r.Destination)
+	if err != nil {
+		return
+	}
+	address = ip.String()
+	valid = true
+	return
+}
+
+//
+// Guest network configuration captured from the source.
+type GuestNetwork struct {
+	// OS family (linux|windows).
+	OS string `json:"os,omitempty"`
+	// Network interfaces.
+	Interfaces []GuestInterface `json:"interfaces,omitempty"`
+	// Default gateways.
+	Gateways []string `json:"gateways,omitempty"`
+	// DNS servers.
+	DNS []string `json:"dns,omitempty"`
+}
+
+//
+// Guest network interface.
+type GuestInterface struct {
+	// MAC address.
+	MAC string `json:"mac"`
+	// Interface name (when reported by the guest agent).
+	Name string `json:"name,omitempty"`
+	// Static IP addresses (CIDR).
+	Addresses []string `json:"addresses"`
+}
+
+//
+// Format a guest IP address (CIDR).
+// The prefix length (when not reported) defaults to
+// /24 (IPv4) and /64 (IPv6). Link-local addresses are
+// not valid.
+func GuestAddress(address string, prefix int) (cidr string, valid bool) {
+	ip := net.ParseIP(address)
+	if ip == nil || ip.IsLinkLocalUnicast() || ip.IsLoopback() {
+		return
+	}
+	if prefix == 0 {
+		if ip.To4() != nil {
+			prefix = 24
+		} else {
+			prefix = 64
+		}
+	}
+	cidr = fmt.Sprintf("%s/%d", ip.String(), prefix)
+	valid = true
+	return
+}
@@ -0,0 +1,129 @@
+package plan
+
+import (
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+//
+// Instance type and preference kinds.
+const (
+	InstanceTypeKind        = "VirtualMachineInstancetype"
+	ClusterInstanceTypeKind = "VirtualMachineClusterInstancetype"
+	PreferenceKind          = "VirtualMachinePreference"
+	ClusterPreferenceKind   = "VirtualMachineClusterPreference"
+)
+
+//
+// Run strategies.
+const (
+	RunStrategyAlways         = "Always"
+	RunStrategyHalted         = "Halted"
+	RunStrategyManual         = "Manual"
+	RunStrategyRerunOnFailure = "RerunOnFailure"
+)
+
+//
+// Target (destination) VM overrides.
+// Applied to the VirtualMachine created by the import.
+type TargetVM struct {
+	// CPU topology.
+	CPU *CPU `json:"cpu,omitempty"`
+	// Guest memory.
+	Memory *resource.Quantity `json:"memory,omitempty"`
+	// Machine type. Example: q35.
+	MachineType string `json:"machineType,omitempty"`
+	// KubeVirt instance type.
+	// Mutually exclusive with CPU and Memory.
+	InstanceType *Matcher `json:"instanceType,omitempty"`
+	// KubeVirt preference.
+	Preference *Matcher `json:"preference,omitempty"`
+	// Node selector.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Tolerations.
+	Tolerations []core.Toleration `json:"tolerations,omitempty"`
+	// Labels.
+	Labels map[string]string `json:"labels,omitempty"`
+	// Annotations.
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// Run strategy (Always|Halted|Manual|RerunOnFailure).
+	RunStrategy string `json:"runStrategy,omitempty"`
+}
+
+//
+// CPU topology.
+type CPU struct {
+	Sockets uint32 `json:"sockets,omitempty"`
+	Cores   uint32 `json:"cores,omitempty"`
+	Threads uint32 `json:"threads,omitempty"`
+}
+
+//
+// Instance type (or preference) matcher.
+type Matcher struct {
+	// Name.
+	Name string `json:"name"`
+	// Kind. Defaults to the cluster scoped kind.
+	Kind string `json:"kind,omitempty"`
+}
+
+//
+// Merge the override into a copy.
+// Fields set on the override replace fields on the
+// receiver; maps are merged by key. Either may be nil.
+func (r *TargetVM) Merge(override *TargetVM) (merged *TargetVM) {
+	if r == nil && override == nil {
+		return
+	}
+	merged = &TargetVM{}
+	if r != nil {
+		r.DeepCopyInto(merged)
+	}
+	if override == nil {
+		return
+	}
+	in := override.DeepCopy()
+	if in.CPU != nil {
+		merged.CPU = in.CPU
+	}
+	if in.Memory != nil {
+		merged.Memory = in.Memory
+	}
+	if in.MachineType != "" {
+		merged.MachineType = in.MachineType
+	}
+	if in.InstanceType != nil {
+		merged.InstanceType = in.InstanceType
+	}
+	if in.Preference != nil {
+		merged.Preference = in.Preference
+	}
+	if in.Tolerations != nil {
+		merged.Tolerations = in.Tolerations
+	}
+	if in.RunStrategy != "" {
+		merged.RunStrategy = in.RunStrategy
+	}
+	merged.NodeSelector = r.mergeMap(merged.NodeSelector, in.NodeSelector)
+	merged.Labels = r.mergeMap(merged.Labels, in.Labels)
+	merged.Annotations = r.mergeMap(merged.Annotations, in.Annotations)
+
+	return
+}
+
+//
+// Merge maps.
+func (r *TargetVM) mergeMap(in, override map[string]string) (merged map[string]string) {
+	if in == nil && override == nil {
+		return
+	}
+	merged = map[string]string{}
+	for k, v := range in {
+		merged[k] = v
+	}
+	for k, v := range override {
+		merged[k] = v
+	}
+
+	return
+}
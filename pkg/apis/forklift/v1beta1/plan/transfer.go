@@ -0,0 +1,42 @@
+package plan
+
+import (
+	"github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1/ref"
+	core "k8s.io/api/core/v1"
+)
+
+//
+// Transfer network selection.
+// Selects the network used for disk transfer for the VMs
+// running on a source host or in a source cluster.
+// Exactly one of `host` or `cluster` must be set.
+type TransferNetwork struct {
+	// Source host.
+	Host *ref.Ref `json:"host,omitempty"`
+	// Source cluster.
+	Cluster *ref.Ref `json:"cluster,omitempty"`
+	// Network attachment definition.
+	Network core.ObjectReference `json:"network"`
+}
+
+//
+// Match a source host.
+func (r *TransferNetwork) MatchHost(host ref.Ref) bool {
+	return r.Host != nil && r.match(r.Host, host)
+}
+
+//
+// Match a source cluster.
+func (r *TransferNetwork) MatchCluster(cluster ref.Ref) bool {
+	return r.Cluster != nil && r.match(r.Cluster, cluster)
+}
+
+//
+// Match a reference by ID (when set) or name.
+func (r *TransferNetwork) match(selector *ref.Ref, in ref.Ref) bool {
+	if selector.ID != "" {
+		return selector.ID == in.ID
+	}
+
+	return selector.Name != "" && selector.Name == in.Name
+}
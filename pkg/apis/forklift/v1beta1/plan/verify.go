@@ -0,0 +1,73 @@
+package plan
+
+//
+// Verification failure policies.
+const (
+	// The VM migration fails.
+	FailurePolicyFail = "Fail"
+	// The failure is reported (advisory).
+	FailurePolicyWarn = "Warn"
+)
+
+//
+// Defaults.
+const (
+	// Verification timeout (seconds).
+	DefaultVerifyTimeout = 600
+)
+
+//
+// Post-migration verification.
+type Verify struct {
+	// Start the VM when not started by the migration.
+	Start bool `json:"start,omitempty"`
+	// Timeout (seconds).
+	// Default: 600.
+	Timeout int `json:"timeout,omitempty"`
+	// Wait for the QEMU guest agent to connect.
+	GuestAgent bool `json:"guestAgent,omitempty"`
+	// Check that the IP addresses reported by the
+	// source guest are reported by the VMI.
+	// Link-local addresses are not checked.
+	IPs bool `json:"ips,omitempty"`
+	// TCP ports that must respond.
+	// Checked on the VMI IP address by a job
+	// created in the target namespace.
+	Ports []int `json:"ports,omitempty"`
+	// Failure policy (Fail|Warn).
+	// Default: Fail.
+	FailurePolicy string `json:"failurePolicy,omitempty"`
+}
+
+//
+// Get the timeout (seconds).
+func (r *Verify) GetTimeout() int {
+	if r.Timeout > 0 {
+		return r.Timeout
+	}
+
+	return DefaultVerifyTimeout
+}
+
+//
+// Verification failures are advisory.
+func (r *Verify) Advisory() bool {
+	return r.FailurePolicy == FailurePolicyWarn
+}
+
+//
+// Post-migration verification results.
+type Verification struct {
+	// The VMI is running.
+	Running bool `json:"running"`
+	// The guest agent is connected.
+	GuestAgent bool `json:"guestAgent"`
+	// IP addresses reported by the VMI.
+	IPs []string `json:"ips,omitempty"`
+	// Expected IP addresses not reported by the VMI.
+	MissingIPs []string `json:"missingIPs,omitempty"`
+	// TCP ports responding.
+	OpenPorts []int `json:"openPorts,omitempty"`
+	// TCP ports not responding.
+	ClosedPorts []int `json:"closedPorts,omitempty"`
+}
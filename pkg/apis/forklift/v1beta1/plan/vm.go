@@ -7,6 +7,7 @@ import (
 	core "k8s.io/api/core/v1"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"path"
+	"strings"
 )
 
 //
@@ -29,8 +30,120 @@ func (r *HookRef) String() string {
 // A VM listed on the plan.
 type VM struct {
 	ref.Ref `json:",inline"`
+	// Target VM name.
+	// Takes precedence over the plan naming policy.
+	TargetName string `json:"targetName,omitempty"`
 	// Enable hooks.
 	Hooks []HookRef `json:"hooks,omitempty"`
+	// Target VM overrides.
+	// Merged with (and take precedence over) the plan overrides.
+	TargetVM *TargetVM `json:"targetVM,omitempty"`
+	// Disk overrides.
+	Disks []Disk `json:"disks,omitempty"`
+	// NIC overrides.
+	NICs []NIC `json:"nics,omitempty"`
+	// The network attachment definition used for disk transfer.
+	// Takes precedence over the plan transfer network selection.
+	TransferNetwork *core.ObjectReference `json:"transferNetwork,omitempty"`
+}
+
+//
+// Find a NIC override matching any of the identifiers.
+// MAC addresses are matched case insensitive.
+func (r *VM) FindNIC(ids ...string) (nic *NIC, found bool) {
+	for i := range r.NICs {
+		n := &r.NICs[i]
+		for _, id := range ids {
+			if id != "" && strings.EqualFold(n.ID, id) {
+				found = true
+				nic = n
+				return
+			}
+		}
+	}
+
+	return
+}
+
+//
+// Find a disk override by identifier.
+func (r *VM) FindDisk(id string) (disk *Disk, found bool) {
+	for i := range r.Disks {
+		d := &r.Disks[i]
+		if d.ID == id {
+			found = true
+			disk = d
+			break
+		}
+	}
+
+	return
+}
+
+//
+// Per-disk overrides.
+// Take precedence over the storage map.
+type Disk struct {
+	// Disk identifier.
+	// vSphere: backing file (without snapshot suffix).
+	// oVirt: disk ID.
+	ID string `json:"id"`
+	// Storage class.
+	StorageClass string `json:"storageClass,omitempty"`
+	// Volume mode.
+	// +kubebuilder:validation:Enum=Filesystem;Block
+	VolumeMode core.PersistentVolumeMode `json:"volumeMode,omitempty"`
+	// Access mode.
+	// +kubebuilder:validation:Enum=ReadWriteOnce;ReadWriteMany;ReadOnlyMany
+	AccessMode core.PersistentVolumeAccessMode `json:"accessMode,omitempty"`
+	// Exclude (do not copy) the disk.
+	// The disk is not attached to the target VM.
+	// Not supported by warm migration.
+	Exclude bool `json:"exclude,omitempty"`
+}
+
+//
+// The storage settings are overridden.
+func (r *Disk) Overridden() bool {
+	return r.StorageClass != "" || r.VolumeMode != "" || r.AccessMode != ""
+}
+
+//
+// Per-NIC overrides.
+// Take precedence over the network map.
+type NIC struct {
+	// NIC identifier: MAC address or,
+	// vSphere: device key.
+	// oVirt: NIC ID.
+	ID string `json:"id"`
+	// Destination network attachment definition.
+	Network *core.ObjectReference `json:"network,omitempty"`
+	// Connect to the pod network.
+	Pod bool `json:"pod,omitempty"`
+	// Interface model (virtio|e1000|e1000e|...).
+	Model string `json:"model,omitempty"`
+	// Exclude the NIC.
+	Exclude bool `json:"exclude,omitempty"`
+}
+
+//
+// The destination network is overridden.
+func (r *NIC) Overridden() bool {
+	return r.Pod || r.Network != nil
+}
+
+//
+// Destination network (pod|namespace/name).
+// Empty when not overridden.
+func (r *NIC) Destination() (name string) {
+	switch {
+	case r.Pod:
+		name = "pod"
+	case r.Network != nil:
+		name = path.Join(r.Network.Namespace, r.Network.Name)
+	}
+
+	return
 }
 
 //
@@ -52,6 +165,9 @@ func (r *VM) FindHook(step string) (ref HookRef, found bool) {
 type VMStatus struct {
 	Timed `json:",inline"`
 	VM    `json:",inline"`
+	// The (resolved) target VM name.
+	// Assigned when the migration is started and preserved.
+	TargetVMName string `json:"targetVMName,omitempty"`
 	// Migration pipeline.
 	Pipeline []*Step `json:"pipeline"`
 	// Phase
@@ -60,6 +176,10 @@ type VMStatus struct {
 	Error *Error `json:"error,omitempty"`
 	// Warm migration status
 	Warm *Warm `json:"warm,omitempty"`
+	// Post-migration verification results.
+	Verification *Verification `json:"verification,omitempty"`
+	// Guest network configuration captured from the source.
+	GuestNetwork *GuestNetwork `json:"guestNetwork,omitempty"`
 
 	// Conditions.
 	libcnd.Conditions `json:",inline"`
@@ -73,19 +193,38 @@ type Warm struct {
 	ConsecutiveFailures int        `json:"consecutiveFailures"`
 	NextPrecopyAt       *meta.Time `json:"nextPrecopyAt,omitempty"`
 	Precopies           []Precopy  `json:"precopies,omitempty"`
+	// Automatic cutover (triggered) time.
+	Cutover *meta.Time `json:"cutover,omitempty"`
+	// Automatic cutover reason.
+	CutoverReason string `json:"cutoverReason,omitempty"`
 }
 
 // Precopy durations
 type Precopy struct {
 	Start *meta.Time `json:"start,omitempty"`
 	End   *meta.Time `json:"end,omitempty"`
+	// Source snapshot (checkpoint) copied.
+	Snapshot string `json:"snapshot,omitempty"`
+	// Disk change IDs of the snapshot keyed by disk.
+	// Recorded when the precopy starts.
+	// Not reported by all providers.
+	ChangeIds map[string]string `json:"changeIds,omitempty"`
+	// Bytes changed (copied).
+	// Not reported by all providers.
+	Bytes *int64 `json:"bytes,omitempty"`
 }
 
 //
 // Find the `Active` step.
 func (r *VMStatus) ActiveStep() (step *Step, found bool) {
+	return r.FindStep(r.Phase)
+}
+
+//
+// Find a step by name.
+func (r *VMStatus) FindStep(name string) (step *Step, found bool) {
 	for _, s := range r.Pipeline {
-		if s.Name == r.Phase {
+		if s.Name == name {
 			found = true
 			step = s
 			break
@@ -0,0 +1,60 @@
+package plan
+
+//
+// Automatic cutover reasons.
+const (
+	// The maximum number of precopies has been reached.
+	CutoverMaxPrecopies = "MaxPrecopies"
+	// The last precopy delta is below the threshold.
+	CutoverDeltaThreshold = "DeltaThreshold"
+	// The consecutive precopy failure limit has been reached.
+	CutoverFailureLimit = "FailureLimit"
+)
+
+//
+// Warm migration settings.
+type WarmPolicy struct {
+	// Interval (minutes) between the start of precopies.
+	// Default: the interval configured on the VMIO controller.
+	PrecopyInterval int `json:"precopyInterval,omitempty"`
+	// Maximum number of (successful) precopies.
+	// The VM is cut over once reached.
+	MaxPrecopies int `json:"maxPrecopies,omitempty"`
+	// Delta threshold (MB).
+	// The VM is cut over once the bytes changed during the
+	// last precopy fall below the threshold.
+	// vSphere only.
+	DeltaThreshold int64 `json:"deltaThreshold,omitempty"`
+	// Consecutive precopy failure limit.
+	// The VM is cut over once reached.
+	FailureLimit int `json:"failureLimit,omitempty"`
+}
+
+//
+// Determine whether an automatic cutover is triggered.
+// Returns the reason.
+func (r *WarmPolicy) Cutover(warm *Warm) (reason string, triggered bool) {
+	if warm == nil {
+		return
+	}
+	if r.MaxPrecopies > 0 && warm.Successes >= r.MaxPrecopies {
+		reason = CutoverMaxPrecopies
+		triggered = true
+		return
+	}
+	if r.FailureLimit > 0 && warm.ConsecutiveFailures >= r.FailureLimit {
+		reason = CutoverFailureLimit
+		triggered = true
+		return
+	}
+	if r.DeltaThreshold > 0 && len(warm.Precopies) > 1 {
+		last := warm.Precopies[len(warm.Precopies)-1]
+		if last.Bytes != nil && *last.Bytes < r.DeltaThreshold*0x100000 {
+			reason = CutoverDeltaThreshold
+			triggered = true
+			return
+		}
+	}
+
+	return
+}
@@ -0,0 +1,121 @@
+package plan
+
+import (
+	"github.com/onsi/gomega"
+	"testing"
+)
+
+func TestWarmPolicyCutover(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	mb := func(n int64) *int64 {
+		n = n * 0x100000
+		return &n
+	}
+	cases := []struct {
+		name      string
+		policy    WarmPolicy
+		warm      *Warm
+		reason    string
+		triggered bool
+	}{
+		{
+			name:   "no status",
+			policy: WarmPolicy{MaxPrecopies: 1},
+		},
+		{
+			name:   "no limits",
+			policy: WarmPolicy{},
+			warm: &Warm{
+				Successes:           10,
+				ConsecutiveFailures: 10,
+			},
+		},
+		{
+			name:   "max precopies not reached",
+			policy: WarmPolicy{MaxPrecopies: 3},
+			warm:   &Warm{Successes: 2},
+		},
+		{
+			name:      "max precopies reached",
+			policy:    WarmPolicy{MaxPrecopies: 3},
+			warm:      &Warm{Successes: 3},
+			reason:    CutoverMaxPrecopies,
+			triggered: true,
+		},
+		{
+			name:   "failure limit not reached",
+			policy: WarmPolicy{FailureLimit: 2},
+			warm: &Warm{
+				Failures:            5,
+				ConsecutiveFailures: 1,
+			},
+		},
+		{
+			name:      "failure limit reached",
+			policy:    WarmPolicy{FailureLimit: 2},
+			warm:      &Warm{ConsecutiveFailures: 2},
+			reason:    CutoverFailureLimit,
+			triggered: true,
+		},
+		{
+			name:   "delta threshold first precopy",
+			policy: WarmPolicy{DeltaThreshold: 10},
+			warm: &Warm{
+				Precopies: []Precopy{
+					{Bytes: mb(1)},
+				},
+			},
+		},
+		{
+			name:   "delta threshold bytes not reported",
+			policy: WarmPolicy{DeltaThreshold: 10},
+			warm: &Warm{
+				Precopies: []Precopy{
+					{Bytes: mb(100)},
+					{},
+				},
+			},
+		},
+		{
+			name:   "delta threshold not reached",
+			policy: WarmPolicy{DeltaThreshold: 10},
+			warm: &Warm{
+				Precopies: []Precopy{
+					{Bytes: mb(100)},
+					{Bytes: mb(10)},
+				},
+			},
+		},
+		{
+			name:   "delta threshold reached",
+			policy: WarmPolicy{DeltaThreshold: 10},
+			warm: &Warm{
+				Precopies: []Precopy{
+					{Bytes: mb(100)},
+					{Bytes: mb(9)},
+				},
+			},
+			reason:    CutoverDeltaThreshold,
+			triggered: true,
+		},
+		{
+			name: "max precopies precedes failure limit",
+			policy: WarmPolicy{
+				MaxPrecopies: 1,
+				FailureLimit: 1,
+			},
+			warm: &Warm{
+				Successes:           1,
+				ConsecutiveFailures: 1,
+			},
+			reason:    CutoverMaxPrecopies,
+			triggered: true,
+		},
+	}
+	for _, c := range cases {
+		reason, triggered := c.policy.Cutover(c.warm)
+		g.Expect(triggered).To(gomega.Equal(c.triggered), c.name)
+		g.Expect(reason).To(gomega.Equal(c.reason), c.name)
+	}
+}
@@ -0,0 +1,70 @@
+package v1beta1
+
+import (
+	libcnd "github.com/konveyor/controller/pkg/condition"
+	"github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1/plan"
+	"github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1/ref"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+//
+// RollbackSpec defines the desired state of Rollback.
+type RollbackSpec struct {
+	// Reference to the associated Plan.
+	Plan core.ObjectReference `json:"plan" ref:"Plan"`
+	// List of migrated VMs to be rolled back.
+	VMs []ref.Ref `json:"vms"`
+	// Preserve the destination VM and DataVolumes.
+	// When true, the destination VM is stopped but not deleted.
+	Preserve bool `json:"preserve,omitempty"`
+}
+
+//
+// RollbackStatus defines the observed state of Rollback.
+type RollbackStatus struct {
+	plan.Timed `json:",inline"`
+	// Conditions.
+	libcnd.Conditions `json:",inline"`
+	// The most recent generation observed by the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+//
+// Rollback of migrated VMs to the source provider.
+// The destination VM is stopped and deleted (or preserved)
+// and the source VM is powered on.
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="SUCCEEDED",type=string,JSONPath=".status.conditions[?(@.type=='Succeeded')].status"
+// +kubebuilder:printcolumn:name="FAILED",type=string,JSONPath=".status.conditions[?(@.type=='Failed')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+type Rollback struct {
+	meta.TypeMeta   `json:",inline"`
+	meta.ObjectMeta `json:"metadata,omitempty"`
+	Spec            RollbackSpec   `json:"spec,omitempty"`
+	Status          RollbackStatus `json:"status,omitempty"`
+}
+
+//
+// Match plan.
+func (r *Rollback) Match(plan *Plan) bool {
+	ref := r.Spec.Plan
+	return ref.Namespace == plan.Namespace &&
+		ref.Name == plan.Name
+}
+
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type RollbackList struct {
+	meta.TypeMeta `json:",inline"`
+	meta.ListMeta `json:"metadata,omitempty"`
+	Items         []Rollback `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Rollback{}, &RollbackList{})
+}
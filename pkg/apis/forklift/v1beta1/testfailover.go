@@ -0,0 +1,99 @@
+package v1beta1
+
+import (
+	libcnd "github.com/konveyor/controller/pkg/condition"
+	"github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1/plan"
+	"github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1/ref"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+//
+// TestFailoverSpec defines the desired state of TestFailover.
+type TestFailoverSpec struct {
+	// Reference to the associated (replicating) Plan.
+	Plan core.ObjectReference `json:"plan" ref:"Plan"`
+	// List of VMs to be tested.
+	// Default: all VMs being replicated.
+	VMs []ref.Ref `json:"vms,omitempty"`
+}
+
+//
+// TestFailoverStatus defines the observed state of TestFailover.
+type TestFailoverStatus struct {
+	plan.Timed `json:",inline"`
+	// Conditions.
+	libcnd.Conditions `json:",inline"`
+	// The most recent generation observed by the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Test VMs.
+	VMs []TestVM `json:"vms,omitempty"`
+}
+
+//
+// Test VM.
+type TestVM struct {
+	// Source VM reference.
+	ref.Ref `json:",inline"`
+	// Test VM namespace.
+	Namespace string `json:"namespace"`
+	// Test VM name.
+	TestName string `json:"testName"`
+	// Cloned DataVolumes.
+	DataVolumes []string `json:"dataVolumes,omitempty"`
+	// Created.
+	Created bool `json:"created"`
+}
+
+//
+// Find a test VM by source VM ID.
+func (r *TestFailoverStatus) FindVM(id string) (vm *TestVM, found bool) {
+	for i := range r.VMs {
+		if r.VMs[i].ID == id {
+			vm = &r.VMs[i]
+			found = true
+			return
+		}
+	}
+
+	return
+}
+
+//
+// Test failover of replicated VMs.
+// The latest synced disks are cloned into the (isolated)
+// test namespace and booted.
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="SUCCEEDED",type=string,JSONPath=".status.conditions[?(@.type=='Succeeded')].status"
+// +kubebuilder:printcolumn:name="FAILED",type=string,JSONPath=".status.conditions[?(@.type=='Failed')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+type TestFailover struct {
+	meta.TypeMeta   `json:",inline"`
+	meta.ObjectMeta `json:"metadata,omitempty"`
+	Spec            TestFailoverSpec   `json:"spec,omitempty"`
+	Status          TestFailoverStatus `json:"status,omitempty"`
+}
+
+//
+// Match plan.
+func (r *TestFailover) Match(plan *Plan) bool {
+	ref := r.Spec.Plan
+	return ref.Namespace == plan.Namespace &&
+		ref.Name == plan.Name
+}
+
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type TestFailoverList struct {
+	meta.TypeMeta `json:",inline"`
+	meta.ListMeta `json:"metadata,omitempty"`
+	Items         []TestFailover `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TestFailover{}, &TestFailoverList{})
+}
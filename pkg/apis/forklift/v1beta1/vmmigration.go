@@ -0,0 +1,49 @@
+package v1beta1
+
+import (
+	"github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1/plan"
+	"github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1/ref"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+//
+// VMMigrationSpec defines the desired state of VMMigration.
+type VMMigrationSpec struct {
+	// Reference to the associated Plan.
+	Plan core.ObjectReference `json:"plan"`
+	// Reference to the associated Migration.
+	Migration core.ObjectReference `json:"migration"`
+	// The VM.
+	VM ref.Ref `json:"vm"`
+}
+
+//
+// Migration of a VM listed on a plan.
+// Owned by the Migration.
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="VM",type=string,JSONPath=".spec.vm.name"
+// +kubebuilder:printcolumn:name="PHASE",type=string,JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="SUCCEEDED",type=string,JSONPath=".status.conditions[?(@.type=='Succeeded')].status"
+// +kubebuilder:printcolumn:name="FAILED",type=string,JSONPath=".status.conditions[?(@.type=='Failed')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+type VMMigration struct {
+	meta.TypeMeta   `json:",inline"`
+	meta.ObjectMeta `json:"metadata,omitempty"`
+	Spec            VMMigrationSpec `json:"spec,omitempty"`
+	Status          plan.VMStatus   `json:"status,omitempty"`
+}
+
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VMMigrationList struct {
+	meta.TypeMeta `json:",inline"`
+	meta.ListMeta `json:"metadata,omitempty"`
+	Items         []VMMigration `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VMMigration{}, &VMMigrationList{})
+}
@@ -47,9 +47,34 @@ func (in *AccessMode) DeepCopy() *AccessMode {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoMap) DeepCopyInto(out *AutoMap) {
+	*out = *in
+	if in.VMs != nil {
+		in, out := &in.VMs, &out.VMs
+		*out = make([]ref.Ref, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoMap.
+func (in *AutoMap) DeepCopy() *AutoMap {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoMap)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DestinationNetwork) DeepCopyInto(out *DestinationNetwork) {
 	*out = *in
+	if in.Generate != nil {
+		in, out := &in.Generate, &out.Generate
+		*out = new(GeneratedNetwork)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DestinationNetwork.
@@ -77,6 +102,21 @@ func (in *DestinationStorage) DeepCopy() *DestinationStorage {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GeneratedNetwork) DeepCopyInto(out *GeneratedNetwork) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GeneratedNetwork.
+func (in *GeneratedNetwork) DeepCopy() *GeneratedNetwork {
+	if in == nil {
+		return nil
+	}
+	out := new(GeneratedNetwork)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Hook) DeepCopyInto(out *Hook) {
 	*out = *in
@@ -195,6 +235,21 @@ func (in *Host) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostAdapter) DeepCopyInto(out *HostAdapter) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostAdapter.
+func (in *HostAdapter) DeepCopy() *HostAdapter {
+	if in == nil {
+		return nil
+	}
+	out := new(HostAdapter)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HostList) DeepCopyInto(out *HostList) {
 	*out = *in
@@ -249,6 +304,11 @@ func (in *HostSpec) DeepCopy() *HostSpec {
 func (in *HostStatus) DeepCopyInto(out *HostStatus) {
 	*out = *in
 	in.Conditions.DeepCopyInto(&out.Conditions)
+	if in.Adapter != nil {
+		in, out := &in.Adapter, &out.Adapter
+		*out = new(HostAdapter)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostStatus.
@@ -350,6 +410,26 @@ func (in *MigrationSpec) DeepCopyInto(out *MigrationSpec) {
 		in, out := &in.Cutover, &out.Cutover
 		*out = (*in).DeepCopy()
 	}
+	if in.PauseVMs != nil {
+		in, out := &in.PauseVMs, &out.PauseVMs
+		*out = make([]ref.Ref, len(*in))
+		copy(*out, *in)
+	}
+	if in.VMCutovers != nil {
+		in, out := &in.VMCutovers, &out.VMCutovers
+		*out = make([]VMCutover, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StartAt != nil {
+		in, out := &in.StartAt, &out.StartAt
+		*out = (*in).DeepCopy()
+	}
+	if in.NotAfter != nil {
+		in, out := &in.NotAfter, &out.NotAfter
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigrationSpec.
@@ -378,6 +458,12 @@ func (in *MigrationStatus) DeepCopyInto(out *MigrationStatus) {
 			}
 		}
 	}
+	if in.VMRefs != nil {
+		in, out := &in.VMRefs, &out.VMRefs
+		*out = make([]plan.VMRef, len(*in))
+		copy(*out, *in)
+	}
+	out.Summary = in.Summary
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigrationStatus.
@@ -457,7 +543,14 @@ func (in *NetworkMapSpec) DeepCopyInto(out *NetworkMapSpec) {
 	if in.Map != nil {
 		in, out := &in.Map, &out.Map
 		*out = make([]NetworkPair, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AutoMap != nil {
+		in, out := &in.AutoMap, &out.AutoMap
+		*out = new(AutoMap)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -475,7 +568,7 @@ func (in *NetworkMapSpec) DeepCopy() *NetworkMapSpec {
 func (in *NetworkPair) DeepCopyInto(out *NetworkPair) {
 	*out = *in
 	out.Source = in.Source
-	out.Destination = in.Destination
+	in.Destination.DeepCopyInto(&out.Destination)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPair.
@@ -560,11 +653,57 @@ func (in *PlanSpec) DeepCopyInto(out *PlanSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.VMSelector != nil {
+		in, out := &in.VMSelector, &out.VMSelector
+		*out = (*in).DeepCopy()
+	}
+	if in.TargetVM != nil {
+		in, out := &in.TargetVM, &out.TargetVM
+		*out = new(plan.TargetVM)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SourceVM != nil {
+		in, out := &in.SourceVM, &out.SourceVM
+		*out = new(plan.SourceVM)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Verify != nil {
+		in, out := &in.Verify, &out.Verify
+		*out = new(plan.Verify)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Naming != nil {
+		in, out := &in.Naming, &out.Naming
+		*out = new(plan.Naming)
+		**out = **in
+	}
+	if in.StaticIPs != nil {
+		in, out := &in.StaticIPs, &out.StaticIPs
+		*out = new(plan.StaticIPs)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WarmPolicy != nil {
+		in, out := &in.WarmPolicy, &out.WarmPolicy
+		*out = new(plan.WarmPolicy)
+		**out = **in
+	}
+	if in.Replication != nil {
+		in, out := &in.Replication, &out.Replication
+		*out = new(plan.Replication)
+		**out = **in
+	}
 	if in.TransferNetwork != nil {
 		in, out := &in.TransferNetwork, &out.TransferNetwork
 		*out = new(v1.ObjectReference)
 		**out = **in
 	}
+	if in.TransferNetworks != nil {
+		in, out := &in.TransferNetworks, &out.TransferNetworks
+		*out = make([]plan.TransferNetwork, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanSpec.
@@ -582,6 +721,11 @@ func (in *PlanStatus) DeepCopyInto(out *PlanStatus) {
 	*out = *in
 	in.Conditions.DeepCopyInto(&out.Conditions)
 	in.Migration.DeepCopyInto(&out.Migration)
+	if in.SelectedVMs != nil {
+		in, out := &in.SelectedVMs, &out.SelectedVMs
+		*out = make([]ref.Ref, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanStatus.
@@ -788,6 +932,103 @@ func (in *ProvisionerStatus) DeepCopy() *ProvisionerStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Rollback) DeepCopyInto(out *Rollback) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Rollback.
+func (in *Rollback) DeepCopy() *Rollback {
+	if in == nil {
+		return nil
+	}
+	out := new(Rollback)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Rollback) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollbackList) DeepCopyInto(out *RollbackList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Rollback, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollbackList.
+func (in *RollbackList) DeepCopy() *RollbackList {
+	if in == nil {
+		return nil
+	}
+	out := new(RollbackList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RollbackList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollbackSpec) DeepCopyInto(out *RollbackSpec) {
+	*out = *in
+	out.Plan = in.Plan
+	if in.VMs != nil {
+		in, out := &in.VMs, &out.VMs
+		*out = make([]ref.Ref, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollbackSpec.
+func (in *RollbackSpec) DeepCopy() *RollbackSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RollbackSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollbackStatus) DeepCopyInto(out *RollbackStatus) {
+	*out = *in
+	in.Timed.DeepCopyInto(&out.Timed)
+	in.Conditions.DeepCopyInto(&out.Conditions)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollbackStatus.
+func (in *RollbackStatus) DeepCopy() *RollbackStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RollbackStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StorageMap) DeepCopyInto(out *StorageMap) {
 	*out = *in
@@ -857,6 +1098,11 @@ func (in *StorageMapSpec) DeepCopyInto(out *StorageMapSpec) {
 		*out = make([]StoragePair, len(*in))
 		copy(*out, *in)
 	}
+	if in.AutoMap != nil {
+		in, out := &in.AutoMap, &out.AutoMap
+		*out = new(AutoMap)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageMapSpec.
@@ -886,6 +1132,228 @@ func (in *StoragePair) DeepCopy() *StoragePair {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TestFailover) DeepCopyInto(out *TestFailover) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TestFailover.
+func (in *TestFailover) DeepCopy() *TestFailover {
+	if in == nil {
+		return nil
+	}
+	out := new(TestFailover)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TestFailover) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TestFailoverList) DeepCopyInto(out *TestFailoverList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TestFailover, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TestFailoverList.
+func (in *TestFailoverList) DeepCopy() *TestFailoverList {
+	if in == nil {
+		return nil
+	}
+	out := new(TestFailoverList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TestFailoverList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TestFailoverSpec) DeepCopyInto(out *TestFailoverSpec) {
+	*out = *in
+	out.Plan = in.Plan
+	if in.VMs != nil {
+		in, out := &in.VMs, &out.VMs
+		*out = make([]ref.Ref, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TestFailoverSpec.
+func (in *TestFailoverSpec) DeepCopy() *TestFailoverSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TestFailoverSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TestFailoverStatus) DeepCopyInto(out *TestFailoverStatus) {
+	*out = *in
+	in.Timed.DeepCopyInto(&out.Timed)
+	in.Conditions.DeepCopyInto(&out.Conditions)
+	if in.VMs != nil {
+		in, out := &in.VMs, &out.VMs
+		*out = make([]TestVM, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TestFailoverStatus.
+func (in *TestFailoverStatus) DeepCopy() *TestFailoverStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TestFailoverStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TestVM) DeepCopyInto(out *TestVM) {
+	*out = *in
+	out.Ref = in.Ref
+	if in.DataVolumes != nil {
+		in, out := &in.DataVolumes, &out.DataVolumes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TestVM.
+func (in *TestVM) DeepCopy() *TestVM {
+	if in == nil {
+		return nil
+	}
+	out := new(TestVM)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMCutover) DeepCopyInto(out *VMCutover) {
+	*out = *in
+	out.Ref = in.Ref
+	if in.Cutover != nil {
+		in, out := &in.Cutover, &out.Cutover
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMCutover.
+func (in *VMCutover) DeepCopy() *VMCutover {
+	if in == nil {
+		return nil
+	}
+	out := new(VMCutover)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMMigration) DeepCopyInto(out *VMMigration) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMMigration.
+func (in *VMMigration) DeepCopy() *VMMigration {
+	if in == nil {
+		return nil
+	}
+	out := new(VMMigration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMMigration) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMMigrationList) DeepCopyInto(out *VMMigrationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VMMigration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMMigrationList.
+func (in *VMMigrationList) DeepCopy() *VMMigrationList {
+	if in == nil {
+		return nil
+	}
+	out := new(VMMigrationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMMigrationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMMigrationSpec) DeepCopyInto(out *VMMigrationSpec) {
+	*out = *in
+	out.Plan = in.Plan
+	out.Migration = in.Migration
+	out.VM = in.VM
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMMigrationSpec.
+func (in *VMMigrationSpec) DeepCopy() *VMMigrationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VMMigrationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeMode) DeepCopyInto(out *VolumeMode) {
 	*out = *in
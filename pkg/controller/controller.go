@@ -24,6 +24,7 @@ import (
 	"github.com/konveyor/forklift-controller/pkg/controller/migration"
 	"github.com/konveyor/forklift-controller/pkg/controller/plan"
 	"github.com/konveyor/forklift-controller/pkg/controller/provider"
+	"github.com/konveyor/forklift-controller/pkg/controller/provisioner"
 	"github.com/konveyor/forklift-controller/pkg/settings"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 )
@@ -46,6 +47,7 @@ var MainControllers = []AddFunction{
 	storage.Add,
 	host.Add,
 	hook.Add,
+	provisioner.Add,
 }
 
 //
@@ -71,6 +71,10 @@ func (r *Reconciler) validate(host *api.Host) error {
 	if err != nil {
 		return liberr.Wrap(err)
 	}
+	err = r.validateAdapter(host)
+	if err != nil {
+		return liberr.Wrap(err)
+	}
 	err = r.validateIp(host)
 	if err != nil {
 		return liberr.Wrap(err)
@@ -180,16 +184,58 @@ func (r *Reconciler) validateRef(host *api.Host) error {
 }
 
 //
-// Validate host ID field.
+// Pick the preferred VMkernel adapter.
+// The adapters are listed by the inventory in order of
+// preference (fastest link, then largest MTU). The adapter
+// with the `ipAddress` is preferred when set.
+func (r *Reconciler) validateAdapter(host *api.Host) error {
+	host.Status.Adapter = nil
+	if host.Status.HasCondition(RefNotValid) {
+		return nil
+	}
+	provider := host.Referenced.Provider.Source
+	if provider == nil || provider.Type() != api.VSphere {
+		return nil
+	}
+	inventory, err := web.NewClient(provider)
+	if err != nil {
+		return liberr.Wrap(err)
+	}
+	hostModel := &vsphere.Host{}
+	err = inventory.Find(hostModel, host.Spec.Ref)
+	if err != nil {
+		return liberr.Wrap(err)
+	}
+	for _, adapter := range hostModel.NetworkAdapters {
+		if adapter.IpAddress == "" {
+			continue
+		}
+		if host.Spec.IpAddress != "" && adapter.IpAddress != host.Spec.IpAddress {
+			continue
+		}
+		host.Status.Adapter = &api.HostAdapter{
+			Name:       adapter.Name,
+			IpAddress:  adapter.IpAddress,
+			SubnetMask: adapter.SubnetMask,
+		}
+		break
+	}
+
+	return nil
+}
+
+//
+// Validate host IP address.
+// Not required when a VMkernel adapter has been picked.
 func (r *Reconciler) validateIp(host *api.Host) error {
-	if host.Spec.IpAddress == "" {
+	if host.TransferIp() == "" {
 		host.Status.SetCondition(
 			libcnd.Condition{
 				Type:     IpNotValid,
 				Status:   True,
 				Reason:   NotSet,
 				Category: Critical,
-				Message:  "The `ipAddress` is not valid.",
+				Message:  "The `ipAddress` is not set and no VMkernel adapter found.",
 			})
 	}
 
@@ -275,7 +321,7 @@ func (r *Reconciler) testConnection(host *api.Host) (err error) {
 	var testErr error
 	switch provider.Type() {
 	case api.VSphere:
-		url := fmt.Sprintf("https://%s/sdk", host.Spec.IpAddress)
+		url := fmt.Sprintf("https://%s/sdk", host.TransferIp())
 		hostModel := &vsphere.Host{}
 		pErr := inventory.Find(hostModel, host.Spec.Ref)
 		if pErr != nil {
@@ -0,0 +1,111 @@
+package network
+
+import (
+	"errors"
+	libcnd "github.com/konveyor/controller/pkg/condition"
+	api "github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1"
+	"github.com/konveyor/forklift-controller/pkg/controller/provider/web"
+	"github.com/konveyor/forklift-controller/pkg/controller/provider/web/suggest"
+)
+
+//
+// Add entries for the unmapped source networks using
+// the inventory suggestions. Returns `updated` when
+// entries have been added to the spec.
+func (r *Reconciler) autoMap(mp *api.NetworkMap) (updated bool, err error) {
+	autoMap := mp.Spec.AutoMap
+	source := mp.Referenced.Provider.Source
+	destination := mp.Referenced.Provider.Destination
+	if source == nil || destination == nil {
+		return
+	}
+	inventory, err := web.NewClient(source)
+	if err != nil {
+		return
+	}
+	params := []web.Param{
+		{
+			Key:   suggest.DestinationParam,
+			Value: string(destination.UID),
+		},
+	}
+	if autoMap.Namespace != "" {
+		params = append(
+			params,
+			web.Param{
+				Key:   suggest.NamespaceParam,
+				Value: autoMap.Namespace,
+			})
+	}
+	notFound := []string{}
+	for _, ref := range autoMap.VMs {
+		_, pErr := inventory.VM(&ref)
+		if pErr != nil {
+			if errors.As(pErr, &web.NotFoundError{}) ||
+				errors.As(pErr, &web.RefNotUniqueError{}) {
+				notFound = append(notFound, ref.String())
+				continue
+			}
+			err = pErr
+			return
+		}
+		params = append(
+			params,
+			web.Param{
+				Key:   suggest.VMParam,
+				Value: ref.ID,
+			})
+	}
+	if len(notFound) > 0 {
+		mp.Status.SetCondition(libcnd.Condition{
+			Type:     AutoMapIncomplete,
+			Status:   True,
+			Reason:   NotFound,
+			Category: Warn,
+			Message:  "Auto-map: VM not found.",
+			Items:    notFound,
+		})
+	}
+	list := []suggest.NetworkSuggestion{}
+	err = inventory.List(&list, params...)
+	if err != nil {
+		return
+	}
+	unmatched := []string{}
+	for _, suggestion := range list {
+		if _, found := mp.FindNetwork(suggestion.Source.ID); found {
+			continue
+		}
+		if suggestion.Confidence < autoMap.GetMinConfidence() {
+			unmatched = append(unmatched, suggestion.Source.String())
+			continue
+		}
+		mp.Spec.Map = append(
+			mp.Spec.Map,
+			api.NetworkPair{
+				Source:      suggestion.Source,
+				Destination: suggestion.Destination,
+			})
+		updated = true
+		r.Log.Info(
+			"Auto-map: network mapped.",
+			"source",
+			suggestion.Source.String(),
+			"destination",
+			suggestion.Destination,
+			"confidence",
+			suggestion.Confidence)
+	}
+	if len(unmatched) > 0 {
+		mp.Status.SetCondition(libcnd.Condition{
+			Type:     AutoMapIncomplete,
+			Status:   True,
+			Reason:   NotMatched,
+			Category: Warn,
+			Message:  "Auto-map: source network not matched with sufficient confidence.",
+			Items:    unmatched,
+		})
+	}
+
+	return
+}
@@ -153,6 +153,27 @@ func (r Reconciler) Reconcile(request reconcile.Request) (result reconcile.Resul
 		return
 	}
 
+	// Automatic mapping.
+	if mp.Spec.AutoMap != nil {
+		updated, aErr := r.autoMap(mp)
+		if aErr != nil {
+			err = aErr
+			return
+		}
+		if updated {
+			err = r.Update(context.TODO(), mp)
+			return
+		}
+	}
+
+	// Generate network attachment definitions.
+	if !mp.Status.HasBlockerCondition() {
+		err = r.generate(mp)
+		if err != nil {
+			return
+		}
+	}
+
 	// Ready condition.
 	if !mp.Status.HasBlockerCondition() {
 		mp.Status.SetCondition(libcnd.Condition{
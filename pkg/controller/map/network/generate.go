@@ -0,0 +1,286 @@
+package network
+
+import (
+	"bytes"
+	"context"
+	net "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	libcnd "github.com/konveyor/controller/pkg/condition"
+	liberr "github.com/konveyor/controller/pkg/error"
+	api "github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1"
+	"github.com/konveyor/forklift-controller/pkg/controller/provider/web"
+	"github.com/konveyor/forklift-controller/pkg/controller/provider/web/ovirt"
+	"github.com/konveyor/forklift-controller/pkg/controller/provider/web/vsphere"
+	core "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"path"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"text/template"
+)
+
+//
+// Labels
+const (
+	// Network map UID.
+	kNetworkMap = "networkMap"
+)
+
+//
+// Annotations
+const (
+	// Bridge device plugin resource.
+	annResourceName = "k8s.v1.cni.cncf.io/resourceName"
+)
+
+//
+// Default CNI configuration template.
+const DefaultNetworkTemplate = `{
+  "cniVersion": "0.3.1",
+  "name": "{{.Name}}",
+  "type": "cnv-bridge",
+  "bridge": "{{.Bridge}}"{{if .VLan}},
+  "vlan": {{.VLan}}{{end}}
+}`
+
+//
+// CNI configuration template fields.
+type NetworkTemplate struct {
+	// NAD name.
+	Name string
+	// NAD namespace.
+	Namespace string
+	// Node bridge.
+	Bridge string
+	// VLAN ID.
+	VLan string
+	// Source network name.
+	Source string
+}
+
+//
+// Parse the CNI configuration template.
+func parseTemplate(generate *api.GeneratedNetwork) (tmpl *template.Template, err error) {
+	text := generate.Template
+	if text == "" {
+		text = DefaultNetworkTemplate
+	}
+	tmpl, err = template.New("cni").Parse(text)
+	if err != nil {
+		err = liberr.Wrap(err)
+	}
+
+	return
+}
+
+//
+// Create or update the generated network attachment
+// definitions. Generated definitions no longer mapped
+// are retained (as when the map is deleted) because
+// migrated VMs may be using them.
+// A definition with the same name that was not generated
+// by the map is reported by the GeneratedNetworkNotValid
+// condition and left unchanged.
+func (r *Reconciler) generate(mp *api.NetworkMap) (err error) {
+	generated := false
+	for _, entry := range mp.Spec.Map {
+		if entry.Destination.Type == Multus && entry.Destination.Generate != nil {
+			generated = true
+			break
+		}
+	}
+	if !generated {
+		return
+	}
+	destination := mp.Referenced.Provider.Destination
+	client, err := r.destinationClient(destination)
+	if err != nil {
+		return
+	}
+	inventory, err := web.NewClient(mp.Referenced.Provider.Source)
+	if err != nil {
+		return
+	}
+	conflicts := []string{}
+	for i := range mp.Spec.Map {
+		entry := &mp.Spec.Map[i]
+		if entry.Destination.Type != Multus || entry.Destination.Generate == nil {
+			continue
+		}
+		owned, nErr := r.ensureNAD(client, inventory, mp, entry)
+		if nErr != nil {
+			err = nErr
+			return
+		}
+		if !owned {
+			conflicts = append(
+				conflicts,
+				path.Join(
+					entry.Destination.Namespace,
+					entry.Destination.Name))
+		}
+	}
+	if len(conflicts) > 0 {
+		mp.Status.SetCondition(libcnd.Condition{
+			Type:     GeneratedNetworkNotValid,
+			Status:   True,
+			Reason:   Conflict,
+			Category: Critical,
+			Message:  "Generated network: network attachment definition exists and was not generated by the map.",
+			Items:    conflicts,
+		})
+	}
+
+	return
+}
+
+//
+// Create or update a generated network attachment definition.
+// Returns owned=false when a definition with the same name
+// exists and was not generated by the map.
+func (r *Reconciler) ensureNAD(client k8sclient.Client, inventory web.Client, mp *api.NetworkMap, entry *api.NetworkPair) (owned bool, err error) {
+	generate := entry.Destination.Generate
+	fields := NetworkTemplate{
+		Name:      entry.Destination.Name,
+		Namespace: entry.Destination.Namespace,
+		Bridge:    generate.Bridge,
+		VLan:      generate.VLan,
+	}
+	source := entry.Source
+	network, err := inventory.Network(&source)
+	if err != nil {
+		return
+	}
+	fields.Source = source.Name
+	if fields.VLan == "" {
+		switch n := network.(type) {
+		case *vsphere.Network:
+			fields.VLan = n.VLan
+		case *ovirt.Network:
+			fields.VLan = n.VLan
+		}
+	}
+	tmpl, err := parseTemplate(generate)
+	if err != nil {
+		return
+	}
+	config := bytes.Buffer{}
+	err = tmpl.Execute(&config, fields)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	err = r.ensureNamespace(client, fields.Namespace)
+	if err != nil {
+		return
+	}
+	nad := &net.NetworkAttachmentDefinition{}
+	err = client.Get(
+		context.TODO(),
+		k8sclient.ObjectKey{
+			Namespace: fields.Namespace,
+			Name:      fields.Name,
+		},
+		nad)
+	if err != nil {
+		if !k8serr.IsNotFound(err) {
+			err = liberr.Wrap(err)
+			return
+		}
+		nad = &net.NetworkAttachmentDefinition{
+			ObjectMeta: meta.ObjectMeta{
+				Namespace: fields.Namespace,
+				Name:      fields.Name,
+				Labels: map[string]string{
+					kNetworkMap: string(mp.UID),
+				},
+				Annotations: map[string]string{
+					annResourceName: "bridge.network.kubevirt.io/" + fields.Bridge,
+				},
+			},
+			Spec: net.NetworkAttachmentDefinitionSpec{
+				Config: config.String(),
+			},
+		}
+		err = client.Create(context.TODO(), nad)
+		if err != nil {
+			err = liberr.Wrap(err)
+			return
+		}
+		owned = true
+		r.Log.Info(
+			"Generated network attachment definition created.",
+			"nad",
+			fields.Namespace+"/"+fields.Name,
+			"source",
+			source.String())
+		return
+	}
+	if nad.Labels[kNetworkMap] != string(mp.UID) {
+		return
+	}
+	owned = true
+	if nad.Spec.Config == config.String() {
+		return
+	}
+	nad.Spec.Config = config.String()
+	if nad.Annotations == nil {
+		nad.Annotations = map[string]string{}
+	}
+	nad.Annotations[annResourceName] = "bridge.network.kubevirt.io/" + fields.Bridge
+	err = client.Update(context.TODO(), nad)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	r.Log.Info(
+		"Generated network attachment definition updated.",
+		"nad",
+		fields.Namespace+"/"+fields.Name)
+
+	return
+}
+
+//
+// Ensure the namespace exists on the destination.
+func (r *Reconciler) ensureNamespace(client k8sclient.Client, name string) (err error) {
+	ns := &core.Namespace{
+		ObjectMeta: meta.ObjectMeta{
+			Name: name,
+		},
+	}
+	err = client.Create(context.TODO(), ns)
+	if err != nil {
+		if k8serr.IsAlreadyExists(err) {
+			err = nil
+		} else {
+			err = liberr.Wrap(err)
+		}
+	}
+
+	return
+}
+
+//
+// Build a client for the destination cluster.
+func (r *Reconciler) destinationClient(provider *api.Provider) (client k8sclient.Client, err error) {
+	if provider.IsHost() {
+		client, err = provider.Client(nil)
+		return
+	}
+	ref := provider.Spec.Secret
+	secret := &core.Secret{}
+	err = r.Get(
+		context.TODO(),
+		k8sclient.ObjectKey{
+			Namespace: ref.Namespace,
+			Name:      ref.Name,
+		},
+		secret)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	client, err = provider.Client(secret)
+
+	return
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/konveyor/forklift-controller/pkg/controller/provider/web"
 	"github.com/konveyor/forklift-controller/pkg/controller/validation"
 	"path"
+	"strconv"
 )
 
 //
@@ -15,6 +16,8 @@ import (
 const (
 	SourceNetworkNotValid      = "SourceNetworkNotValid"
 	DestinationNetworkNotValid = "DestinationNetworkNotValid"
+	GeneratedNetworkNotValid   = "GeneratedNetworkNotValid"
+	AutoMapIncomplete          = "AutoMapIncomplete"
 )
 
 //
@@ -30,9 +33,12 @@ const (
 //
 // Reasons
 const (
-	NotSet    = "NotSet"
-	NotFound  = "NotFound"
-	Ambiguous = "Ambiguous"
+	NotSet     = "NotSet"
+	NotFound   = "NotFound"
+	NotValid   = "NotValid"
+	NotMatched = "NotMatched"
+	Ambiguous  = "Ambiguous"
+	Conflict   = "Conflict"
 )
 
 //
@@ -153,12 +159,23 @@ func (r *Reconciler) validateDestination(mp *api.NetworkMap) (err error) {
 	}
 	list := mp.Spec.Map
 	notFound := []string{}
+	notValid := []string{}
 next:
 	for _, entry := range list {
 		switch entry.Destination.Type {
 		case Pod:
 			continue next
 		case Multus:
+			if entry.Destination.Generate != nil {
+				if !r.validGenerated(&entry.Destination) {
+					notValid = append(
+						notValid,
+						path.Join(
+							entry.Destination.Namespace,
+							entry.Destination.Name))
+				}
+				continue next
+			}
 			id := path.Join(
 				entry.Destination.Namespace,
 				entry.Destination.Name)
@@ -187,6 +204,35 @@ next:
 			Items:    notFound,
 		})
 	}
+	if len(notValid) > 0 {
+		mp.Status.SetCondition(libcnd.Condition{
+			Type:     GeneratedNetworkNotValid,
+			Status:   True,
+			Reason:   NotValid,
+			Category: Critical,
+			Message:  "Generated network: `namespace`, `name` and `bridge` required; `vlan` must be numeric; `template` must be valid.",
+			Items:    notValid,
+		})
+	}
 
 	return
 }
+
+//
+// Validate a generated network destination.
+func (r *Reconciler) validGenerated(destination *api.DestinationNetwork) bool {
+	generate := destination.Generate
+	if destination.Namespace == "" || destination.Name == "" || generate.Bridge == "" {
+		return false
+	}
+	if generate.VLan != "" {
+		if _, err := strconv.Atoi(generate.VLan); err != nil {
+			return false
+		}
+	}
+	if _, err := parseTemplate(generate); err != nil {
+		return false
+	}
+
+	return true
+}
@@ -158,6 +158,19 @@ func (r Reconciler) Reconcile(request reconcile.Request) (result reconcile.Resul
 		return
 	}
 
+	// Automatic mapping.
+	if mp.Spec.AutoMap != nil {
+		updated, aErr := r.autoMap(mp)
+		if aErr != nil {
+			err = aErr
+			return
+		}
+		if updated {
+			err = r.Update(context.TODO(), mp)
+			return
+		}
+	}
+
 	// Ready condition.
 	if !mp.Status.HasBlockerCondition() {
 		mp.Status.SetCondition(libcnd.Condition{
@@ -14,6 +14,7 @@ import (
 const (
 	SourceStorageNotValid      = "SourceStorageNotValid"
 	DestinationStorageNotValid = "DestinationStorageNotValid"
+	AutoMapIncomplete          = "AutoMapIncomplete"
 )
 
 //
@@ -29,9 +30,10 @@ const (
 //
 // Reasons
 const (
-	NotSet    = "NotSet"
-	NotFound  = "NotFound"
-	Ambiguous = "Ambiguous"
+	NotSet     = "NotSet"
+	NotFound   = "NotFound"
+	Ambiguous  = "Ambiguous"
+	NotMatched = "NotMatched"
 )
 
 //
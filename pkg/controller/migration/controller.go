@@ -18,6 +18,7 @@ package migration
 
 import (
 	"context"
+	"fmt"
 	libcnd "github.com/konveyor/controller/pkg/condition"
 	"github.com/konveyor/controller/pkg/logging"
 	libref "github.com/konveyor/controller/pkg/ref"
@@ -148,6 +149,21 @@ func (r Reconciler) Reconcile(request reconcile.Request) (result reconcile.Resul
 	// Reflect plan.
 	r.reflectPlan(plan, migration)
 
+	// Scheduled.
+	if !migration.Status.MarkedStarted() {
+		if due, wait := migration.Spec.Due(); !due {
+			migration.Status.SetCondition(libcnd.Condition{
+				Type:     Scheduled,
+				Status:   True,
+				Category: Advisory,
+				Message: fmt.Sprintf(
+					"The migration is scheduled to start at %s.",
+					migration.Spec.StartAt.String()),
+			})
+			result.RequeueAfter = wait
+		}
+	}
+
 	// Ready condition.
 	if !migration.Status.HasBlockerCondition() {
 		migration.Status.SetCondition(libcnd.Condition{
@@ -178,7 +194,7 @@ func (r *Reconciler) reflectPlan(plan *api.Plan, migration *api.Migration) {
 	if migration.Status.HasBlockerCondition() {
 		return
 	}
-	if migration.Status.HasAnyCondition(Canceled, Succeeded, Failed) {
+	if migration.Status.HasAnyCondition(Canceled, Succeeded, Failed, Skipped) {
 		return
 	}
 	found, snapshot := plan.Status.Migration.SnapshotWithMigration(migration.UID)
@@ -200,6 +216,14 @@ func (r *Reconciler) reflectPlan(plan *api.Plan, migration *api.Migration) {
 			Message:  "The migration is RUNNING.",
 		})
 	}
+	if snapshot.HasCondition(Paused) {
+		migration.Status.SetCondition(libcnd.Condition{
+			Type:     Paused,
+			Status:   True,
+			Category: Advisory,
+			Message:  "The migration is PAUSED.",
+		})
+	}
 	if snapshot.HasCondition(Succeeded) {
 		migration.Status.MarkCompleted()
 		migration.Status.SetCondition(libcnd.Condition{
@@ -220,5 +244,19 @@ func (r *Reconciler) reflectPlan(plan *api.Plan, migration *api.Migration) {
 			Durable:  true,
 		})
 	}
-	migration.Status.VMs = plan.Status.Migration.VMs
+	if cnd := snapshot.FindCondition(Skipped); cnd != nil {
+		migration.Status.MarkCompleted()
+		migration.Status.SetCondition(libcnd.Condition{
+			Type:     Skipped,
+			Status:   True,
+			Category: Advisory,
+			Reason:   cnd.Reason,
+			Message:  cnd.Message,
+			Items:    cnd.Items,
+			Durable:  true,
+		})
+	}
+	migration.Status.VMRefs = plan.Status.Migration.VMRefs
+	migration.Status.VMs = nil
+	migration.Status.Summary = plan.Status.Migration.Summary
 }
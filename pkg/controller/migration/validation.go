@@ -7,6 +7,7 @@ import (
 	liberr "github.com/konveyor/controller/pkg/error"
 	libref "github.com/konveyor/controller/pkg/ref"
 	api "github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1"
+	refapi "github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1/ref"
 	plancnt "github.com/konveyor/forklift-controller/pkg/controller/plan"
 	"github.com/konveyor/forklift-controller/pkg/controller/provider/web"
 	k8serr "k8s.io/apimachinery/pkg/api/errors"
@@ -25,6 +26,10 @@ const (
 	Succeeded    = plancnt.Succeeded
 	Failed       = plancnt.Failed
 	Canceled     = plancnt.Canceled
+	Paused       = plancnt.Paused
+	Skipped      = plancnt.Skipped
+	Scheduled    = "Scheduled"
+	NotScheduled = "ScheduleNotValid"
 )
 
 //
@@ -41,6 +46,7 @@ const (
 const (
 	NotSet    = "NotSet"
 	NotFound  = "NotFound"
+	NotValid  = "NotValid"
 	Ambiguous = "Ambiguous"
 )
 
@@ -53,6 +59,16 @@ const (
 //
 // Validate the migration resource.
 func (r *Reconciler) validate(migration *api.Migration) (plan *api.Plan, err error) {
+	if !migration.Spec.ScheduleValid() {
+		migration.Status.SetCondition(
+			libcnd.Condition{
+				Type:     NotScheduled,
+				Status:   True,
+				Reason:   NotValid,
+				Category: Critical,
+				Message:  "The `notAfter` deadline must be after `startAt`.",
+			})
+	}
 	newCnd := libcnd.Condition{
 		Type:     PlanNotValid,
 		Status:   True,
@@ -93,7 +109,7 @@ func (r *Reconciler) validate(migration *api.Migration) (plan *api.Plan, err err
 		return
 	}
 
-	// Validate the refs in the Cancel array
+	// Validate the refs in the Cancel, PauseVMs and VMCutovers arrays
 	notFound := libcnd.Condition{
 		Type:     VMNotFound,
 		Status:   True,
@@ -126,7 +142,12 @@ func (r *Reconciler) validate(migration *api.Migration) (plan *api.Plan, err err
 	if err != nil {
 		return
 	}
-	for _, ref := range migration.Spec.Cancel {
+	refs := append([]refapi.Ref{}, migration.Spec.Cancel...)
+	refs = append(refs, migration.Spec.PauseVMs...)
+	for _, vm := range migration.Spec.VMCutovers {
+		refs = append(refs, vm.Ref)
+	}
+	for _, ref := range refs {
 		_, err = inventory.VM(&ref)
 		if err != nil {
 			if errors.As(err, &web.NotFoundError{}) {
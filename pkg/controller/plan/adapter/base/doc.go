@@ -6,8 +6,11 @@ import (
 	"github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1/ref"
 	plancontext "github.com/konveyor/forklift-controller/pkg/controller/plan/context"
 	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	cnv "kubevirt.io/client-go/api/v1"
 	cdi "kubevirt.io/containerized-data-importer/pkg/apis/core/v1beta1"
 	vmio "kubevirt.io/vm-import-operator/pkg/apis/v2v/v1beta1"
+	"net"
 )
 
 //
@@ -19,6 +22,8 @@ type Adapter interface {
 	Builder(ctx *plancontext.Context) (Builder, error)
 	// Construct validator.
 	Validator(plan *api.Plan) (Validator, error)
+	// Construct client.
+	Client(ctx *plancontext.Context) (Client, error)
 }
 
 //
@@ -34,6 +39,19 @@ type Builder interface {
 	Tasks(vmRef ref.Ref) ([]*plan.Task, error)
 	// Return a stable identifier for a DataVolume.
 	ResolveDataVolumeIdentifier(dv *cdi.DataVolume) string
+	// Return the names of the DataVolumes the import
+	// would create for the excluded disks.
+	ExcludedDataVolumes(vmRef ref.Ref, vmName string) ([]string, error)
+	// Return the IP addresses reported by the source guest.
+	GuestIPs(vmRef ref.Ref) ([]string, error)
+	// Return the static network configuration reported
+	// by the source guest.
+	GuestNetwork(vmRef ref.Ref) (*plan.GuestNetwork, error)
+	// Build the VMI template (CPU, memory and firmware)
+	// for VMs not created by VMIO.
+	Template(vmRef ref.Ref, object *cnv.VirtualMachineInstanceSpec) error
+	// Build the devices (disks and NICs) of the VM created by VMIO.
+	Devices(vmRef ref.Ref, dvs []*cdi.DataVolume, object *cnv.VirtualMachineInstanceSpec) error
 }
 
 //
@@ -46,4 +64,95 @@ type Validator interface {
 	NetworksMapped(vmRef ref.Ref) (bool, error)
 	// Validate that a VM's Host isn't in maintenance mode.
 	MaintenanceMode(vmRef ref.Ref) (bool, error)
+	// Find the VMs matched by the label selector.
+	SelectVMs(selector labels.Selector) ([]ref.Ref, error)
+	// List the (stable) identifiers of a VM's disks.
+	Disks(vmRef ref.Ref) ([]string, error)
+	// Validate that a disk override is supported.
+	DiskOverrideSupported(vmRef ref.Ref, disk *plan.Disk) (bool, error)
+	// Validate a VM's NIC overrides.
+	// Returns the overrides not matching a NIC and
+	// those not supported by the provider.
+	NICOverrides(vmRef ref.Ref) (notFound []string, notSupported []string, err error)
+	// The name of the folder (or cluster) containing the VM.
+	// Used by the target VM naming policy.
+	Folder(vmRef ref.Ref) (string, error)
+	// Resources required by a VM on the destination.
+	Requirements(vmRef ref.Ref) (*Requirements, error)
+	// List the devices of a VM that cannot be migrated.
+	UnsupportedDevices(vmRef ref.Ref) ([]string, error)
+	// The host and cluster running the VM.
+	// Used by the transfer network selection.
+	Placement(vmRef ref.Ref) (host ref.Ref, cluster ref.Ref, err error)
+	// Validate that a transfer network (subnets) is reachable
+	// from the network adapters of the VM's host.
+	TransferReachable(vmRef ref.Ref, subnets []*net.IPNet) (bool, error)
+}
+
+//
+// Resources required by a VM on the destination.
+type Requirements struct {
+	// CPU (count).
+	CPU int64
+	// Guest memory (bytes).
+	Memory int64
+	// Disks (excluded disks are omitted).
+	Disks []DiskRequirement
+}
+
+//
+// Storage required by a disk.
+type DiskRequirement struct {
+	// Destination storage class.
+	// Empty when not mapped.
+	StorageClass string
+	// Capacity (bytes).
+	Capacity int64
+}
+
+//
+// Client API.
+// Performs provider-specific operations
+// on the source VM.
+type Client interface {
+	// Power on the source VM.
+	PowerOn(vmRef ref.Ref) error
+	// Power off the source VM.
+	PowerOff(vmRef ref.Ref) error
+	// Determine whether the source VM is powered off.
+	PoweredOff(vmRef ref.Ref) (bool, error)
+	// Shut down the guest OS of the source VM.
+	Shutdown(vmRef ref.Ref) error
+	// Rename the source VM.
+	Rename(vmRef ref.Ref, name string) error
+	// Move the source VM into a folder.
+	MoveToFolder(vmRef ref.Ref, folder string) error
+	// The inventory path of the folder containing the source VM.
+	Folder(vmRef ref.Ref) (string, error)
+	// Attach an (existing) tag to the source VM.
+	Tag(vmRef ref.Ref, tag string) error
+	// Detach a tag from the source VM.
+	Untag(vmRef ref.Ref, tag string) error
+	// Disable automatic start of the source VM.
+	DisableAutostart(vmRef ref.Ref) error
+	// Create a snapshot of the source VM.
+	// Returns the snapshot ID.
+	CreateSnapshot(vmRef ref.Ref, name string) (string, error)
+	// Determine whether the snapshot is ready.
+	SnapshotReady(vmRef ref.Ref, id string) (bool, error)
+	// Remove a snapshot of the source VM.
+	// The removal may be asynchronous.
+	RemoveSnapshot(vmRef ref.Ref, id string) error
+	// Determine whether the snapshot has been removed.
+	SnapshotRemoved(vmRef ref.Ref, id string) (bool, error)
+	// Get the disk change IDs of a (warm migration) snapshot.
+	// Returns supported=false when not reported by the provider.
+	ChangeIds(vmRef ref.Ref, snapshot string) (map[string]string, bool, error)
+	// Get the bytes changed in a (warm migration) snapshot since
+	// the disk change IDs (of the previous snapshot) were recorded.
+	// All allocated bytes are reported when no change IDs are passed.
+	// Returns supported=false when not reported by the provider.
+	ChangedBytes(vmRef ref.Ref, changeIds map[string]string, snapshot string) (int64, bool, error)
+	// Close connections to the provider API.
+	Close()
 }
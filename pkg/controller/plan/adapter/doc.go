@@ -11,6 +11,7 @@ import (
 type Adapter = base.Adapter
 type Builder = base.Builder
 type Validator = base.Validator
+type Client = base.Client
 
 //
 // Adapter factory.
@@ -13,9 +13,12 @@ import (
 	model "github.com/konveyor/forklift-controller/pkg/controller/provider/web/ovirt"
 	"gopkg.in/yaml.v2"
 	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	cnv "kubevirt.io/client-go/api/v1"
 	cdi "kubevirt.io/containerized-data-importer/pkg/apis/core/v1beta1"
 	vmio "kubevirt.io/vm-import-operator/pkg/apis/v2v/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"strings"
 )
 
 //
@@ -80,24 +83,18 @@ func (r *Builder) Import(vmRef ref.Ref, object *vmio.VirtualMachineImportSpec) (
 func (r *Builder) mapping(vm *model.VM) (out *vmio.OvirtMappings, err error) {
 	netMap := []vmio.NetworkResourceMappingItem{}
 	storageMap := []vmio.StorageResourceMappingItem{}
-	netMapIn := r.Context.Map.Network.Spec.Map
-	for i := range netMapIn {
-		mapped := &netMapIn[i]
-		ref := mapped.Source
-		network := &model.Network{}
-		fErr := r.Source.Inventory.Find(network, ref)
-		if err != nil {
-			err = fErr
-			return
+	planVM, _ := r.Plan.Spec.FindVM(ref.Ref{ID: vm.ID})
+	profiles := map[string]bool{}
+	for _, nic := range vm.NICs {
+		profileID := nic.Profile.ID
+		if profiles[profileID] {
+			continue
 		}
-		needed := false
-		profileId := ""
-		for _, nic := range vm.NICs {
-			if nic.Profile.Network == network.ID {
-				needed = true
-				profileId = nic.Profile.ID
-				break
-			}
+		profiles[profileID] = true
+		destination, needed, dErr := r.networkDestination(vm, planVM, &nic.Profile)
+		if dErr != nil {
+			err = dErr
+			return
 		}
 		if !needed {
 			continue
@@ -106,13 +103,13 @@ func (r *Builder) mapping(vm *model.VM) (out *vmio.OvirtMappings, err error) {
 			netMap,
 			vmio.NetworkResourceMappingItem{
 				Source: vmio.Source{
-					ID: &profileId,
+					ID: &profileID,
 				},
 				Target: vmio.ObjectIdentifier{
-					Namespace: &mapped.Destination.Namespace,
-					Name:      mapped.Destination.Name,
+					Namespace: &destination.Namespace,
+					Name:      destination.Name,
 				},
-				Type: &mapped.Destination.Type,
+				Type: &destination.Type,
 			})
 	}
 	storageMapIn := r.Context.Map.Storage.Spec.Map
@@ -156,9 +153,163 @@ func (r *Builder) mapping(vm *model.VM) (out *vmio.OvirtMappings, err error) {
 		}
 		storageMap = append(storageMap, item)
 	}
+	diskMap, err := r.diskMapping(vm)
+	if err != nil {
+		return
+	}
 	out = &vmio.OvirtMappings{
 		NetworkMappings: &netMap,
 		StorageMappings: &storageMap,
+		DiskMappings:    &diskMap,
+	}
+
+	return
+}
+
+//
+// Destination of a vNIC profile used by the VM.
+// NIC overrides take precedence over the network map.
+// Not needed when the network is not mapped and all the
+// NICs using the profile are excluded. VMIO requires all
+// profiles to be mapped so the profile of excluded NICs is
+// mapped by the network map. The excluded NICs are removed
+// from the VM created by VMIO (see: Devices()).
+func (r *Builder) networkDestination(vm *model.VM, planVM *plan.VM, profile *model.NICProfile) (destination api.DestinationNetwork, needed bool, err error) {
+	mapped, found, err := r.mappedNetwork(profile.Network)
+	if err != nil {
+		return
+	}
+	for _, nic := range vm.NICs {
+		if nic.Profile.ID != profile.ID {
+			continue
+		}
+		var override *plan.NIC
+		if planVM != nil {
+			override, _ = planVM.FindNIC(nic.MAC, nic.ID)
+		}
+		switch {
+		case override != nil && override.Exclude:
+			if found && !needed {
+				destination = mapped.Destination
+				needed = true
+			}
+		case override != nil && override.Pod:
+			destination = api.DestinationNetwork{
+				Type: "pod",
+			}
+			needed = true
+		case override != nil && override.Network != nil:
+			destination = api.DestinationNetwork{
+				Type:      "multus",
+				Namespace: override.Network.Namespace,
+				Name:      override.Network.Name,
+			}
+			needed = true
+		case found:
+			destination = mapped.Destination
+			needed = true
+		}
+	}
+
+	return
+}
+
+//
+// Find the network map entry for a source network.
+func (r *Builder) mappedNetwork(networkID string) (mapped *api.NetworkPair, found bool, err error) {
+	list := r.Context.Map.Network.Spec.Map
+	for i := range list {
+		network := &model.Network{}
+		err = r.Source.Inventory.Find(network, list[i].Source)
+		if err != nil {
+			return
+		}
+		if network.ID == networkID {
+			mapped = &list[i]
+			found = true
+			break
+		}
+	}
+
+	return
+}
+
+//
+// Build the disk mappings for the (plan) VM disk overrides.
+// Settings not overridden are inherited from the storage map.
+func (r *Builder) diskMapping(vm *model.VM) (diskMap []vmio.StorageResourceMappingItem, err error) {
+	diskMap = []vmio.StorageResourceMappingItem{}
+	planVM, found := r.Plan.Spec.FindVM(ref.Ref{ID: vm.ID})
+	if !found {
+		return
+	}
+	for _, da := range vm.DiskAttachments {
+		disk, found := planVM.FindDisk(da.Disk.ID)
+		if !found || disk.Exclude || !disk.Overridden() {
+			continue
+		}
+		destination := api.DestinationStorage{}
+		mapped, found, mErr := r.mappedStorage(da.Disk.StorageDomain)
+		if mErr != nil {
+			err = mErr
+			return
+		}
+		if found {
+			destination = mapped.Destination
+		}
+		if disk.StorageClass != "" && disk.StorageClass != destination.StorageClass {
+			destination = api.DestinationStorage{
+				StorageClass: disk.StorageClass,
+			}
+		}
+		if disk.VolumeMode != "" && disk.VolumeMode != destination.VolumeMode {
+			destination.VolumeMode = disk.VolumeMode
+			destination.AccessMode = ""
+		}
+		if disk.AccessMode != "" {
+			destination.AccessMode = disk.AccessMode
+		}
+		mErr = r.defaultModes(&destination)
+		if mErr != nil {
+			err = mErr
+			return
+		}
+		id := da.Disk.ID
+		item := vmio.StorageResourceMappingItem{
+			Source: vmio.Source{
+				ID: &id,
+			},
+			Target: vmio.ObjectIdentifier{
+				Name: destination.StorageClass,
+			},
+		}
+		if destination.VolumeMode != "" {
+			item.VolumeMode = &destination.VolumeMode
+		}
+		if destination.AccessMode != "" {
+			item.AccessMode = &destination.AccessMode
+		}
+		diskMap = append(diskMap, item)
+	}
+
+	return
+}
+
+//
+// Find the storage map entry for a storage domain.
+func (r *Builder) mappedStorage(domainID string) (mapped *api.StoragePair, found bool, err error) {
+	list := r.Context.Map.Storage.Spec.Map
+	for i := range list {
+		domain := &model.StorageDomain{}
+		err = r.Source.Inventory.Find(domain, list[i].Source)
+		if err != nil {
+			return
+		}
+		if domain.ID == domainID {
+			mapped = &list[i]
+			found = true
+			break
+		}
 	}
 
 	return
@@ -203,7 +354,13 @@ func (r *Builder) Tasks(vmRef ref.Ref) (list []*plan.Task, err error) {
 				pErr.Error()))
 		return
 	}
+	planVM, _ := r.Plan.Spec.FindVM(vmRef)
 	for _, da := range vm.DiskAttachments {
+		if planVM != nil {
+			if disk, found := planVM.FindDisk(da.Disk.ID); found && disk.Exclude {
+				continue
+			}
+		}
 		mB := da.Disk.ProvisionedSize / 0x100000
 		list = append(
 			list,
@@ -221,12 +378,202 @@ func (r *Builder) Tasks(vmRef ref.Ref) (list []*plan.Task, err error) {
 	return
 }
 
+//
+// Return the names of the DataVolumes the import would
+// create for the excluded disks.
+// VMIO names the DataVolumes using the target VM name
+// and disk attachment ID.
+func (r *Builder) ExcludedDataVolumes(vmRef ref.Ref, vmName string) (names []string, err error) {
+	planVM, found := r.Plan.Spec.FindVM(vmRef)
+	if !found {
+		return
+	}
+	vm := &model.VM{}
+	pErr := r.Source.Inventory.Find(vm, vmRef)
+	if pErr != nil {
+		err = liberr.New(
+			fmt.Sprintf(
+				"VM %s lookup failed: %s",
+				vmRef.String(),
+				pErr.Error()))
+		return
+	}
+	for _, da := range vm.DiskAttachments {
+		if disk, found := planVM.FindDisk(da.Disk.ID); found && disk.Exclude {
+			names = append(names, vmName+"-"+da.ID)
+		}
+	}
+
+	return
+}
+
 //
 // Return a stable identifier for a DataVolume.
 func (r *Builder) ResolveDataVolumeIdentifier(dv *cdi.DataVolume) string {
 	return dv.Spec.Source.Imageio.DiskID
 }
 
+//
+// Return the IP addresses reported by the guest agent.
+func (r *Builder) GuestIPs(vmRef ref.Ref) (list []string, err error) {
+	vm := &model.VM{}
+	pErr := r.Source.Inventory.Find(vm, vmRef)
+	if pErr != nil {
+		err = liberr.New(
+			fmt.Sprintf(
+				"VM %s lookup failed: %s",
+				vmRef.String(),
+				pErr.Error()))
+		return
+	}
+	for _, nic := range vm.NICs {
+		for _, ip := range nic.IpAddress {
+			list = append(list, ip.Address)
+		}
+	}
+
+	return
+}
+
+//
+// Return the network configuration reported by the guest agent.
+// The interface names are reported with the addresses.
+// The guest agent does not report how the addresses are assigned,
+// the prefix length, the gateways nor the DNS servers.
+func (r *Builder) GuestNetwork(vmRef ref.Ref) (network *plan.GuestNetwork, err error) {
+	vm := &model.VM{}
+	pErr := r.Source.Inventory.Find(vm, vmRef)
+	if pErr != nil {
+		err = liberr.New(
+			fmt.Sprintf(
+				"VM %s lookup failed: %s",
+				vmRef.String(),
+				pErr.Error()))
+		return
+	}
+	network = &plan.GuestNetwork{}
+	switch strings.ToLower(vm.GuestFamily) {
+	case plan.GuestLinux:
+		network.OS = plan.GuestLinux
+	case plan.GuestWindows:
+		network.OS = plan.GuestWindows
+	}
+	for _, nic := range vm.NICs {
+		if nic.MAC == "" {
+			continue
+		}
+		guestInterface := plan.GuestInterface{
+			MAC:       nic.MAC,
+			Name:      nic.GuestName,
+			Addresses: []string{},
+		}
+		for _, ip := range nic.IpAddress {
+			if cidr, valid := plan.GuestAddress(ip.Address, 0); valid {
+				guestInterface.Addresses = append(guestInterface.Addresses, cidr)
+			}
+		}
+		if len(guestInterface.Addresses) > 0 {
+			network.Interfaces = append(network.Interfaces, guestInterface)
+		}
+	}
+
+	return
+}
+
+//
+// Build the devices of the VM created by VMIO.
+// The disk interfaces and NIC models are mapped by the import.
+// The NIC model overrides are applied and the excluded NICs
+// (and networks) are removed.
+func (r *Builder) Devices(vmRef ref.Ref, dvs []*cdi.DataVolume, object *cnv.VirtualMachineInstanceSpec) (err error) {
+	planVM, found := r.Plan.Spec.FindVM(vmRef)
+	if !found || len(planVM.NICs) == 0 {
+		return
+	}
+	vm := &model.VM{}
+	pErr := r.Source.Inventory.Find(vm, vmRef)
+	if pErr != nil {
+		err = liberr.New(
+			fmt.Sprintf(
+				"VM %s lookup failed: %s",
+				vmRef.String(),
+				pErr.Error()))
+		return
+	}
+	overrides := map[string]*plan.NIC{}
+	for _, nic := range vm.NICs {
+		if override, found := planVM.FindNIC(nic.MAC, nic.ID); found {
+			overrides[strings.ToLower(nic.MAC)] = override
+		}
+	}
+	excluded := map[string]bool{}
+	interfaces := []cnv.Interface{}
+	for _, iface := range object.Domain.Devices.Interfaces {
+		override, found := overrides[strings.ToLower(iface.MacAddress)]
+		if found && override.Exclude {
+			excluded[iface.Name] = true
+			continue
+		}
+		if found && override.Model != "" {
+			iface.Model = override.Model
+		}
+		interfaces = append(interfaces, iface)
+	}
+	object.Domain.Devices.Interfaces = interfaces
+	networks := []cnv.Network{}
+	for _, network := range object.Networks {
+		if !excluded[network.Name] {
+			networks = append(networks, network)
+		}
+	}
+	object.Networks = networks
+
+	return
+}
+
+//
+// Build the VMI template.
+func (r *Builder) Template(vmRef ref.Ref, object *cnv.VirtualMachineInstanceSpec) (err error) {
+	vm := &model.VM{}
+	pErr := r.Source.Inventory.Find(vm, vmRef)
+	if pErr != nil {
+		err = liberr.New(
+			fmt.Sprintf(
+				"VM %s lookup failed: %s",
+				vmRef.String(),
+				pErr.Error()))
+		return
+	}
+	sockets := uint32(vm.CpuSockets)
+	if sockets < 1 {
+		sockets = 1
+	}
+	cores := uint32(vm.CpuCores)
+	if cores < 1 {
+		cores = 1
+	}
+	object.Domain.CPU = &cnv.CPU{
+		Sockets: sockets,
+		Cores:   cores,
+	}
+	object.Domain.Resources.Requests = core.ResourceList{
+		core.ResourceMemory: *resource.NewQuantity(vm.Memory, resource.BinarySI),
+	}
+	switch vm.BIOS {
+	case "q35_ovmf", "q35_secure_boot":
+		secureBoot := vm.BIOS == "q35_secure_boot"
+		object.Domain.Firmware = &cnv.Firmware{
+			Bootloader: &cnv.Bootloader{
+				EFI: &cnv.EFI{
+					SecureBoot: &secureBoot,
+				},
+			},
+		}
+	}
+
+	return
+}
+
 func (r *Builder) Load() (err error) {
 	return r.loadProvisioners()
 }
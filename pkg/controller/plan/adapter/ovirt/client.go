@@ -0,0 +1,599 @@
+package ovirt
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	liberr "github.com/konveyor/controller/pkg/error"
+	libweb "github.com/konveyor/controller/pkg/inventory/web"
+	"github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1/ref"
+	plancontext "github.com/konveyor/forklift-controller/pkg/controller/plan/context"
+	"io"
+	"net"
+	"net/http"
+	liburl "net/url"
+	"strings"
+	"time"
+)
+
+//
+// VM status.
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+//
+// Snapshot status.
+const (
+	SnapshotOk = "ok"
+)
+
+//
+// oVirt VM Client.
+type Client struct {
+	*plancontext.Context
+	// Base URL.
+	url string
+	// Raw client.
+	client *libweb.Client
+}
+
+//
+// Power on the source VM.
+func (r *Client) PowerOn(vmRef ref.Ref) (err error) {
+	vm, err := r.getVM(vmRef)
+	if err != nil {
+		return
+	}
+	if vm.Status == StatusUp {
+		return
+	}
+	err = r.action(vmRef, "start")
+
+	return
+}
+
+//
+// Power off the source VM.
+func (r *Client) PowerOff(vmRef ref.Ref) (err error) {
+	vm, err := r.getVM(vmRef)
+	if err != nil {
+		return
+	}
+	if vm.Status == StatusDown {
+		return
+	}
+	err = r.action(vmRef, "stop")
+
+	return
+}
+
+//
+// Determine whether the source VM is powered off.
+func (r *Client) PoweredOff(vmRef ref.Ref) (off bool, err error) {
+	vm, err := r.getVM(vmRef)
+	if err != nil {
+		return
+	}
+
+	off = vm.Status == StatusDown
+
+	return
+}
+
+//
+// Shut down the guest OS of the source VM.
+func (r *Client) Shutdown(vmRef ref.Ref) (err error) {
+	vm, err := r.getVM(vmRef)
+	if err != nil {
+		return
+	}
+	if vm.Status == StatusDown {
+		return
+	}
+	err = r.action(vmRef, "shutdown")
+
+	return
+}
+
+//
+// Rename the source VM.
+func (r *Client) Rename(vmRef ref.Ref, name string) (err error) {
+	err = r.update(
+		vmRef,
+		map[string]interface{}{
+			"name": name,
+		})
+
+	return
+}
+
+//
+// Move the source VM into a folder.
+// Not supported.
+func (r *Client) MoveToFolder(vmRef ref.Ref, folder string) (err error) {
+	err = liberr.New("oVirt does not support VM folders.")
+	return
+}
+
+//
+// The folder containing the source VM.
+// oVirt does not have VM folders.
+func (r *Client) Folder(vmRef ref.Ref) (folder string, err error) {
+	return
+}
+
+//
+// Attach an (existing) tag to the source VM.
+func (r *Client) Tag(vmRef ref.Ref, tag string) (err error) {
+	_, err = r.Source.Inventory.VM(&vmRef)
+	if err != nil {
+		return
+	}
+	url, err := r.resource("vms", vmRef.ID, "tags")
+	if err != nil {
+		return
+	}
+	status, err := r.client.Post(
+		url,
+		map[string]interface{}{
+			"name": tag,
+		},
+		nil)
+	if err != nil {
+		return
+	}
+	if status != http.StatusOK && status != http.StatusCreated {
+		err = liberr.New(
+			fmt.Sprintf(
+				"VM %s tag '%s' failed: %s",
+				vmRef.String(),
+				tag,
+				http.StatusText(status)))
+		return
+	}
+
+	return
+}
+
+//
+// Detach a tag from the source VM.
+// The tag is specified by name.
+func (r *Client) Untag(vmRef ref.Ref, tag string) (err error) {
+	_, err = r.Source.Inventory.VM(&vmRef)
+	if err != nil {
+		return
+	}
+	url, err := r.resource("vms", vmRef.ID, "tags")
+	if err != nil {
+		return
+	}
+	list := &TagList{}
+	status, err := r.client.Get(url, list)
+	if err != nil {
+		return
+	}
+	if status != http.StatusOK {
+		err = liberr.New(
+			fmt.Sprintf(
+				"VM %s tag list failed: %s",
+				vmRef.String(),
+				http.StatusText(status)))
+		return
+	}
+	for _, attached := range list.Tag {
+		if attached.Name != tag {
+			continue
+		}
+		url, err = r.resource("vms", vmRef.ID, "tags", attached.ID)
+		if err != nil {
+			return
+		}
+		status, err = r.send(http.MethodDelete, url, nil, nil)
+		if err != nil {
+			return
+		}
+		if status != http.StatusOK {
+			err = liberr.New(
+				fmt.Sprintf(
+					"VM %s untag '%s' failed: %s",
+					vmRef.String(),
+					tag,
+					http.StatusText(status)))
+			return
+		}
+	}
+
+	return
+}
+
+//
+// Disable automatic start of the source VM.
+// High availability is disabled.
+func (r *Client) DisableAutostart(vmRef ref.Ref) (err error) {
+	err = r.update(
+		vmRef,
+		map[string]interface{}{
+			"high_availability": map[string]interface{}{
+				"enabled": false,
+			},
+		})
+
+	return
+}
+
+//
+// Create a snapshot of the source VM.
+// Returns the snapshot ID.
+func (r *Client) CreateSnapshot(vmRef ref.Ref, name string) (id string, err error) {
+	_, err = r.Source.Inventory.VM(&vmRef)
+	if err != nil {
+		return
+	}
+	url, err := r.resource("vms", vmRef.ID, "snapshots")
+	if err != nil {
+		return
+	}
+	snapshot := &Snapshot{}
+	status, err := r.send(
+		http.MethodPost,
+		url,
+		&Snapshot{
+			Description:        name,
+			PersistMemoryState: false,
+		},
+		snapshot)
+	if err != nil {
+		return
+	}
+	if status != http.StatusOK && status != http.StatusCreated && status != http.StatusAccepted {
+		err = liberr.New(
+			fmt.Sprintf(
+				"VM %s snapshot '%s' failed: %s",
+				vmRef.String(),
+				name,
+				http.StatusText(status)))
+		return
+	}
+
+	id = snapshot.ID
+
+	return
+}
+
+//
+// Determine whether the snapshot is ready.
+// Snapshots are created asynchronously and are
+// locked until created.
+func (r *Client) SnapshotReady(vmRef ref.Ref, id string) (ready bool, err error) {
+	url, err := r.resource("vms", vmRef.ID, "snapshots", id)
+	if err != nil {
+		return
+	}
+	snapshot := &Snapshot{}
+	status, err := r.client.Get(url, snapshot)
+	if err != nil {
+		return
+	}
+	if status != http.StatusOK {
+		err = liberr.New(
+			fmt.Sprintf(
+				"VM %s snapshot %s lookup failed: %s",
+				vmRef.String(),
+				id,
+				http.StatusText(status)))
+		return
+	}
+
+	ready = snapshot.Status == SnapshotOk
+
+	return
+}
+
+//
+// Remove a snapshot of the source VM.
+func (r *Client) RemoveSnapshot(vmRef ref.Ref, id string) (err error) {
+	_, err = r.Source.Inventory.VM(&vmRef)
+	if err != nil {
+		return
+	}
+	url, err := r.resource("vms", vmRef.ID, "snapshots", id)
+	if err != nil {
+		return
+	}
+	status, err := r.send(http.MethodDelete, url, nil, nil)
+	if err != nil {
+		return
+	}
+	switch status {
+	case http.StatusOK, http.StatusAccepted, http.StatusNoContent, http.StatusNotFound:
+	default:
+		err = liberr.New(
+			fmt.Sprintf(
+				"VM %s snapshot %s removal failed: %s",
+				vmRef.String(),
+				id,
+				http.StatusText(status)))
+		return
+	}
+
+	return
+}
+
+//
+// Determine whether the snapshot has been removed.
+// Snapshots are removed asynchronously and are
+// locked until removed.
+func (r *Client) SnapshotRemoved(vmRef ref.Ref, id string) (removed bool, err error) {
+	url, err := r.resource("vms", vmRef.ID, "snapshots", id)
+	if err != nil {
+		return
+	}
+	snapshot := &Snapshot{}
+	status, err := r.client.Get(url, snapshot)
+	if err != nil {
+		return
+	}
+	switch status {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		removed = true
+	default:
+		err = liberr.New(
+			fmt.Sprintf(
+				"VM %s snapshot %s lookup failed: %s",
+				vmRef.String(),
+				id,
+				http.StatusText(status)))
+	}
+
+	return
+}
+
+//
+// Get the disk change IDs of a (warm migration) snapshot.
+// Not supported.
+func (r *Client) ChangeIds(vmRef ref.Ref, snapshot string) (changeIds map[string]string, supported bool, err error) {
+	return
+}
+
+//
+// Get the bytes changed in a (warm migration) snapshot.
+// Not supported.
+func (r *Client) ChangedBytes(vmRef ref.Ref, changeIds map[string]string, snapshot string) (bytes int64, supported bool, err error) {
+	return
+}
+
+//
+// Close the connection.
+func (r *Client) Close() {
+	r.client = nil
+}
+
+//
+// Get the VM by ref.
+func (r *Client) getVM(vmRef ref.Ref) (vm *VM, err error) {
+	_, err = r.Source.Inventory.VM(&vmRef)
+	if err != nil {
+		return
+	}
+	url, err := r.resource("vms", vmRef.ID)
+	if err != nil {
+		return
+	}
+	vm = &VM{}
+	status, err := r.client.Get(url, vm)
+	if err != nil {
+		return
+	}
+	if status != http.StatusOK {
+		err = liberr.New(
+			fmt.Sprintf(
+				"VM %s lookup failed: %s",
+				vmRef.String(),
+				http.StatusText(status)))
+		return
+	}
+
+	return
+}
+
+//
+// Perform a VM action.
+func (r *Client) action(vmRef ref.Ref, action string) (err error) {
+	url, err := r.resource("vms", vmRef.ID, action)
+	if err != nil {
+		return
+	}
+	status, err := r.client.Post(url, &Action{}, nil)
+	if err != nil {
+		return
+	}
+	if status != http.StatusOK {
+		err = liberr.New(
+			fmt.Sprintf(
+				"VM %s action '%s' failed: %s",
+				vmRef.String(),
+				action,
+				http.StatusText(status)))
+		return
+	}
+
+	return
+}
+
+//
+// Update the VM.
+func (r *Client) update(vmRef ref.Ref, in interface{}) (err error) {
+	_, err = r.Source.Inventory.VM(&vmRef)
+	if err != nil {
+		return
+	}
+	url, err := r.resource("vms", vmRef.ID)
+	if err != nil {
+		return
+	}
+	status, err := r.send(http.MethodPut, url, in, nil)
+	if err != nil {
+		return
+	}
+	if status != http.StatusOK {
+		err = liberr.New(
+			fmt.Sprintf(
+				"VM %s update failed: %s",
+				vmRef.String(),
+				http.StatusText(status)))
+		return
+	}
+
+	return
+}
+
+//
+// Send a request.
+// The (optional) body is decoded into `out` on success.
+func (r *Client) send(method, url string, in interface{}, out interface{}) (status int, err error) {
+	var body io.Reader
+	if in != nil {
+		content, mErr := json.Marshal(in)
+		if mErr != nil {
+			err = liberr.Wrap(mErr)
+			return
+		}
+		body = bytes.NewReader(content)
+	}
+	request, err := http.NewRequest(method, url, body)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	request.Header = r.client.Header
+	client := http.Client{Transport: r.client.Transport}
+	response, err := client.Do(request)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	status = response.StatusCode
+	if out != nil && status >= http.StatusOK && status < http.StatusMultipleChoices {
+		err = json.NewDecoder(response.Body).Decode(out)
+		if err != nil {
+			err = liberr.Wrap(err)
+			return
+		}
+	}
+
+	return
+}
+
+//
+// Build the resource URL.
+func (r *Client) resource(path ...string) (url string, err error) {
+	parsed, err := liburl.Parse(r.url)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	parsed.Path += "/" + strings.Join(path, "/")
+	url = parsed.String()
+
+	return
+}
+
+//
+// Connect.
+func (r *Client) connect() (err error) {
+	cacert := r.Source.Secret.Data["cacert"]
+	roots := x509.NewCertPool()
+	ok := roots.AppendCertsFromPEM(cacert)
+	if !ok {
+		err = liberr.New("failed to parse cacert")
+		return
+	}
+
+	r.url = strings.TrimRight(r.Source.Provider.Spec.URL, "/")
+	client := &libweb.Client{
+		Transport: &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+			DialContext: (&net.Dialer{
+				Timeout:   10 * time.Second,
+				KeepAlive: 10 * time.Second,
+			}).DialContext,
+			MaxIdleConns:          10,
+			IdleConnTimeout:       10 * time.Second,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ExpectContinueTimeout: 1 * time.Second,
+			TLSClientConfig:       &tls.Config{RootCAs: roots},
+		},
+	}
+	client.Header = http.Header{
+		"Accept":       []string{"application/json"},
+		"Content-Type": []string{"application/json"},
+		"Authorization": []string{
+			"Basic",
+			r.auth()},
+		"Version": []string{"4"},
+	}
+
+	r.client = client
+
+	return
+}
+
+//
+// Basic authorization user.
+func (r *Client) auth() (user string) {
+	user = strings.Join(
+		[]string{
+			string(r.Source.Secret.Data["user"]),
+			string(r.Source.Secret.Data["password"]),
+		},
+		":")
+
+	user = base64.StdEncoding.EncodeToString([]byte(user))
+
+	return
+}
+
+//
+// VM (REST) resource.
+type VM struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+//
+// Snapshot (REST) resource.
+type Snapshot struct {
+	ID                 string `json:"id,omitempty"`
+	Description        string `json:"description,omitempty"`
+	Status             string `json:"snapshot_status,omitempty"`
+	PersistMemoryState bool   `json:"persist_memorystate"`
+}
+
+//
+// Action (REST) resource.
+type Action struct {
+	Async bool `json:"async,omitempty"`
+}
+
+//
+// Tag (REST) resource.
+type Tag struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+//
+// Tag list (REST) resource.
+type TagList struct {
+	Tag []Tag `json:"tag"`
+}
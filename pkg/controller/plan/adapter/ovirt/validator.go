@@ -3,9 +3,13 @@ package ovirt
 import (
 	liberr "github.com/konveyor/controller/pkg/error"
 	api "github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1"
+	"github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1/plan"
 	"github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1/ref"
+	"github.com/konveyor/forklift-controller/pkg/controller/plan/adapter/base"
 	"github.com/konveyor/forklift-controller/pkg/controller/provider/web"
 	model "github.com/konveyor/forklift-controller/pkg/controller/provider/web/ovirt"
+	"k8s.io/apimachinery/pkg/labels"
+	"net"
 )
 
 //
@@ -24,6 +28,7 @@ func (r *Validator) Load() (err error) {
 
 //
 // Validate that a VM's networks have been mapped.
+// Excluded and overridden NICs are ignored.
 func (r *Validator) NetworksMapped(vmRef ref.Ref) (ok bool, err error) {
 	if r.plan.Referenced.Map.Network == nil {
 		return
@@ -39,7 +44,13 @@ func (r *Validator) NetworksMapped(vmRef ref.Ref) (ok bool, err error) {
 		return
 	}
 
+	planVM, _ := r.plan.Spec.FindVM(vmRef)
 	for _, nic := range vm.NICs {
+		if override, found := r.findNIC(planVM, nic.MAC, nic.ID); found {
+			if override.Exclude || override.Overridden() {
+				continue
+			}
+		}
 		if !r.plan.Referenced.Map.Network.Status.Refs.Find(ref.Ref{ID: nic.Profile.Network}) {
 			return
 		}
@@ -79,3 +90,282 @@ func (r *Validator) MaintenanceMode(_ ref.Ref) (ok bool, err error) {
 	ok = true
 	return
 }
+
+//
+// Find the VMs matched by the label selector.
+// oVirt VMs are not labeled. No-op for oVirt.
+func (r *Validator) SelectVMs(_ labels.Selector) (list []ref.Ref, err error) {
+	return
+}
+
+//
+// List the disk IDs.
+func (r *Validator) Disks(vmRef ref.Ref) (list []string, err error) {
+	vm := &model.VM{}
+	err = r.inventory.Find(vm, vmRef)
+	if err != nil {
+		err = liberr.Wrap(
+			err,
+			"VM not found in inventory.",
+			"vm",
+			vmRef.String())
+		return
+	}
+	for _, da := range vm.DiskAttachments {
+		list = append(list, da.Disk.ID)
+	}
+
+	return
+}
+
+//
+// The name of the VM cluster.
+// oVirt VMs are not organized in folders.
+func (r *Validator) Folder(vmRef ref.Ref) (name string, err error) {
+	vm := &model.VM{}
+	err = r.inventory.Find(vm, vmRef)
+	if err != nil {
+		err = liberr.Wrap(
+			err,
+			"VM not found in inventory.",
+			"vm",
+			vmRef.String())
+		return
+	}
+	cluster := &model.Cluster{}
+	err = r.inventory.Find(cluster, ref.Ref{ID: vm.Cluster})
+	if err != nil {
+		err = liberr.Wrap(
+			err,
+			"Cluster not found in inventory.",
+			"vm",
+			vmRef.String(),
+			"cluster",
+			vm.Cluster)
+		return
+	}
+
+	name = cluster.Name
+	return
+}
+
+//
+// Resources required by a VM on the destination.
+// The disk storage class is resolved using the disk
+// overrides and the storage map.
+func (r *Validator) Requirements(vmRef ref.Ref) (required *base.Requirements, err error) {
+	vm := &model.VM{}
+	err = r.inventory.Find(vm, vmRef)
+	if err != nil {
+		err = liberr.Wrap(
+			err,
+			"VM not found in inventory.",
+			"vm",
+			vmRef.String())
+		return
+	}
+	planVM, _ := r.plan.Spec.FindVM(vmRef)
+	required = &base.Requirements{
+		CPU:    int64(vm.CpuSockets) * int64(vm.CpuCores),
+		Memory: vm.Memory,
+	}
+	for _, da := range vm.DiskAttachments {
+		storageClass := ""
+		if planVM != nil {
+			if disk, found := planVM.FindDisk(da.Disk.ID); found {
+				if disk.Exclude {
+					continue
+				}
+				storageClass = disk.StorageClass
+			}
+		}
+		if storageClass == "" {
+			storageClass, err = r.storageClass(da.Disk.StorageDomain)
+			if err != nil {
+				return
+			}
+		}
+		required.Disks = append(
+			required.Disks,
+			base.DiskRequirement{
+				StorageClass: storageClass,
+				Capacity:     da.Disk.ProvisionedSize,
+			})
+	}
+
+	return
+}
+
+//
+// List the devices of a VM that cannot be migrated.
+// Not reported by the oVirt inventory.
+func (r *Validator) UnsupportedDevices(_ ref.Ref) (list []string, err error) {
+	return
+}
+
+//
+// The host and cluster running the VM.
+// The host is not set when the VM is not running.
+func (r *Validator) Placement(vmRef ref.Ref) (host ref.Ref, cluster ref.Ref, err error) {
+	vm := &model.VM{}
+	err = r.inventory.Find(vm, vmRef)
+	if err != nil {
+		err = liberr.Wrap(
+			err,
+			"VM not found in inventory.",
+			"vm",
+			vmRef.String())
+		return
+	}
+	if vm.Host != "" {
+		hostModel := &model.Host{}
+		err = r.inventory.Find(hostModel, ref.Ref{ID: vm.Host})
+		if err != nil {
+			err = liberr.Wrap(
+				err,
+				"Host not found in inventory.",
+				"vm",
+				vmRef.String(),
+				"host",
+				vm.Host)
+			return
+		}
+		host = ref.Ref{
+			ID:   hostModel.ID,
+			Name: hostModel.Name,
+		}
+	}
+	clusterModel := &model.Cluster{}
+	err = r.inventory.Find(clusterModel, ref.Ref{ID: vm.Cluster})
+	if err != nil {
+		err = liberr.Wrap(
+			err,
+			"Cluster not found in inventory.",
+			"vm",
+			vmRef.String(),
+			"cluster",
+			vm.Cluster)
+		return
+	}
+	cluster = ref.Ref{
+		ID:   clusterModel.ID,
+		Name: clusterModel.Name,
+	}
+
+	return
+}
+
+//
+// Validate that a transfer network is reachable.
+// Disks are transferred through the engine (imageio).
+func (r *Validator) TransferReachable(_ ref.Ref, _ []*net.IPNet) (ok bool, err error) {
+	ok = true
+	return
+}
+
+//
+// Find the storage class mapped to a storage domain.
+func (r *Validator) storageClass(domainID string) (storageClass string, err error) {
+	dsMap := r.plan.Referenced.Map.Storage
+	if dsMap == nil {
+		return
+	}
+	for _, mapped := range dsMap.Spec.Map {
+		domain := &model.StorageDomain{}
+		err = r.inventory.Find(domain, mapped.Source)
+		if err != nil {
+			err = liberr.Wrap(err)
+			return
+		}
+		if domain.ID == domainID {
+			storageClass = mapped.Destination.StorageClass
+			break
+		}
+	}
+
+	return
+}
+
+//
+// Validate that a disk override is supported.
+// VMIO maps disks individually.
+func (r *Validator) DiskOverrideSupported(vmRef ref.Ref, disk *plan.Disk) (bool, error) {
+	return true, nil
+}
+
+//
+// Validate a VM's NIC overrides.
+// VMIO maps oVirt NICs by vNIC profile. Overrides must be
+// consistent for all the NICs using the same vNIC profile.
+// VMIO requires all profiles to be mapped so excluding all
+// the NICs using a profile requires the network to be mapped.
+func (r *Validator) NICOverrides(vmRef ref.Ref) (notFound []string, notSupported []string, err error) {
+	planVM, found := r.plan.Spec.FindVM(vmRef)
+	if !found || len(planVM.NICs) == 0 {
+		return
+	}
+	vm := &model.VM{}
+	err = r.inventory.Find(vm, vmRef)
+	if err != nil {
+		err = liberr.Wrap(
+			err,
+			"VM not found in inventory.",
+			"vm",
+			vmRef.String())
+		return
+	}
+	matched := map[string]bool{}
+	destinations := map[string]map[string]bool{}
+	for _, nic := range vm.NICs {
+		override, found := r.findNIC(planVM, nic.MAC, nic.ID)
+		destination := ""
+		if found {
+			matched[override.ID] = true
+			if override.Exclude {
+				continue
+			}
+			destination = override.Destination()
+		}
+		if destinations[nic.Profile.ID] == nil {
+			destinations[nic.Profile.ID] = map[string]bool{}
+		}
+		destinations[nic.Profile.ID][destination] = true
+	}
+	for _, nic := range vm.NICs {
+		override, found := r.findNIC(planVM, nic.MAC, nic.ID)
+		if !found || override.Exclude {
+			continue
+		}
+		if override.Overridden() && len(destinations[nic.Profile.ID]) > 1 {
+			notSupported = append(notSupported, override.ID)
+		}
+	}
+	for _, nic := range vm.NICs {
+		override, found := r.findNIC(planVM, nic.MAC, nic.ID)
+		if !found || !override.Exclude || len(destinations[nic.Profile.ID]) > 0 {
+			continue
+		}
+		mapped := r.plan.Referenced.Map.Network != nil &&
+			r.plan.Referenced.Map.Network.Status.Refs.Find(ref.Ref{ID: nic.Profile.Network})
+		if !mapped {
+			notSupported = append(notSupported, override.ID)
+		}
+	}
+	for _, override := range planVM.NICs {
+		if !matched[override.ID] {
+			notFound = append(notFound, override.ID)
+		}
+	}
+
+	return
+}
+
+//
+// Find the NIC override by MAC or NIC ID.
+func (r *Validator) findNIC(planVM *plan.VM, mac, id string) (override *plan.NIC, found bool) {
+	if planVM == nil {
+		return
+	}
+	override, found = planVM.FindNIC(mac, id)
+	return
+}
@@ -4,7 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 
 	libcnd "github.com/konveyor/controller/pkg/condition"
 	liberr "github.com/konveyor/controller/pkg/error"
@@ -20,12 +24,31 @@ import (
 	"github.com/vmware/govmomi/vim25/types"
 	"gopkg.in/yaml.v2"
 	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	cnv "kubevirt.io/client-go/api/v1"
 	cdi "kubevirt.io/containerized-data-importer/pkg/apis/core/v1beta1"
 	vmio "kubevirt.io/vm-import-operator/pkg/apis/v2v/v1beta1"
 	liburl "net/url"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+//
+// Device kinds which cannot be migrated.
+const (
+	PciPassthrough  = "VirtualPCIPassthrough"
+	ScsiPassthrough = "VirtualSCSIPassthrough"
+)
+
+//
+// Position of the disk controller buses in the
+// (default) source device order.
+var busOrder = map[string]int64{
+	"ide":  0,
+	"sata": 1,
+	"scsi": 2,
+	"nvme": 3,
+}
+
 //
 // Regex which matches the snapshot identifier suffix of a
 // vSphere disk backing file.
@@ -52,7 +75,7 @@ func (r *Builder) Secret(vmRef ref.Ref, in, object *core.Secret) (err error) {
 	if hostDef, found := r.hosts[hostID]; found {
 		hostURL := liburl.URL{
 			Scheme: "https",
-			Host:   hostDef.Spec.IpAddress,
+			Host:   hostDef.TransferIp(),
 			Path:   vim25.Path,
 		}
 		url = hostURL.String()
@@ -154,12 +177,19 @@ func (r *Builder) Tasks(vmRef ref.Ref) (list []*plan.Task, err error) {
 				pErr.Error()))
 		return
 	}
+	planVM, _ := r.Plan.Spec.FindVM(vmRef)
 	for _, disk := range vm.Disks {
+		name := r.trimBackingFileName(disk.File)
+		if planVM != nil {
+			if override, found := planVM.FindDisk(name); found && override.Exclude {
+				continue
+			}
+		}
 		mB := disk.Capacity / 0x100000
 		list = append(
 			list,
 			&plan.Task{
-				Name: r.trimBackingFileName(disk.File),
+				Name: name,
 				Progress: libitr.Progress{
 					Total: mB,
 				},
@@ -172,12 +202,304 @@ func (r *Builder) Tasks(vmRef ref.Ref) (list []*plan.Task, err error) {
 	return
 }
 
+//
+// Return the names of the DataVolumes the import would
+// create for the excluded disks.
+// VMIO names the DataVolumes using the VM UUID and disk key.
+func (r *Builder) ExcludedDataVolumes(vmRef ref.Ref, vmName string) (names []string, err error) {
+	planVM, found := r.Plan.Spec.FindVM(vmRef)
+	if !found {
+		return
+	}
+	vm := &model.VM{}
+	pErr := r.Source.Inventory.Find(vm, vmRef)
+	if pErr != nil {
+		err = liberr.New(
+			fmt.Sprintf(
+				"VM %s lookup failed: %s",
+				vmRef.String(),
+				pErr.Error()))
+		return
+	}
+	for _, disk := range vm.Disks {
+		override, found := planVM.FindDisk(r.trimBackingFileName(disk.File))
+		if found && override.Exclude {
+			names = append(names, fmt.Sprintf("%s-%d", vm.UUID, disk.Key))
+		}
+	}
+
+	return
+}
+
 //
 // Return a stable identifier for a VDDK DataVolume.
 func (r *Builder) ResolveDataVolumeIdentifier(dv *cdi.DataVolume) string {
 	return r.trimBackingFileName(dv.Spec.Source.VDDK.BackingFile)
 }
 
+//
+// Return the IP addresses reported by VMware Tools.
+func (r *Builder) GuestIPs(vmRef ref.Ref) (list []string, err error) {
+	vm := &model.VM{}
+	pErr := r.Source.Inventory.Find(vm, vmRef)
+	if pErr != nil {
+		err = liberr.New(
+			fmt.Sprintf(
+				"VM %s lookup failed: %s",
+				vmRef.String(),
+				pErr.Error()))
+		return
+	}
+	for _, network := range vm.GuestNetworks {
+		for _, ip := range network.IPs {
+			list = append(list, ip.Address)
+		}
+	}
+
+	return
+}
+
+//
+// Return the static network configuration reported by VMware Tools.
+// Addresses assigned by DHCP, link-layer and random (privacy)
+// addresses are omitted. The interface names are not reported.
+func (r *Builder) GuestNetwork(vmRef ref.Ref) (network *plan.GuestNetwork, err error) {
+	vm := &model.VM{}
+	pErr := r.Source.Inventory.Find(vm, vmRef)
+	if pErr != nil {
+		err = liberr.New(
+			fmt.Sprintf(
+				"VM %s lookup failed: %s",
+				vmRef.String(),
+				pErr.Error()))
+		return
+	}
+	network = &plan.GuestNetwork{
+		Gateways: vm.GuestGateways,
+		DNS:      vm.GuestDNS,
+	}
+	switch vm.GuestFamily {
+	case string(types.VirtualMachineGuestOsFamilyLinuxGuest):
+		network.OS = plan.GuestLinux
+	case string(types.VirtualMachineGuestOsFamilyWindowsGuest):
+		network.OS = plan.GuestWindows
+	}
+	for _, nic := range vm.GuestNetworks {
+		if nic.MAC == "" {
+			continue
+		}
+		guestInterface := plan.GuestInterface{
+			MAC:       nic.MAC,
+			Addresses: []string{},
+		}
+		for _, ip := range nic.IPs {
+			switch types.NetIpConfigInfoIpAddressOrigin(ip.Origin) {
+			case types.NetIpConfigInfoIpAddressOriginDhcp,
+				types.NetIpConfigInfoIpAddressOriginLinklayer,
+				types.NetIpConfigInfoIpAddressOriginRandom:
+				continue
+			}
+			if cidr, valid := plan.GuestAddress(ip.Address, int(ip.PrefixLength)); valid {
+				guestInterface.Addresses = append(guestInterface.Addresses, cidr)
+			}
+		}
+		if len(guestInterface.Addresses) > 0 {
+			network.Interfaces = append(network.Interfaces, guestInterface)
+		}
+	}
+
+	return
+}
+
+//
+// Build the VMI template.
+func (r *Builder) Template(vmRef ref.Ref, object *cnv.VirtualMachineInstanceSpec) (err error) {
+	vm := &model.VM{}
+	pErr := r.Source.Inventory.Find(vm, vmRef)
+	if pErr != nil {
+		err = liberr.New(
+			fmt.Sprintf(
+				"VM %s lookup failed: %s",
+				vmRef.String(),
+				pErr.Error()))
+		return
+	}
+	cores := vm.CoresPerSocket
+	if cores < 1 {
+		cores = 1
+	}
+	sockets := vm.CpuCount / cores
+	if sockets < 1 {
+		sockets = 1
+	}
+	object.Domain.CPU = &cnv.CPU{
+		Sockets: uint32(sockets),
+		Cores:   uint32(cores),
+	}
+	memory := resource.MustParse(fmt.Sprintf("%dMi", vm.MemoryMB))
+	object.Domain.Resources.Requests = core.ResourceList{
+		core.ResourceMemory: memory,
+	}
+	if vm.Firmware == "efi" {
+		secureBoot := vm.SecureBoot
+		object.Domain.Firmware = &cnv.Firmware{
+			Bootloader: &cnv.Bootloader{
+				EFI: &cnv.EFI{
+					SecureBoot: &secureBoot,
+				},
+			},
+		}
+	}
+
+	return
+}
+
+//
+// Build the devices of the VM created by VMIO.
+// VMIO attaches all disks to the virtio bus in an arbitrary
+// order. The disks are attached to the bus of the source
+// controller and ordered as on the source so that the first
+// disk is the boot disk. The MAC address and (emulated) model
+// of the source NICs are retained unless the model is
+// overridden by the plan.
+func (r *Builder) Devices(vmRef ref.Ref, dvs []*cdi.DataVolume, object *cnv.VirtualMachineInstanceSpec) (err error) {
+	vm := &model.VM{}
+	pErr := r.Source.Inventory.Find(vm, vmRef)
+	if pErr != nil {
+		err = liberr.New(
+			fmt.Sprintf(
+				"VM %s lookup failed: %s",
+				vmRef.String(),
+				pErr.Error()))
+		return
+	}
+	devices := &object.Domain.Devices
+	// Disks.
+	sourceDisks := map[string]int{}
+	for i := range vm.Disks {
+		sourceDisks[r.trimBackingFileName(vm.Disks[i].File)] = i
+	}
+	dvDisks := map[string]int{}
+	for _, dv := range dvs {
+		if dv.Spec.Source.VDDK == nil {
+			continue
+		}
+		if index, found := sourceDisks[r.ResolveDataVolumeIdentifier(dv)]; found {
+			dvDisks[dv.Name] = index
+		}
+	}
+	diskMap := map[string]int{}
+	for _, volume := range object.Volumes {
+		if volume.DataVolume == nil {
+			continue
+		}
+		if index, found := dvDisks[volume.DataVolume.Name]; found {
+			diskMap[volume.Name] = index
+		}
+	}
+	for i := range devices.Disks {
+		disk := &devices.Disks[i]
+		index, found := diskMap[disk.Name]
+		if !found || disk.Disk == nil {
+			continue
+		}
+		disk.Disk.Bus = r.diskBus(vm.Disks[index].Bus)
+	}
+	position := func(name string) int64 {
+		index, found := diskMap[name]
+		if !found {
+			return math.MaxInt64
+		}
+		return r.diskPosition(vm, index)
+	}
+	sort.SliceStable(
+		devices.Disks,
+		func(i, j int) bool {
+			return position(devices.Disks[i].Name) < position(devices.Disks[j].Name)
+		})
+	if len(devices.Disks) > 0 {
+		if _, found := diskMap[devices.Disks[0].Name]; found {
+			bootOrder := uint(1)
+			devices.Disks[0].BootOrder = &bootOrder
+		}
+	}
+	// NICs.
+	planVM, _ := r.Plan.Spec.FindVM(vmRef)
+	nicMap := map[string]int{}
+	for i := range vm.NICs {
+		nicMap[strings.ToLower(vm.NICs[i].MAC)] = i
+	}
+	for i := range devices.Interfaces {
+		iface := &devices.Interfaces[i]
+		index, found := nicMap[strings.ToLower(iface.MacAddress)]
+		if !found {
+			continue
+		}
+		nic := &vm.NICs[index]
+		iface.MacAddress = nic.MAC
+		iface.Model = r.nicModel(nic.Type)
+		if planVM != nil {
+			override, found := planVM.FindNIC(nic.MAC, strconv.Itoa(int(nic.Key)))
+			if found && override.Model != "" {
+				iface.Model = override.Model
+			}
+		}
+	}
+
+	return
+}
+
+//
+// The (destination) disk bus.
+// Disks on IDE and SATA controllers are attached
+// to the SATA bus and disks on SCSI controllers to
+// the SCSI bus. Otherwise, the virtio bus.
+func (r *Builder) diskBus(controllerBus string) (bus string) {
+	switch controllerBus {
+	case "ide", "sata":
+		bus = "sata"
+	case "scsi":
+		bus = "scsi"
+	default:
+		bus = "virtio"
+	}
+
+	return
+}
+
+//
+// The position of the disk in the (default) source
+// device order: controller bus, controller bus number
+// and unit number.
+func (r *Builder) diskPosition(vm *model.VM, index int) (position int64) {
+	disk := &vm.Disks[index]
+	position = busOrder[disk.Bus] * 100
+	for _, controller := range vm.Controllers {
+		if controller.Key == disk.Controller {
+			position += int64(controller.BusNumber)
+			break
+		}
+	}
+	position = position*100 + int64(disk.UnitNumber)
+
+	return
+}
+
+//
+// The (destination) NIC model.
+// The emulated e1000 and e1000e adapters are retained.
+// Otherwise, virtio.
+func (r *Builder) nicModel(nicType string) (nicModel string) {
+	switch nicType {
+	case "e1000", "e1000e":
+		nicModel = nicType
+	default:
+		nicModel = "virtio"
+	}
+
+	return
+}
+
 //
 // Load
 func (r *Builder) Load() (err error) {
@@ -317,43 +639,48 @@ func (r *Builder) host(hostID string) (host *model.Host, err error) {
 func (r *Builder) mapping(vm *model.VM) (out *vmio.VmwareMappings, err error) {
 	netMap := []vmio.NetworkResourceMappingItem{}
 	dsMap := []vmio.StorageResourceMappingItem{}
-	netMapIn := r.Context.Map.Network.Spec.Map
-	for i := range netMapIn {
-		mapped := &netMapIn[i]
-		ref := mapped.Source
+	planVM, _ := r.Plan.Spec.FindVM(ref.Ref{ID: vm.ID})
+	for _, vmNet := range vm.Networks {
+		destinations, shared, dErr := r.nicDestinations(vm, planVM, vmNet.ID)
+		if dErr != nil {
+			err = dErr
+			return
+		}
+		if len(destinations) == 0 {
+			continue
+		}
 		network := &model.Network{}
-		fErr := r.Source.Inventory.Find(network, ref)
+		fErr := r.Source.Inventory.Find(network, ref.Ref{ID: vmNet.ID})
 		if fErr != nil {
 			err = fErr
 			return
 		}
-		needed := false
-		for _, net := range vm.Networks {
-			if net.ID == network.ID {
-				needed = true
+		if shared {
+			id, pErr := r.networkID(vm, network)
+			if pErr != nil {
+				err = pErr
+				return
+			}
+			for _, destination := range destinations {
+				netMap = append(netMap, r.networkMapping(vmio.Source{ID: &id}, destination))
 				break
 			}
-		}
-		if !needed {
 			continue
 		}
-		id, pErr := r.networkID(vm, network)
-		if pErr != nil {
-			err = pErr
+		// VMIO matches NICs by label only when connected
+		// to a distributed port group.
+		if network.DVSwitch == nil {
+			err = liberr.New(
+				fmt.Sprintf(
+					"VM %s NICs connected to network %s cannot be mapped individually.",
+					vm.ID,
+					network.Name))
 			return
 		}
-		netMap = append(
-			netMap,
-			vmio.NetworkResourceMappingItem{
-				Source: vmio.Source{
-					ID: &id,
-				},
-				Target: vmio.ObjectIdentifier{
-					Namespace: &mapped.Destination.Namespace,
-					Name:      mapped.Destination.Name,
-				},
-				Type: &mapped.Destination.Type,
-			})
+		for label, destination := range destinations {
+			name := label
+			netMap = append(netMap, r.networkMapping(vmio.Source{Name: &name}, destination))
+		}
 	}
 	dsMapIn := r.Context.Map.Storage.Spec.Map
 	for i := range dsMapIn {
@@ -402,9 +729,192 @@ func (r *Builder) mapping(vm *model.VM) (out *vmio.VmwareMappings, err error) {
 		}*/
 		dsMap = append(dsMap, item)
 	}
+	diskMap, err := r.diskMapping(vm, planVM)
+	if err != nil {
+		return
+	}
 	out = &vmio.VmwareMappings{
 		NetworkMappings: &netMap,
 		StorageMappings: &dsMap,
+		DiskMappings:    &diskMap,
+	}
+
+	return
+}
+
+//
+// Build the disk mappings for the (plan) VM disk overrides.
+// Settings not overridden are inherited from the storage map.
+// VMIO matches the disks by disk ID.
+func (r *Builder) diskMapping(vm *model.VM, planVM *plan.VM) (diskMap []vmio.StorageResourceMappingItem, err error) {
+	diskMap = []vmio.StorageResourceMappingItem{}
+	if planVM == nil {
+		return
+	}
+	for _, disk := range vm.Disks {
+		override, found := planVM.FindDisk(r.trimBackingFileName(disk.File))
+		if !found || override.Exclude || !override.Overridden() {
+			continue
+		}
+		if disk.ObjectID == "" {
+			err = liberr.New(
+				fmt.Sprintf(
+					"VM %s disk %s cannot be mapped: disk ID not reported.",
+					vm.ID,
+					override.ID))
+			return
+		}
+		destination := api.DestinationStorage{}
+		mapped, found, mErr := r.mappedStorage(disk.Datastore.ID)
+		if mErr != nil {
+			err = mErr
+			return
+		}
+		if found {
+			destination = mapped.Destination
+		}
+		if override.StorageClass != "" && override.StorageClass != destination.StorageClass {
+			destination = api.DestinationStorage{
+				StorageClass: override.StorageClass,
+			}
+		}
+		if override.VolumeMode != "" && override.VolumeMode != destination.VolumeMode {
+			destination.VolumeMode = override.VolumeMode
+			destination.AccessMode = ""
+		}
+		if override.AccessMode != "" {
+			destination.AccessMode = override.AccessMode
+		}
+		mErr = r.defaultModes(&destination)
+		if mErr != nil {
+			err = mErr
+			return
+		}
+		id := disk.ObjectID
+		item := vmio.StorageResourceMappingItem{
+			Source: vmio.Source{
+				ID: &id,
+			},
+			Target: vmio.ObjectIdentifier{
+				Name: destination.StorageClass,
+			},
+		}
+		if destination.VolumeMode != "" {
+			item.VolumeMode = &destination.VolumeMode
+		}
+		if destination.AccessMode != "" {
+			item.AccessMode = &destination.AccessMode
+		}
+		diskMap = append(diskMap, item)
+	}
+
+	return
+}
+
+//
+// Find the storage map entry for a datastore.
+func (r *Builder) mappedStorage(dsID string) (mapped *api.StoragePair, found bool, err error) {
+	list := r.Context.Map.Storage.Spec.Map
+	for i := range list {
+		ds := &model.Datastore{}
+		err = r.Source.Inventory.Find(ds, list[i].Source)
+		if err != nil {
+			return
+		}
+		if ds.ID == dsID {
+			mapped = &list[i]
+			found = true
+			break
+		}
+	}
+
+	return
+}
+
+//
+// Destinations of the NICs connected to a source network
+// keyed by NIC label. NIC overrides take precedence over the
+// network map. Excluded NICs and NICs connected to a network
+// that is not mapped are omitted. Shared when all the NICs
+// have the same destination and may be mapped by network.
+func (r *Builder) nicDestinations(vm *model.VM, planVM *plan.VM, networkID string) (destinations map[string]api.DestinationNetwork, shared bool, err error) {
+	mapped, found, err := r.mappedNetwork(networkID)
+	if err != nil {
+		return
+	}
+	destinations = map[string]api.DestinationNetwork{}
+	shared = true
+	var first *api.DestinationNetwork
+	for _, nic := range vm.NICs {
+		if nic.Network.ID != networkID {
+			continue
+		}
+		var override *plan.NIC
+		if planVM != nil {
+			override, _ = planVM.FindNIC(nic.MAC, strconv.Itoa(int(nic.Key)))
+		}
+		var destination api.DestinationNetwork
+		switch {
+		case override != nil && override.Exclude:
+			shared = false
+			continue
+		case override != nil && override.Pod:
+			destination = api.DestinationNetwork{
+				Type: "pod",
+			}
+		case override != nil && override.Network != nil:
+			destination = api.DestinationNetwork{
+				Type:      "multus",
+				Namespace: override.Network.Namespace,
+				Name:      override.Network.Name,
+			}
+		case found:
+			destination = mapped.Destination
+		default:
+			shared = false
+			continue
+		}
+		if first == nil {
+			first = &destination
+		} else if first.Type != destination.Type ||
+			first.Namespace != destination.Namespace ||
+			first.Name != destination.Name {
+			shared = false
+		}
+		destinations[nic.Label] = destination
+	}
+
+	return
+}
+
+//
+// Build a network mapping item.
+func (r *Builder) networkMapping(source vmio.Source, destination api.DestinationNetwork) vmio.NetworkResourceMappingItem {
+	return vmio.NetworkResourceMappingItem{
+		Source: source,
+		Target: vmio.ObjectIdentifier{
+			Namespace: &destination.Namespace,
+			Name:      destination.Name,
+		},
+		Type: &destination.Type,
+	}
+}
+
+//
+// Find the network map entry for a source network.
+func (r *Builder) mappedNetwork(networkID string) (mapped *api.NetworkPair, found bool, err error) {
+	list := r.Context.Map.Network.Spec.Map
+	for i := range list {
+		network := &model.Network{}
+		err = r.Source.Inventory.Find(network, list[i].Source)
+		if err != nil {
+			return
+		}
+		if network.ID == networkID {
+			mapped = &list[i]
+			found = true
+			break
+		}
 	}
 
 	return
@@ -465,7 +975,7 @@ func (r *Builder) esxHost(vm *model.VM) (esxHost *EsxHost, found bool, err error
 	}
 	hostURL := liburl.URL{
 		Scheme: "https",
-		Host:   hostDef.Spec.IpAddress,
+		Host:   hostDef.TransferIp(),
 		Path:   vim25.Path,
 	}
 	url = hostURL.String()
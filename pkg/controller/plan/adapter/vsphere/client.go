@@ -0,0 +1,721 @@
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	liberr "github.com/konveyor/controller/pkg/error"
+	"github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1/ref"
+	plancontext "github.com/konveyor/forklift-controller/pkg/controller/plan/context"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/session"
+	"github.com/vmware/govmomi/vapi/rest"
+	"github.com/vmware/govmomi/vapi/tags"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/soap"
+	"github.com/vmware/govmomi/vim25/types"
+	liburl "net/url"
+	"path"
+	"strconv"
+	"time"
+)
+
+//
+// Settings.
+const (
+	// Task timeout.
+	TaskTimeout = time.Minute * 5
+)
+
+//
+// vSphere VM Client.
+type Client struct {
+	*plancontext.Context
+	// vSphere client.
+	client *govmomi.Client
+}
+
+//
+// Power on the source VM.
+func (r *Client) PowerOn(vmRef ref.Ref) (err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), TaskTimeout)
+	defer cancel()
+	vm, err := r.getVM(vmRef)
+	if err != nil {
+		return
+	}
+	state, err := vm.PowerState(ctx)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	if state == types.VirtualMachinePowerStatePoweredOn {
+		return
+	}
+	task, err := vm.PowerOn(ctx)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	err = task.Wait(ctx)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+
+	return
+}
+
+//
+// Power off the source VM.
+func (r *Client) PowerOff(vmRef ref.Ref) (err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), TaskTimeout)
+	defer cancel()
+	vm, err := r.getVM(vmRef)
+	if err != nil {
+		return
+	}
+	state, err := vm.PowerState(ctx)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	if state == types.VirtualMachinePowerStatePoweredOff {
+		return
+	}
+	task, err := vm.PowerOff(ctx)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	err = task.Wait(ctx)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+
+	return
+}
+
+//
+// Determine whether the source VM is powered off.
+func (r *Client) PoweredOff(vmRef ref.Ref) (off bool, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), TaskTimeout)
+	defer cancel()
+	vm, err := r.getVM(vmRef)
+	if err != nil {
+		return
+	}
+	state, err := vm.PowerState(ctx)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+
+	off = state == types.VirtualMachinePowerStatePoweredOff
+
+	return
+}
+
+//
+// Shut down the guest OS of the source VM.
+func (r *Client) Shutdown(vmRef ref.Ref) (err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), TaskTimeout)
+	defer cancel()
+	vm, err := r.getVM(vmRef)
+	if err != nil {
+		return
+	}
+	state, err := vm.PowerState(ctx)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	if state == types.VirtualMachinePowerStatePoweredOff {
+		return
+	}
+	err = vm.ShutdownGuest(ctx)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+
+	return
+}
+
+//
+// Rename the source VM.
+func (r *Client) Rename(vmRef ref.Ref, name string) (err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), TaskTimeout)
+	defer cancel()
+	vm, err := r.getVM(vmRef)
+	if err != nil {
+		return
+	}
+	task, err := vm.Rename(ctx, name)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	err = task.Wait(ctx)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+
+	return
+}
+
+//
+// Move the source VM into a folder.
+// The folder is an inventory path. Example: /dc/vm/migrated.
+func (r *Client) MoveToFolder(vmRef ref.Ref, folder string) (err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), TaskTimeout)
+	defer cancel()
+	vm, err := r.getVM(vmRef)
+	if err != nil {
+		return
+	}
+	finder := find.NewFinder(r.client.Client)
+	object, err := finder.Folder(ctx, folder)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	task, err := object.MoveInto(
+		ctx,
+		[]types.ManagedObjectReference{
+			vm.Reference(),
+		})
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	err = task.Wait(ctx)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+
+	return
+}
+
+//
+// The inventory path of the folder containing the source VM.
+// Example: /dc/vm/production.
+func (r *Client) Folder(vmRef ref.Ref) (folder string, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), TaskTimeout)
+	defer cancel()
+	vm, err := r.getVM(vmRef)
+	if err != nil {
+		return
+	}
+	finder := find.NewFinder(r.client.Client)
+	element, err := finder.Element(ctx, vm.Reference())
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+
+	folder = path.Dir(element.Path)
+
+	return
+}
+
+//
+// Attach an (existing) tag to the source VM.
+// The tag is specified by name or ID.
+func (r *Client) Tag(vmRef ref.Ref, tag string) (err error) {
+	err = r.tagging(
+		vmRef,
+		tag,
+		func(ctx context.Context, manager *tags.Manager, tagID string, vm types.ManagedObjectReference) error {
+			return manager.AttachTag(ctx, tagID, vm)
+		})
+
+	return
+}
+
+//
+// Detach a tag from the source VM.
+// The tag is specified by name or ID.
+func (r *Client) Untag(vmRef ref.Ref, tag string) (err error) {
+	err = r.tagging(
+		vmRef,
+		tag,
+		func(ctx context.Context, manager *tags.Manager, tagID string, vm types.ManagedObjectReference) error {
+			return manager.DetachTag(ctx, tagID, vm)
+		})
+
+	return
+}
+
+//
+// Perform a tagging operation on the source VM.
+// Tags are managed using the REST (vapi) API.
+func (r *Client) tagging(
+	vmRef ref.Ref,
+	tag string,
+	operation func(context.Context, *tags.Manager, string, types.ManagedObjectReference) error) (err error) {
+	//
+	ctx, cancel := context.WithTimeout(context.Background(), TaskTimeout)
+	defer cancel()
+	vm, err := r.getVM(vmRef)
+	if err != nil {
+		return
+	}
+	client := rest.NewClient(r.client.Client)
+	err = client.Login(
+		ctx,
+		liburl.UserPassword(
+			r.user(),
+			r.password()))
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	defer func() {
+		_ = client.Logout(context.Background())
+	}()
+	manager := tags.NewManager(client)
+	object, err := manager.GetTag(ctx, tag)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	err = operation(ctx, manager, object.ID, vm.Reference())
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+
+	return
+}
+
+//
+// Disable automatic start of the source VM.
+// The VM start action is cleared on the host autostart manager.
+func (r *Client) DisableAutostart(vmRef ref.Ref) (err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), TaskTimeout)
+	defer cancel()
+	vm, err := r.getVM(vmRef)
+	if err != nil {
+		return
+	}
+	host, err := vm.HostSystem(ctx)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	hostMo := mo.HostSystem{}
+	err = host.Properties(
+		ctx,
+		host.Reference(),
+		[]string{"configManager.autoStartManager"},
+		&hostMo)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	manager := hostMo.ConfigManager.AutoStartManager
+	if manager == nil {
+		return
+	}
+	_, err = methods.ReconfigureAutostart(
+		ctx,
+		r.client.Client,
+		&types.ReconfigureAutostart{
+			This: *manager,
+			Spec: types.HostAutoStartManagerConfig{
+				PowerInfo: []types.AutoStartPowerInfo{
+					{
+						Key:              vm.Reference(),
+						StartOrder:       -1,
+						StartDelay:       -1,
+						WaitForHeartbeat: types.AutoStartWaitHeartbeatSettingSystemDefault,
+						StartAction:      "none",
+						StopDelay:        -1,
+						StopAction:       "systemDefault",
+					},
+				},
+			},
+		})
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+
+	return
+}
+
+//
+// Create a snapshot of the source VM.
+// Returns the snapshot (managed object) ID.
+func (r *Client) CreateSnapshot(vmRef ref.Ref, name string) (id string, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), TaskTimeout)
+	defer cancel()
+	vm, err := r.getVM(vmRef)
+	if err != nil {
+		return
+	}
+	task, err := vm.CreateSnapshot(
+		ctx,
+		name,
+		"Created by forklift before migration.",
+		false,
+		false)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	info, err := task.WaitForResult(ctx)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	if snapshot, cast := info.Result.(types.ManagedObjectReference); cast {
+		id = snapshot.Value
+	}
+
+	return
+}
+
+//
+// Determine whether the snapshot is ready.
+// The snapshot is ready once found in the VM snapshot tree.
+func (r *Client) SnapshotReady(vmRef ref.Ref, id string) (ready bool, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), TaskTimeout)
+	defer cancel()
+	vm, err := r.getVM(vmRef)
+	if err != nil {
+		return
+	}
+	object := mo.VirtualMachine{}
+	err = vm.Properties(ctx, vm.Reference(), []string{"snapshot"}, &object)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	if object.Snapshot != nil {
+		ready = r.hasSnapshot(object.Snapshot.RootSnapshotList, id)
+	}
+	if !ready {
+		err = liberr.New(
+			fmt.Sprintf(
+				"VM %s snapshot %s not found.",
+				vmRef.String(),
+				id))
+	}
+
+	return
+}
+
+//
+// Remove a snapshot of the source VM.
+// The disks are consolidated. The removal task is
+// started but not waited on (see: SnapshotRemoved()).
+func (r *Client) RemoveSnapshot(vmRef ref.Ref, id string) (err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), TaskTimeout)
+	defer cancel()
+	vm, err := r.getVM(vmRef)
+	if err != nil {
+		return
+	}
+	consolidate := true
+	_, err = vm.RemoveSnapshot(ctx, id, false, &consolidate)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+
+	return
+}
+
+//
+// Determine whether the snapshot has been removed.
+// The removal is in progress while the task is found queued
+// or running in the recent tasks of the VM. The snapshot has
+// been removed once no longer found in the VM snapshot tree
+// and the disks do not need to be consolidated.
+func (r *Client) SnapshotRemoved(vmRef ref.Ref, id string) (removed bool, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), TaskTimeout)
+	defer cancel()
+	vm, err := r.getVM(vmRef)
+	if err != nil {
+		return
+	}
+	object := mo.VirtualMachine{}
+	err = vm.Properties(
+		ctx,
+		vm.Reference(),
+		[]string{"snapshot", "runtime", "recentTask"},
+		&object)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	var failed *types.LocalizedMethodFault
+	if len(object.RecentTask) > 0 {
+		tasks := []mo.Task{}
+		err = property.DefaultCollector(r.client.Client).Retrieve(
+			ctx,
+			object.RecentTask,
+			[]string{"info"},
+			&tasks)
+		if err != nil {
+			err = liberr.Wrap(err)
+			return
+		}
+		for _, task := range tasks {
+			if task.Info.DescriptionId != "VirtualMachineSnapshot.remove" {
+				continue
+			}
+			switch task.Info.State {
+			case types.TaskInfoStateQueued, types.TaskInfoStateRunning:
+				return
+			case types.TaskInfoStateError:
+				failed = task.Info.Error
+			}
+		}
+	}
+	if object.Snapshot != nil && r.hasSnapshot(object.Snapshot.RootSnapshotList, id) {
+		message := "not removed"
+		if failed != nil {
+			message = failed.LocalizedMessage
+		}
+		err = liberr.New(
+			fmt.Sprintf(
+				"VM %s snapshot %s removal failed: %s",
+				vmRef.String(),
+				id,
+				message))
+		return
+	}
+	if object.Runtime.ConsolidationNeeded != nil && *object.Runtime.ConsolidationNeeded {
+		err = liberr.New(
+			fmt.Sprintf(
+				"VM %s disks need to be consolidated.",
+				vmRef.String()))
+		return
+	}
+
+	removed = true
+
+	return
+}
+
+//
+// Get the disk change IDs of a (warm migration) snapshot.
+// Keyed by the disk (device) key.
+func (r *Client) ChangeIds(vmRef ref.Ref, snapshot string) (changeIds map[string]string, supported bool, err error) {
+	supported = true
+	ctx, cancel := context.WithTimeout(context.Background(), TaskTimeout)
+	defer cancel()
+	disks, err := r.snapshotDisks(ctx, snapshot)
+	if err != nil {
+		return
+	}
+	changeIds = map[string]string{}
+	for _, disk := range disks {
+		if backing, cast := disk.Backing.(*types.VirtualDiskFlatVer2BackingInfo); cast {
+			if backing.ChangeId != "" {
+				changeIds[strconv.Itoa(int(disk.Key))] = backing.ChangeId
+			}
+		}
+	}
+
+	return
+}
+
+//
+// Get the bytes changed in a (warm migration) snapshot.
+// Changed block tracking is used to sum the changed areas of each
+// disk since the change IDs were recorded. The allocated areas are
+// summed when no change IDs are passed.
+func (r *Client) ChangedBytes(vmRef ref.Ref, changeIds map[string]string, snapshot string) (bytes int64, supported bool, err error) {
+	supported = true
+	ctx, cancel := context.WithTimeout(context.Background(), TaskTimeout)
+	defer cancel()
+	vm, err := r.getVM(vmRef)
+	if err != nil {
+		return
+	}
+	disks, err := r.snapshotDisks(ctx, snapshot)
+	if err != nil {
+		return
+	}
+	snapshotRef := types.ManagedObjectReference{
+		Type:  "VirtualMachineSnapshot",
+		Value: snapshot,
+	}
+	for _, disk := range disks {
+		changeId := "*"
+		if len(changeIds) > 0 {
+			id, found := changeIds[strconv.Itoa(int(disk.Key))]
+			if !found {
+				continue
+			}
+			changeId = id
+		}
+		offset := int64(0)
+		for offset < disk.CapacityInBytes {
+			response, qErr := methods.QueryChangedDiskAreas(
+				ctx,
+				r.client.Client,
+				&types.QueryChangedDiskAreas{
+					This:        vm.Reference(),
+					Snapshot:    &snapshotRef,
+					DeviceKey:   disk.Key,
+					StartOffset: offset,
+					ChangeId:    changeId,
+				})
+			if qErr != nil {
+				err = liberr.Wrap(qErr)
+				return
+			}
+			info := response.Returnval
+			for _, area := range info.ChangedArea {
+				bytes += area.Length
+			}
+			if info.Length == 0 {
+				break
+			}
+			offset = info.StartOffset + info.Length
+		}
+	}
+
+	return
+}
+
+//
+// Close the connection.
+func (r *Client) Close() {
+	if r.client != nil {
+		_ = r.client.Logout(context.Background())
+		r.client = nil
+	}
+}
+
+//
+// Get the VM by ref.
+func (r *Client) getVM(vmRef ref.Ref) (vm *object.VirtualMachine, err error) {
+	_, err = r.Source.Inventory.VM(&vmRef)
+	if err != nil {
+		return
+	}
+	vm = object.NewVirtualMachine(
+		r.client.Client,
+		types.ManagedObjectReference{
+			Type:  "VirtualMachine",
+			Value: vmRef.ID,
+		})
+
+	return
+}
+
+//
+// Determine whether the snapshot is found in the snapshot tree.
+func (r *Client) hasSnapshot(tree []types.VirtualMachineSnapshotTree, id string) bool {
+	for _, node := range tree {
+		if node.Snapshot.Value == id || r.hasSnapshot(node.ChildSnapshotList, id) {
+			return true
+		}
+	}
+
+	return false
+}
+
+//
+// Get the disks of a snapshot.
+func (r *Client) snapshotDisks(ctx context.Context, id string) (disks []*types.VirtualDisk, err error) {
+	snapshot := mo.VirtualMachineSnapshot{}
+	err = property.DefaultCollector(r.client.Client).RetrieveOne(
+		ctx,
+		types.ManagedObjectReference{
+			Type:  "VirtualMachineSnapshot",
+			Value: id,
+		},
+		[]string{"config.hardware.device"},
+		&snapshot)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	for _, device := range snapshot.Config.Hardware.Device {
+		if disk, cast := device.(*types.VirtualDisk); cast {
+			disks = append(disks, disk)
+		}
+	}
+
+	return
+}
+
+//
+// Connect to the vSphere API.
+func (r *Client) connect() (err error) {
+	r.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	url, err := liburl.Parse(r.Source.Provider.Spec.URL)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	url.User = liburl.UserPassword(
+		r.user(),
+		r.password())
+	soapClient := soap.NewClient(url, false)
+	soapClient.SetThumbprint(url.Host, r.thumbprint())
+	vimClient, err := vim25.NewClient(ctx, soapClient)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	client := &govmomi.Client{
+		SessionManager: session.NewManager(vimClient),
+		Client:         vimClient,
+	}
+	err = client.Login(ctx, url.User)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+
+	r.client = client
+
+	return
+}
+
+//
+// User.
+func (r *Client) user() string {
+	if user, found := r.Source.Secret.Data["user"]; found {
+		return string(user)
+	}
+
+	return ""
+}
+
+//
+// Password.
+func (r *Client) password() string {
+	if password, found := r.Source.Secret.Data["password"]; found {
+		return string(password)
+	}
+
+	return ""
+}
+
+//
+// Thumbprint.
+func (r *Client) thumbprint() string {
+	if thumbprint, found := r.Source.Secret.Data["thumbprint"]; found {
+		return string(thumbprint)
+	}
+
+	return ""
+}
@@ -3,9 +3,15 @@ package vsphere
 import (
 	liberr "github.com/konveyor/controller/pkg/error"
 	api "github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1"
+	"github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1/plan"
 	"github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1/ref"
+	"github.com/konveyor/forklift-controller/pkg/controller/plan/adapter/base"
 	"github.com/konveyor/forklift-controller/pkg/controller/provider/web"
 	model "github.com/konveyor/forklift-controller/pkg/controller/provider/web/vsphere"
+	"k8s.io/apimachinery/pkg/labels"
+	"net"
+	"sort"
+	"strconv"
 )
 
 //
@@ -24,6 +30,7 @@ func (r *Validator) Load() (err error) {
 
 //
 // Validate that a VM's networks have been mapped.
+// Excluded and overridden NICs are ignored.
 func (r *Validator) NetworksMapped(vmRef ref.Ref) (ok bool, err error) {
 	if r.plan.Referenced.Map.Network == nil {
 		return
@@ -39,8 +46,14 @@ func (r *Validator) NetworksMapped(vmRef ref.Ref) (ok bool, err error) {
 		return
 	}
 
-	for _, net := range vm.Networks {
-		if !r.plan.Referenced.Map.Network.Status.Refs.Find(ref.Ref{ID: net.ID}) {
+	planVM, _ := r.plan.Spec.FindVM(vmRef)
+	for _, nic := range vm.NICs {
+		if override, found := r.findNIC(planVM, nic.MAC, nic.Key); found {
+			if override.Exclude || override.Overridden() {
+				continue
+			}
+		}
+		if !r.plan.Referenced.Map.Network.Status.Refs.Find(ref.Ref{ID: nic.Network.ID}) {
 			return
 		}
 	}
@@ -105,3 +118,398 @@ func (r *Validator) MaintenanceMode(vmRef ref.Ref) (ok bool, err error) {
 	ok = !host.InMaintenanceMode
 	return
 }
+
+//
+// Find the VMs matched by the label selector.
+// Templates are excluded.
+func (r *Validator) SelectVMs(selector labels.Selector) (list []ref.Ref, err error) {
+	vms := []model.VM{}
+	err = r.inventory.List(
+		&vms,
+		web.Param{
+			Key:   model.DetailParam,
+			Value: "1",
+		})
+	if err != nil {
+		return
+	}
+	for _, vm := range vms {
+		if vm.IsTemplate {
+			continue
+		}
+		if vm.Match(selector) {
+			list = append(
+				list,
+				ref.Ref{
+					ID:   vm.ID,
+					Name: vm.Name,
+				})
+		}
+	}
+	sort.Slice(
+		list,
+		func(i, j int) bool {
+			return list[i].ID < list[j].ID
+		})
+
+	return
+}
+
+//
+// List the disk backing files.
+// The snapshot suffix is trimmed.
+func (r *Validator) Disks(vmRef ref.Ref) (list []string, err error) {
+	vm := &model.VM{}
+	err = r.inventory.Find(vm, vmRef)
+	if err != nil {
+		err = liberr.Wrap(
+			err,
+			"VM not found in inventory.",
+			"vm",
+			vmRef.String())
+		return
+	}
+	for _, disk := range vm.Disks {
+		list = append(
+			list,
+			backingFilePattern.ReplaceAllString(disk.File, ".vmdk"))
+	}
+
+	return
+}
+
+//
+// The name of the VM folder.
+func (r *Validator) Folder(vmRef ref.Ref) (name string, err error) {
+	vm := &model.VM{}
+	err = r.inventory.Find(vm, vmRef)
+	if err != nil {
+		err = liberr.Wrap(
+			err,
+			"VM not found in inventory.",
+			"vm",
+			vmRef.String())
+		return
+	}
+	id := vm.Folder
+	if id == "" {
+		id = vm.Parent.ID
+	}
+	folder := &model.Folder{}
+	err = r.inventory.Find(folder, ref.Ref{ID: id})
+	if err != nil {
+		err = liberr.Wrap(
+			err,
+			"Folder not found in inventory.",
+			"vm",
+			vmRef.String(),
+			"folder",
+			id)
+		return
+	}
+
+	name = folder.Name
+	return
+}
+
+//
+// Resources required by a VM on the destination.
+// The disk storage class is resolved using the disk
+// overrides and the storage map.
+func (r *Validator) Requirements(vmRef ref.Ref) (required *base.Requirements, err error) {
+	vm := &model.VM{}
+	err = r.inventory.Find(vm, vmRef)
+	if err != nil {
+		err = liberr.Wrap(
+			err,
+			"VM not found in inventory.",
+			"vm",
+			vmRef.String())
+		return
+	}
+	required = &base.Requirements{
+		CPU:    int64(vm.CpuCount),
+		Memory: int64(vm.MemoryMB) * 1024 * 1024,
+	}
+	planVM, _ := r.plan.Spec.FindVM(vmRef)
+	for _, disk := range vm.Disks {
+		storageClass := ""
+		if planVM != nil {
+			id := backingFilePattern.ReplaceAllString(disk.File, ".vmdk")
+			if override, found := planVM.FindDisk(id); found {
+				if override.Exclude {
+					continue
+				}
+				storageClass = override.StorageClass
+			}
+		}
+		if storageClass == "" {
+			storageClass, err = r.storageClass(disk.Datastore.ID)
+			if err != nil {
+				return
+			}
+		}
+		required.Disks = append(
+			required.Disks,
+			base.DiskRequirement{
+				StorageClass: storageClass,
+				Capacity:     disk.Capacity,
+			})
+	}
+
+	return
+}
+
+//
+// List the devices of a VM that cannot be migrated.
+// Passthrough (PCI and SCSI) devices and the virtual TPM.
+func (r *Validator) UnsupportedDevices(vmRef ref.Ref) (list []string, err error) {
+	vm := &model.VM{}
+	err = r.inventory.Find(vm, vmRef)
+	if err != nil {
+		err = liberr.Wrap(
+			err,
+			"VM not found in inventory.",
+			"vm",
+			vmRef.String())
+		return
+	}
+	for _, device := range vm.Devices {
+		if device.Kind == PciPassthrough || device.Kind == ScsiPassthrough {
+			list = append(list, device.Label)
+		}
+	}
+	if vm.TpmEnabled {
+		list = append(list, "vTPM")
+	}
+
+	return
+}
+
+//
+// The host and cluster running the VM.
+func (r *Validator) Placement(vmRef ref.Ref) (host ref.Ref, cluster ref.Ref, err error) {
+	hostModel, err := r.host(vmRef)
+	if err != nil {
+		return
+	}
+	host = ref.Ref{
+		ID:   hostModel.ID,
+		Name: hostModel.Name,
+	}
+	if hostModel.Cluster == "" {
+		return
+	}
+	clusterModel := &model.Cluster{}
+	err = r.inventory.Find(clusterModel, ref.Ref{ID: hostModel.Cluster})
+	if err != nil {
+		err = liberr.Wrap(
+			err,
+			"Cluster not found in inventory.",
+			"vm",
+			vmRef.String(),
+			"cluster",
+			hostModel.Cluster)
+		return
+	}
+	cluster = ref.Ref{
+		ID:   clusterModel.ID,
+		Name: clusterModel.Name,
+	}
+
+	return
+}
+
+//
+// Validate that a transfer network is reachable from the
+// VMkernel adapters of the VM's host. Reachable when an
+// adapter and the network share a subnet.
+func (r *Validator) TransferReachable(vmRef ref.Ref, subnets []*net.IPNet) (ok bool, err error) {
+	host, err := r.host(vmRef)
+	if err != nil {
+		return
+	}
+	for _, adapter := range host.NetworkAdapters {
+		ip := net.ParseIP(adapter.IpAddress)
+		if ip == nil {
+			continue
+		}
+		adapterNet := &net.IPNet{IP: ip}
+		if mask := net.ParseIP(adapter.SubnetMask).To4(); mask != nil {
+			adapterNet.Mask = net.IPMask(mask)
+			adapterNet.IP = ip.Mask(adapterNet.Mask)
+		}
+		for _, subnet := range subnets {
+			if subnet.Contains(ip) {
+				ok = true
+				return
+			}
+			if adapterNet.Mask != nil && adapterNet.Contains(subnet.IP) {
+				ok = true
+				return
+			}
+		}
+	}
+
+	return
+}
+
+//
+// Find the host running the VM.
+// The network adapters are included.
+func (r *Validator) host(vmRef ref.Ref) (host *model.Host, err error) {
+	vm := &model.VM{}
+	err = r.inventory.Find(vm, vmRef)
+	if err != nil {
+		err = liberr.Wrap(
+			err,
+			"VM not found in inventory.",
+			"vm",
+			vmRef.String())
+		return
+	}
+	host = &model.Host{}
+	err = r.inventory.Find(host, ref.Ref{ID: vm.Host})
+	if err != nil {
+		err = liberr.Wrap(
+			err,
+			"Host not found in inventory.",
+			"vm",
+			vmRef.String(),
+			"host",
+			vm.Host)
+	}
+
+	return
+}
+
+//
+// Find the storage class mapped to a datastore.
+func (r *Validator) storageClass(dsID string) (storageClass string, err error) {
+	dsMap := r.plan.Referenced.Map.Storage
+	if dsMap == nil {
+		return
+	}
+	for _, mapped := range dsMap.Spec.Map {
+		ds := &model.Datastore{}
+		err = r.inventory.Find(ds, mapped.Source)
+		if err != nil {
+			err = liberr.Wrap(err)
+			return
+		}
+		if ds.ID == dsID {
+			storageClass = mapped.Destination.StorageClass
+			break
+		}
+	}
+
+	return
+}
+
+//
+// Validate that a disk override is supported.
+// VMIO maps VMware disks individually by disk ID which
+// is not reported for some disks (older ESX versions).
+// Excluding a disk does not require the disk ID.
+func (r *Validator) DiskOverrideSupported(vmRef ref.Ref, disk *plan.Disk) (supported bool, err error) {
+	vm := &model.VM{}
+	err = r.inventory.Find(vm, vmRef)
+	if err != nil {
+		err = liberr.Wrap(
+			err,
+			"VM not found in inventory.",
+			"vm",
+			vmRef.String())
+		return
+	}
+	for _, d := range vm.Disks {
+		if backingFilePattern.ReplaceAllString(d.File, ".vmdk") == disk.ID {
+			supported = d.ObjectID != "" || disk.Exclude || !disk.Overridden()
+			break
+		}
+	}
+
+	return
+}
+
+//
+// Validate a VM's NIC overrides.
+// VMIO maps VMware NICs by source
+// network or, when connected to a distributed port group,
+// by NIC label. Overrides must be consistent for all the
+// NICs connected to the same standard network.
+func (r *Validator) NICOverrides(vmRef ref.Ref) (notFound []string, notSupported []string, err error) {
+	planVM, found := r.plan.Spec.FindVM(vmRef)
+	if !found || len(planVM.NICs) == 0 {
+		return
+	}
+	vm := &model.VM{}
+	err = r.inventory.Find(vm, vmRef)
+	if err != nil {
+		err = liberr.Wrap(
+			err,
+			"VM not found in inventory.",
+			"vm",
+			vmRef.String())
+		return
+	}
+	matched := map[string]bool{}
+	destinations := map[string]map[string]bool{}
+	for _, nic := range vm.NICs {
+		override, found := r.findNIC(planVM, nic.MAC, nic.Key)
+		destination := ""
+		if found {
+			matched[override.ID] = true
+			if override.Exclude {
+				continue
+			}
+			destination = override.Destination()
+		}
+		if destinations[nic.Network.ID] == nil {
+			destinations[nic.Network.ID] = map[string]bool{}
+		}
+		destinations[nic.Network.ID][destination] = true
+	}
+	for _, nic := range vm.NICs {
+		override, found := r.findNIC(planVM, nic.MAC, nic.Key)
+		if !found {
+			continue
+		}
+		network := &model.Network{}
+		err = r.inventory.Find(network, ref.Ref{ID: nic.Network.ID})
+		if err != nil {
+			err = liberr.Wrap(
+				err,
+				"Network not found in inventory.",
+				"network",
+				nic.Network.ID)
+			return
+		}
+		// Distributed port group: mapped by NIC label.
+		if network.DVSwitch != nil {
+			continue
+		}
+		shared := destinations[nic.Network.ID]
+		if override.Exclude && len(shared) > 0 ||
+			override.Overridden() && len(shared) > 1 {
+			notSupported = append(notSupported, override.ID)
+		}
+	}
+	for _, override := range planVM.NICs {
+		if !matched[override.ID] {
+			notFound = append(notFound, override.ID)
+		}
+	}
+
+	return
+}
+
+//
+// Find the NIC override by MAC or device key.
+func (r *Validator) findNIC(planVM *plan.VM, mac string, key int32) (override *plan.NIC, found bool) {
+	if planVM == nil {
+		return
+	}
+	override, found = planVM.FindNIC(mac, strconv.Itoa(int(key)))
+	return
+}
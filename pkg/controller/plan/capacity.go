@@ -0,0 +1,336 @@
+package plan
+
+import (
+	"fmt"
+	liberr "github.com/konveyor/controller/pkg/error"
+	api "github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1"
+	"github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1/ref"
+	"github.com/konveyor/forklift-controller/pkg/controller/plan/adapter/base"
+	"github.com/konveyor/forklift-controller/pkg/controller/provider/web"
+	"github.com/konveyor/forklift-controller/pkg/controller/provider/web/ocp"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sort"
+)
+
+//
+// KubeVirt default CPU allocation ratio.
+// The virt-launcher pod requests 1/ratio CPU per vCPU.
+const (
+	CPUAllocationRatio = 10
+)
+
+//
+// Storage class quota resource suffixes.
+const (
+	scStorageSuffix = ".storageclass.storage.k8s.io/requests.storage"
+	scPVCSuffix     = ".storageclass.storage.k8s.io/persistentvolumeclaims"
+)
+
+//
+// Destination capacity pre-flight checks.
+// The resources required by the VMs are compared with the
+// resource quotas and limit ranges in the target namespace
+// and the allocatable capacity of the nodes schedulable for VMs.
+// Overhead (virt-launcher and CDI filesystem) is not included.
+type Capacity struct {
+	// Plan.
+	Plan *api.Plan
+	// Destination inventory.
+	Destination web.Client
+	// VM requirements.
+	vms []vmRequirements
+	// Total requests.
+	requests core.ResourceList
+}
+
+//
+// Requirements of a VM.
+type vmRequirements struct {
+	ref.Ref
+	base.Requirements
+}
+
+//
+// Add a VM.
+func (r *Capacity) Add(vmRef ref.Ref, required *base.Requirements) {
+	if r.requests == nil {
+		r.requests = core.ResourceList{}
+	}
+	r.vms = append(
+		r.vms,
+		vmRequirements{
+			Ref:          vmRef,
+			Requirements: *required,
+		})
+	add := func(name core.ResourceName, q resource.Quantity) {
+		total := r.requests[name]
+		total.Add(q)
+		r.requests[name] = total
+	}
+	cpu := r.cpuRequest(required)
+	memory := *resource.NewQuantity(required.Memory, resource.BinarySI)
+	add(core.ResourceRequestsCPU, cpu)
+	add(core.ResourceCPU, cpu)
+	add(core.ResourceRequestsMemory, memory)
+	add(core.ResourceMemory, memory)
+	add(core.ResourcePods, *resource.NewQuantity(1, resource.DecimalSI))
+	for _, disk := range required.Disks {
+		capacity := *resource.NewQuantity(disk.Capacity, resource.BinarySI)
+		one := *resource.NewQuantity(1, resource.DecimalSI)
+		add(core.ResourceRequestsStorage, capacity)
+		add(core.ResourcePersistentVolumeClaims, one)
+		if disk.StorageClass != "" {
+			add(core.ResourceName(disk.StorageClass+scStorageSuffix), capacity)
+			add(core.ResourceName(disk.StorageClass+scPVCSuffix), one)
+		}
+	}
+}
+
+//
+// Check the resource quotas in the target namespace.
+// Returns a description of each quota exceeded.
+func (r *Capacity) Quotas() (exceeded []string, err error) {
+	list := []ocp.ResourceQuota{}
+	err = r.Destination.List(
+		&list,
+		web.Param{
+			Key:   ocp.DetailParam,
+			Value: "1",
+		},
+		web.Param{
+			Key:   ocp.NsParam,
+			Value: r.Plan.Spec.TargetNamespace,
+		})
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	if len(list) == 0 {
+		return
+	}
+	pvcUsed, err := r.pvcUsage()
+	if err != nil {
+		return
+	}
+	for _, quota := range list {
+		for _, name := range r.sortedNames(quota.Object.Spec.Hard) {
+			hard := quota.Object.Spec.Hard[name]
+			required, found := r.requests[name]
+			if !found {
+				continue
+			}
+			used, found := quota.Object.Status.Used[name]
+			if !found {
+				used = pvcUsed[name]
+			}
+			available := hard.DeepCopy()
+			available.Sub(used)
+			if required.Cmp(available) > 0 {
+				exceeded = append(
+					exceeded,
+					fmt.Sprintf(
+						"%s %s: required %s, available %s",
+						quota.Name,
+						name,
+						required.String(),
+						available.String()))
+			}
+		}
+	}
+
+	return
+}
+
+//
+// Check the limit ranges in the target namespace.
+// Returns a description of each VM exceeding a limit.
+func (r *Capacity) LimitRanges() (exceeded []string, err error) {
+	list := []ocp.LimitRange{}
+	err = r.Destination.List(
+		&list,
+		web.Param{
+			Key:   ocp.DetailParam,
+			Value: "1",
+		},
+		web.Param{
+			Key:   ocp.NsParam,
+			Value: r.Plan.Spec.TargetNamespace,
+		})
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	for _, lr := range list {
+		for _, item := range lr.Object.Spec.Limits {
+			for _, vm := range r.vms {
+				switch item.Type {
+				case core.LimitTypeContainer, core.LimitTypePod:
+					max, found := item.Max[core.ResourceMemory]
+					if found && max.Value() < vm.Memory {
+						exceeded = append(
+							exceeded,
+							fmt.Sprintf(
+								"%s %s %s memory: required %s, max %s",
+								vm.String(),
+								lr.Name,
+								item.Type,
+								resource.NewQuantity(vm.Memory, resource.BinarySI).String(),
+								max.String()))
+					}
+				case core.LimitTypePersistentVolumeClaim:
+					max, found := item.Max[core.ResourceStorage]
+					if !found {
+						continue
+					}
+					for _, disk := range vm.Disks {
+						if max.Value() < disk.Capacity {
+							exceeded = append(
+								exceeded,
+								fmt.Sprintf(
+									"%s %s %s storage: required %s, max %s",
+									vm.String(),
+									lr.Name,
+									item.Type,
+									resource.NewQuantity(disk.Capacity, resource.BinarySI).String(),
+									max.String()))
+							break
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return
+}
+
+//
+// Check the allocatable capacity of the schedulable nodes.
+// Returns a description of each VM exceeding the capacity of
+// the largest node and of the total requests exceeding the
+// cluster capacity.
+func (r *Capacity) Nodes() (exceeded []string, err error) {
+	list := []ocp.Node{}
+	err = r.Destination.List(
+		&list,
+		web.Param{
+			Key:   ocp.DetailParam,
+			Value: "1",
+		})
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	if len(list) == 0 {
+		return
+	}
+	cpu := resource.Quantity{}
+	memory := resource.Quantity{}
+	largest := resource.Quantity{}
+	for _, node := range list {
+		if !node.Schedulable {
+			continue
+		}
+		allocatable := node.Object.Status.Allocatable
+		cpu.Add(allocatable[core.ResourceCPU])
+		nodeMemory := allocatable[core.ResourceMemory]
+		memory.Add(nodeMemory)
+		if nodeMemory.Cmp(largest) > 0 {
+			largest = nodeMemory
+		}
+	}
+	for _, vm := range r.vms {
+		if largest.Value() < vm.Memory {
+			exceeded = append(
+				exceeded,
+				fmt.Sprintf(
+					"%s memory: required %s, largest node %s",
+					vm.String(),
+					resource.NewQuantity(vm.Memory, resource.BinarySI).String(),
+					largest.String()))
+		}
+	}
+	requested := r.requests[core.ResourceRequestsMemory]
+	if requested.Cmp(memory) > 0 {
+		exceeded = append(
+			exceeded,
+			fmt.Sprintf(
+				"memory: required %s, allocatable %s",
+				requested.String(),
+				memory.String()))
+	}
+	requested = r.requests[core.ResourceRequestsCPU]
+	if requested.Cmp(cpu) > 0 {
+		exceeded = append(
+			exceeded,
+			fmt.Sprintf(
+				"cpu: required %s, allocatable %s",
+				requested.String(),
+				cpu.String()))
+	}
+
+	return
+}
+
+//
+// CPU requested by the virt-launcher pod.
+func (r *Capacity) cpuRequest(required *base.Requirements) resource.Quantity {
+	return *resource.NewMilliQuantity(
+		required.CPU*1000/CPUAllocationRatio,
+		resource.DecimalSI)
+}
+
+//
+// Storage requested by the PVCs in the target namespace.
+// Used when the quota usage has not been reported.
+func (r *Capacity) pvcUsage() (used core.ResourceList, err error) {
+	used = core.ResourceList{}
+	list := []ocp.PersistentVolumeClaim{}
+	err = r.Destination.List(
+		&list,
+		web.Param{
+			Key:   ocp.DetailParam,
+			Value: "1",
+		},
+		web.Param{
+			Key:   ocp.NsParam,
+			Value: r.Plan.Spec.TargetNamespace,
+		})
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	add := func(name core.ResourceName, q resource.Quantity) {
+		total := used[name]
+		total.Add(q)
+		used[name] = total
+	}
+	one := *resource.NewQuantity(1, resource.DecimalSI)
+	for _, pvc := range list {
+		requested := pvc.Object.Spec.Resources.Requests[core.ResourceStorage]
+		add(core.ResourceRequestsStorage, requested)
+		add(core.ResourcePersistentVolumeClaims, one)
+		if sc := pvc.Object.Spec.StorageClassName; sc != nil && *sc != "" {
+			add(core.ResourceName(*sc+scStorageSuffix), requested)
+			add(core.ResourceName(*sc+scPVCSuffix), one)
+		}
+	}
+
+	return
+}
+
+//
+// Sorted resource names.
+func (r *Capacity) sortedNames(list core.ResourceList) (names []core.ResourceName) {
+	for name := range list {
+		names = append(names, name)
+	}
+	sort.Slice(
+		names,
+		func(i, j int) bool {
+			return names[i] < names[j]
+		})
+
+	return
+}
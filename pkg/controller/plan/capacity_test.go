@@ -0,0 +1,315 @@
+package plan
+
+import (
+	api "github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1"
+	"github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1/ref"
+	"github.com/konveyor/forklift-controller/pkg/controller/plan/adapter/base"
+	"github.com/konveyor/forklift-controller/pkg/controller/provider/web"
+	"github.com/konveyor/forklift-controller/pkg/controller/provider/web/ocp"
+	"github.com/onsi/gomega"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"testing"
+)
+
+//
+// VM added to the capacity checks.
+var capacityVM = ref.Ref{ID: "vm-1", Name: "vm1"}
+
+//
+// Destination inventory client.
+type fakeInventory struct {
+	web.Client
+	quotas      []ocp.ResourceQuota
+	limitRanges []ocp.LimitRange
+	nodes       []ocp.Node
+	pvcs        []ocp.PersistentVolumeClaim
+}
+
+func (r *fakeInventory) List(list interface{}, param ...web.Param) (err error) {
+	switch list := list.(type) {
+	case *[]ocp.ResourceQuota:
+		*list = r.quotas
+	case *[]ocp.LimitRange:
+		*list = r.limitRanges
+	case *[]ocp.Node:
+		*list = r.nodes
+	case *[]ocp.PersistentVolumeClaim:
+		*list = r.pvcs
+	}
+
+	return
+}
+
+func newCapacity(inventory *fakeInventory) (capacity *Capacity) {
+	capacity = &Capacity{
+		Plan:        &api.Plan{},
+		Destination: inventory,
+	}
+	capacity.Plan.Spec.TargetNamespace = "test"
+	capacity.Add(
+		capacityVM,
+		&base.Requirements{
+			CPU:    4,
+			Memory: 8 * 1024 * 1024 * 1024,
+			Disks: []base.DiskRequirement{
+				{StorageClass: "fast", Capacity: 10 * 1024 * 1024 * 1024},
+				{Capacity: 2 * 1024 * 1024 * 1024},
+			},
+		})
+
+	return
+}
+
+func quota(hard, used core.ResourceList) (object ocp.ResourceQuota) {
+	object.Name = "quota"
+	object.Object.Spec.Hard = hard
+	object.Object.Status.Used = used
+	return
+}
+
+func TestCapacityQuotas(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	fast := "fast"
+	pvc := ocp.PersistentVolumeClaim{}
+	pvc.Object.Spec.StorageClassName = &fast
+	pvc.Object.Spec.Resources.Requests = core.ResourceList{
+		core.ResourceStorage: resource.MustParse("15Gi"),
+	}
+
+	cases := []struct {
+		name     string
+		quotas   []ocp.ResourceQuota
+		pvcs     []ocp.PersistentVolumeClaim
+		expected []string
+	}{
+		{
+			name: "no quotas",
+		},
+		{
+			name: "not exceeded",
+			quotas: []ocp.ResourceQuota{
+				quota(
+					core.ResourceList{
+						core.ResourceRequestsCPU:    resource.MustParse("1"),
+						core.ResourceRequestsMemory: resource.MustParse("16Gi"),
+						"limits.memory":             resource.MustParse("1Gi"),
+					},
+					core.ResourceList{
+						core.ResourceRequestsCPU:    resource.MustParse("500m"),
+						core.ResourceRequestsMemory: resource.MustParse("8Gi"),
+					}),
+			},
+		},
+		{
+			name: "exceeded",
+			quotas: []ocp.ResourceQuota{
+				quota(
+					core.ResourceList{
+						core.ResourceRequestsCPU:            resource.MustParse("1"),
+						core.ResourcePods:                   resource.MustParse("2"),
+						core.ResourceRequestsStorage:        resource.MustParse("20Gi"),
+						core.ResourcePersistentVolumeClaims: resource.MustParse("2"),
+					},
+					core.ResourceList{
+						core.ResourceRequestsCPU:            resource.MustParse("800m"),
+						core.ResourcePods:                   resource.MustParse("1"),
+						core.ResourceRequestsStorage:        resource.MustParse("10Gi"),
+						core.ResourcePersistentVolumeClaims: resource.MustParse("1"),
+					}),
+			},
+			expected: []string{
+				"quota persistentvolumeclaims: required 2, available 1",
+				"quota requests.cpu: required 400m, available 200m",
+				"quota requests.storage: required 12Gi, available 10Gi",
+			},
+		},
+		{
+			name: "storage class",
+			quotas: []ocp.ResourceQuota{
+				quota(
+					core.ResourceList{
+						"fast" + scStorageSuffix: resource.MustParse("20Gi"),
+						"fast" + scPVCSuffix:     resource.MustParse("1"),
+						"slow" + scStorageSuffix: resource.MustParse("1Gi"),
+					},
+					core.ResourceList{
+						"fast" + scStorageSuffix: resource.MustParse("15Gi"),
+						"fast" + scPVCSuffix:     resource.MustParse("0"),
+					}),
+			},
+			expected: []string{
+				"quota fast.storageclass.storage.k8s.io/requests.storage: required 10Gi, available 5Gi",
+			},
+		},
+		{
+			name: "usage not reported",
+			quotas: []ocp.ResourceQuota{
+				quota(
+					core.ResourceList{
+						core.ResourceRequestsStorage: resource.MustParse("30Gi"),
+						"fast" + scStorageSuffix:     resource.MustParse("20Gi"),
+						"fast" + scPVCSuffix:         resource.MustParse("2"),
+					},
+					nil),
+			},
+			pvcs: []ocp.PersistentVolumeClaim{pvc, pvc},
+			expected: []string{
+				"quota fast.storageclass.storage.k8s.io/persistentvolumeclaims: required 1, available 0",
+				"quota fast.storageclass.storage.k8s.io/requests.storage: required 10Gi, available -10Gi",
+				"quota requests.storage: required 12Gi, available 0",
+			},
+		},
+	}
+
+	for _, c := range cases {
+		capacity := newCapacity(
+			&fakeInventory{
+				quotas: c.quotas,
+				pvcs:   c.pvcs,
+			})
+		exceeded, err := capacity.Quotas()
+		g.Expect(err).To(gomega.BeNil(), c.name)
+		g.Expect(exceeded).To(gomega.Equal(c.expected), c.name)
+	}
+}
+
+func TestCapacityLimitRanges(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	limitRange := func(item core.LimitRangeItem) (object ocp.LimitRange) {
+		object.Name = "limits"
+		object.Object.Spec.Limits = []core.LimitRangeItem{item}
+		return
+	}
+
+	cases := []struct {
+		name        string
+		limitRanges []ocp.LimitRange
+		expected    []string
+	}{
+		{
+			name: "no limit ranges",
+		},
+		{
+			name: "not exceeded",
+			limitRanges: []ocp.LimitRange{
+				limitRange(
+					core.LimitRangeItem{
+						Type: core.LimitTypeContainer,
+						Max: core.ResourceList{
+							core.ResourceMemory: resource.MustParse("16Gi"),
+						},
+					}),
+				limitRange(
+					core.LimitRangeItem{
+						Type: core.LimitTypePersistentVolumeClaim,
+						Min: core.ResourceList{
+							core.ResourceStorage: resource.MustParse("1Gi"),
+						},
+					}),
+			},
+		},
+		{
+			name: "memory",
+			limitRanges: []ocp.LimitRange{
+				limitRange(
+					core.LimitRangeItem{
+						Type: core.LimitTypePod,
+						Max: core.ResourceList{
+							core.ResourceMemory: resource.MustParse("4Gi"),
+						},
+					}),
+			},
+			expected: []string{
+				capacityVM.String() + " limits Pod memory: required 8Gi, max 4Gi",
+			},
+		},
+		{
+			name: "storage",
+			limitRanges: []ocp.LimitRange{
+				limitRange(
+					core.LimitRangeItem{
+						Type: core.LimitTypePersistentVolumeClaim,
+						Max: core.ResourceList{
+							core.ResourceStorage: resource.MustParse("1Gi"),
+						},
+					}),
+			},
+			expected: []string{
+				capacityVM.String() + " limits PersistentVolumeClaim storage: required 10Gi, max 1Gi",
+			},
+		},
+	}
+
+	for _, c := range cases {
+		capacity := newCapacity(
+			&fakeInventory{
+				limitRanges: c.limitRanges,
+			})
+		exceeded, err := capacity.LimitRanges()
+		g.Expect(err).To(gomega.BeNil(), c.name)
+		g.Expect(exceeded).To(gomega.Equal(c.expected), c.name)
+	}
+}
+
+func TestCapacityNodes(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	node := func(schedulable bool, cpu, memory string) (object ocp.Node) {
+		object.Schedulable = schedulable
+		object.Object.Status.Allocatable = core.ResourceList{
+			core.ResourceCPU:    resource.MustParse(cpu),
+			core.ResourceMemory: resource.MustParse(memory),
+		}
+		return
+	}
+
+	cases := []struct {
+		name     string
+		nodes    []ocp.Node
+		expected []string
+	}{
+		{
+			name: "no nodes",
+		},
+		{
+			name: "not exceeded",
+			nodes: []ocp.Node{
+				node(true, "1", "16Gi"),
+			},
+		},
+		{
+			name: "largest node",
+			nodes: []ocp.Node{
+				node(true, "1", "4Gi"),
+				node(true, "1", "6Gi"),
+				node(false, "8", "32Gi"),
+			},
+			expected: []string{
+				capacityVM.String() + " memory: required 8Gi, largest node 6Gi",
+			},
+		},
+		{
+			name: "cluster",
+			nodes: []ocp.Node{
+				node(true, "200m", "4Gi"),
+				node(true, "100m", "2Gi"),
+				node(false, "8", "32Gi"),
+			},
+			expected: []string{
+				capacityVM.String() + " memory: required 8Gi, largest node 4Gi",
+				"memory: required 8Gi, allocatable 6Gi",
+				"cpu: required 400m, allocatable 300m",
+			},
+		},
+	}
+
+	for _, c := range cases {
+		capacity := newCapacity(
+			&fakeInventory{
+				nodes: c.nodes,
+			})
+		exceeded, err := capacity.Nodes()
+		g.Expect(err).To(gomega.BeNil(), c.name)
+		g.Expect(exceeded).To(gomega.Equal(c.expected), c.name)
+	}
+}
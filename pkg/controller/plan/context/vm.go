@@ -0,0 +1,290 @@
+package context
+
+import (
+	"context"
+	liberr "github.com/konveyor/controller/pkg/error"
+	api "github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1"
+	planapi "github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1/plan"
+	core "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"reflect"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sort"
+	"strings"
+)
+
+//
+// VMMigration labels.
+const (
+	// migration label (value=UID)
+	kMigration = "migration"
+	// plan label (value=UID)
+	kPlan = "plan"
+	// VM label (value=vmID)
+	kVM = "vmID"
+)
+
+//
+// VM status store.
+// The VM migration status is stored in VMMigration CRs
+// owned by the migration rather than in the plan status.
+type VMStore struct {
+	// Host client.
+	k8sclient.Client
+	// Loaded CRs keyed by name.
+	loaded map[string]*api.VMMigration
+}
+
+//
+// Load the VM status for the plan.
+// The status recorded for the active migration takes precedence
+// over status recorded by prior migrations. The list is ordered
+// as the VMs listed on the plan.
+func (r *VMStore) Load(plan *api.Plan) (err error) {
+	r.loaded = map[string]*api.VMMigration{}
+	err = r.upgrade(plan)
+	if err != nil {
+		return
+	}
+	list, err := r.list(plan)
+	if err != nil {
+		return
+	}
+	active := plan.Status.Migration.ActiveSnapshot().Migration.UID
+	byVM := map[string]*api.VMMigration{}
+	for i := range list {
+		object := &list[i]
+		r.loaded[object.Name] = object
+		vmID := object.Labels[kVM]
+		current, found := byVM[vmID]
+		if !found {
+			byVM[vmID] = object
+			continue
+		}
+		if current.Labels[kMigration] == string(active) {
+			continue
+		}
+		if object.Labels[kMigration] == string(active) ||
+			current.CreationTimestamp.Before(&object.CreationTimestamp) {
+			byVM[vmID] = object
+		}
+	}
+	index := map[string]int{}
+	for i, vm := range plan.Spec.VMs {
+		index[vm.ID] = i
+	}
+	vms := []*planapi.VMStatus{}
+	for _, object := range byVM {
+		vms = append(vms, object.Status.DeepCopy())
+	}
+	position := func(vm *planapi.VMStatus) int {
+		if n, found := index[vm.ID]; found {
+			return n
+		}
+		return len(index)
+	}
+	sort.SliceStable(
+		vms,
+		func(i, j int) bool {
+			pi, pj := position(vms[i]), position(vms[j])
+			if pi != pj {
+				return pi < pj
+			}
+			return vms[i].ID < vms[j].ID
+		})
+
+	plan.Status.Migration.VMs = vms
+
+	return
+}
+
+//
+// The VM status recorded for the active migration.
+func (r *VMStore) Active(plan *api.Plan) (vms []*planapi.VMStatus, err error) {
+	list, err := r.list(plan)
+	if err != nil {
+		return
+	}
+	active := string(plan.Status.Migration.ActiveSnapshot().Migration.UID)
+	for i := range list {
+		object := &list[i]
+		if object.Labels[kMigration] == active {
+			vms = append(vms, &object.Status)
+		}
+	}
+
+	return
+}
+
+//
+// Store the VM status for the active migration.
+// CRs are created as needed and only updated when the
+// status has changed. The plan status is updated to
+// reflect the VM references and summary.
+func (r *VMStore) Save(plan *api.Plan) (err error) {
+	migration := plan.Status.Migration.ActiveSnapshot().Migration
+	if migration.UID == "" {
+		return
+	}
+	if r.loaded == nil {
+		r.loaded = map[string]*api.VMMigration{}
+	}
+	for _, vm := range plan.Status.Migration.VMs {
+		name := r.name(migration, vm)
+		object, found := r.loaded[name]
+		if !found {
+			object, err = r.create(plan, migration, vm)
+			if err != nil {
+				return
+			}
+			r.loaded[name] = object
+		}
+		if reflect.DeepEqual(&object.Status, vm) {
+			continue
+		}
+		vm.DeepCopyInto(&object.Status)
+		err = r.Status().Update(context.TODO(), object)
+		if err != nil {
+			err = liberr.Wrap(err)
+			return
+		}
+	}
+	plan.Status.Migration.Reflect(
+		func(vm *planapi.VMStatus) string {
+			return r.name(migration, vm)
+		})
+
+	return
+}
+
+//
+// Convert the VM status recorded on the plan prior to the
+// VMMigration CRs. The status is stored in CRs for the active
+// migration and cleared from the plan. The plan status is
+// updated (persisted) by the reconciler.
+func (r *VMStore) upgrade(plan *api.Plan) (err error) {
+	legacy := plan.Status.Migration.LegacyVMs
+	if len(legacy) == 0 {
+		return
+	}
+	migration := plan.Status.Migration.ActiveSnapshot().Migration
+	if migration.UID != "" {
+		for _, vm := range legacy {
+			object, cErr := r.create(plan, migration, vm)
+			if cErr != nil {
+				err = cErr
+				return
+			}
+			if object.Status.ID != "" {
+				continue
+			}
+			vm.DeepCopyInto(&object.Status)
+			err = r.Status().Update(context.TODO(), object)
+			if err != nil {
+				err = liberr.Wrap(err)
+				return
+			}
+		}
+	}
+
+	plan.Status.Migration.LegacyVMs = nil
+
+	return
+}
+
+//
+// Create the CR.
+// An existing CR (not yet in the cache) is fetched.
+func (r *VMStore) create(plan *api.Plan, migration planapi.SnapshotRef, vm *planapi.VMStatus) (object *api.VMMigration, err error) {
+	owner := true
+	object = &api.VMMigration{
+		ObjectMeta: meta.ObjectMeta{
+			Namespace: migration.Namespace,
+			Name:      r.name(migration, vm),
+			Labels: map[string]string{
+				kMigration: string(migration.UID),
+				kPlan:      string(plan.UID),
+				kVM:        vm.ID,
+			},
+			OwnerReferences: []meta.OwnerReference{
+				{
+					APIVersion:         api.SchemeGroupVersion.String(),
+					Kind:               "Migration",
+					Name:               migration.Name,
+					UID:                migration.UID,
+					BlockOwnerDeletion: &owner,
+				},
+			},
+		},
+		Spec: api.VMMigrationSpec{
+			Plan: core.ObjectReference{
+				Namespace: plan.Namespace,
+				Name:      plan.Name,
+				UID:       plan.UID,
+			},
+			Migration: core.ObjectReference{
+				Namespace: migration.Namespace,
+				Name:      migration.Name,
+				UID:       migration.UID,
+			},
+			VM: vm.Ref,
+		},
+	}
+	err = r.Create(context.TODO(), object)
+	if err != nil {
+		if !k8serr.IsAlreadyExists(err) {
+			err = liberr.Wrap(err)
+			return
+		}
+		err = r.Get(
+			context.TODO(),
+			k8sclient.ObjectKey{
+				Namespace: object.Namespace,
+				Name:      object.Name,
+			},
+			object)
+		if err != nil {
+			err = liberr.Wrap(err)
+			return
+		}
+	}
+
+	return
+}
+
+//
+// List the CRs for the plan.
+func (r *VMStore) list(plan *api.Plan) (list []api.VMMigration, err error) {
+	vmList := &api.VMMigrationList{}
+	err = r.List(
+		context.TODO(),
+		vmList,
+		&k8sclient.ListOptions{
+			LabelSelector: labels.SelectorFromSet(
+				map[string]string{
+					kPlan: string(plan.UID),
+				}),
+		})
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+
+	list = vmList.Items
+
+	return
+}
+
+//
+// CR name.
+func (r *VMStore) name(migration planapi.SnapshotRef, vm *planapi.VMStatus) string {
+	return strings.ToLower(
+		strings.Join(
+			[]string{
+				migration.Name,
+				vm.ID,
+			},
+			"-"))
+}
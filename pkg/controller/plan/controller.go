@@ -26,8 +26,10 @@ import (
 	planapi "github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1/plan"
 	"github.com/konveyor/forklift-controller/pkg/controller/base"
 	plancontext "github.com/konveyor/forklift-controller/pkg/controller/plan/context"
+	"github.com/konveyor/forklift-controller/pkg/controller/plan/scheduler/fairshare"
 	"github.com/konveyor/forklift-controller/pkg/settings"
 	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apiserver/pkg/storage/names"
 	"path"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -157,6 +159,46 @@ func Add(mgr manager.Manager) error {
 		log.Trace(err)
 		return err
 	}
+	// Rollback.
+	err = cnt.Watch(
+		&source.Kind{
+			Type: &api.Rollback{},
+		},
+		&handler.EnqueueRequestsFromMapFunc{
+			ToRequests: handler.ToRequestsFunc(RequestForRollback),
+		},
+		&RollbackPredicate{})
+	if err != nil {
+		log.Trace(err)
+		return err
+	}
+	// TestFailover.
+	err = cnt.Watch(
+		&source.Kind{
+			Type: &api.TestFailover{},
+		},
+		&handler.EnqueueRequestsFromMapFunc{
+			ToRequests: handler.ToRequestsFunc(RequestForTestFailover),
+		},
+		&TestFailoverPredicate{})
+	if err != nil {
+		log.Trace(err)
+		return err
+	}
+	// Scheduler (fair-share) ledger.
+	for _, kind := range []runtime.Object{&api.Plan{}, &api.VMMigration{}, &api.Migration{}} {
+		err = cnt.Watch(
+			&source.Kind{
+				Type: kind,
+			},
+			&fairshare.Handler{
+				Ledger: fairshare.Shared,
+			})
+		if err != nil {
+			log.Trace(err)
+			return err
+		}
+	}
 
 	return nil
 }
@@ -210,6 +252,13 @@ func (r Reconciler) Reconcile(request reconcile.Request) (result reconcile.Resul
 		r.Log.Info("Plan Postponed.")
 	}
 
+	// Load VM status.
+	vmStore := &plancontext.VMStore{Client: r}
+	err = vmStore.Load(plan)
+	if err != nil {
+		return
+	}
+
 	// Begin staging conditions.
 	plan.Status.BeginStagingConditions()
 
@@ -242,10 +291,14 @@ func (r Reconciler) Reconcile(request reconcile.Request) (result reconcile.Resul
 		return
 	}
 
+	// The update replaced the spec with the stored
+	// copy. Restore the VMs matched by the selector.
+	plan.AddSelectedVMs()
+
 	//
 	// Execute.
 	// The plan is updated as needed to reflect status.
-	result.RequeueAfter, err = r.execute(plan)
+	result.RequeueAfter, err = r.execute(plan, vmStore)
 	if err != nil {
 		return
 	}
@@ -262,11 +315,15 @@ func (r Reconciler) Reconcile(request reconcile.Request) (result reconcile.Resul
 //   4. If not, find the next pending migration.
 //   5. If a new migration is being started, update the context and snapshot.
 //   6. Run the migration.
-func (r *Reconciler) execute(plan *api.Plan) (reQ time.Duration, err error) {
+//   7. Store the VM status.
+func (r *Reconciler) execute(plan *api.Plan, vmStore *plancontext.VMStore) (reQ time.Duration, err error) {
 	if plan.Status.HasBlockerCondition() {
 		return
 	}
 	defer func() {
+		if err == nil {
+			err = vmStore.Save(plan)
+		}
 		if err == nil {
 			err = r.Status().Update(context.TODO(), plan)
 			if err != nil {
@@ -335,10 +392,24 @@ func (r *Reconciler) execute(plan *api.Plan) (reQ time.Duration, err error) {
 		return
 	}
 	//
+	// Pending migrations scheduled to start later are
+	// not (yet) eligible.
+	due := []*api.Migration{}
+	wait := time.Duration(0)
+	for _, m := range pending {
+		if ok, remaining := m.Spec.Due(); ok {
+			due = append(due, m)
+		} else {
+			if wait == 0 || remaining < wait {
+				wait = remaining
+			}
+		}
+	}
+	//
 	// No active migration.
-	// Select the next pending migration as the (active) migration.
-	if migration == nil && len(pending) > 0 {
-		migration = pending[0]
+	// Select the next (due) pending migration as the (active) migration.
+	if migration == nil && len(due) > 0 {
+		migration = due[0]
 		ctx.SetMigration(migration)
 		snapshot = r.newSnapshot(ctx)
 		plan.Status.DeleteCondition(Failed, Canceled)
@@ -356,6 +427,18 @@ func (r *Reconciler) execute(plan *api.Plan) (reQ time.Duration, err error) {
 		r.Log.Info("No pending migrations found.")
 		plan.Status.DeleteCondition(Executing)
 		reQ = NoReQ
+		if wait > 0 {
+			r.Log.Info(
+				"Found scheduled migrations.",
+				"wait",
+				wait.String())
+			reQ = wait
+		}
+		err = r.rollback(ctx)
+		if err != nil {
+			return
+		}
+		_, err = r.testFailover(ctx)
 		return
 	}
 
@@ -370,7 +453,7 @@ func (r *Reconciler) execute(plan *api.Plan) (reQ time.Duration, err error) {
 	snapshot.EndStagingConditions()
 
 	// Reflect the active snapshot status on the plan.
-	for _, t := range []string{Executing, Succeeded, Failed, Canceled} {
+	for _, t := range []string{Executing, Paused, Succeeded, Failed, Canceled, Skipped} {
 		if cnd := snapshot.FindCondition(t); cnd != nil {
 			r.Log.V(2).Info(
 				"Snapshot condition copied to plan.",
@@ -385,13 +468,192 @@ func (r *Reconciler) execute(plan *api.Plan) (reQ time.Duration, err error) {
 				t)
 		}
 	}
-	if len(pending) > 1 && reQ == 0 {
+	//
+	// Run pending test failovers.
+	testing, err := r.testFailover(ctx)
+	if err != nil {
+		return
+	}
+	if testing && reQ == 0 {
+		reQ = base.SlowReQ
+	}
+	if len(due) > 1 && reQ == 0 {
 		r.Log.V(1).Info(
 			"Found pending migrations.",
 			"count",
-			len(pending))
+			len(due))
 		reQ = base.FastReQ
 	}
+	if wait > 0 && reQ == 0 {
+		reQ = wait
+	}
+	if reQ == 0 {
+		rollbacks, pErr := r.pendingRollbacks(plan)
+		if pErr != nil {
+			err = pErr
+			return
+		}
+		if len(rollbacks) > 0 {
+			r.Log.V(1).Info(
+				"Found pending rollbacks.",
+				"count",
+				len(rollbacks))
+			reQ = base.FastReQ
+		}
+	}
+
+	return
+}
+
+//
+// Run pending rollbacks.
+// Rollbacks are run only when the plan is not executing.
+func (r *Reconciler) rollback(ctx *plancontext.Context) (err error) {
+	list, err := r.pendingRollbacks(ctx.Plan)
+	if err != nil {
+		return
+	}
+	for _, rollback := range list {
+		r.Log.Info(
+			"Found (new) rollback.",
+			"rollback",
+			path.Join(
+				rollback.GetNamespace(),
+				rollback.GetName()))
+		runner := Rollback{Context: ctx}
+		err = runner.Run(rollback)
+		if err != nil {
+			return
+		}
+		rollback.Status.ObservedGeneration = rollback.Generation
+		err = r.Status().Update(context.TODO(), rollback)
+		if err != nil {
+			err = liberr.Wrap(err)
+			return
+		}
+	}
+
+	return
+}
+
+//
+// Run pending test failovers.
+// Returns pending=true when test failovers are waiting
+// for precopies to complete.
+func (r *Reconciler) testFailover(ctx *plancontext.Context) (pending bool, err error) {
+	err = r.deleteTestFailovers(ctx)
+	if err != nil {
+		return
+	}
+	list, err := r.pendingTestFailovers(ctx.Plan)
+	if err != nil {
+		return
+	}
+	for _, failover := range list {
+		r.Log.Info(
+			"Found (new) test failover.",
+			"testFailover",
+			path.Join(
+				failover.GetNamespace(),
+				failover.GetName()))
+		runner := TestFailover{Context: ctx}
+		completed, rErr := runner.Run(failover)
+		if rErr != nil {
+			err = rErr
+			return
+		}
+		if !completed {
+			pending = true
+		}
+		failover.Status.ObservedGeneration = failover.Generation
+		err = r.Status().Update(context.TODO(), failover)
+		if err != nil {
+			err = liberr.Wrap(err)
+			return
+		}
+	}
+
+	return
+}
+
+//
+// Delete the test VMs created by test failovers
+// that have been deleted.
+func (r *Reconciler) deleteTestFailovers(ctx *plancontext.Context) (err error) {
+	if ctx.Plan.Spec.Replication == nil {
+		return
+	}
+	all := &api.TestFailoverList{}
+	err = r.List(context.TODO(), all)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	keep := map[string]bool{}
+	for i := range all.Items {
+		failover := &all.Items[i]
+		if failover.Match(ctx.Plan) {
+			keep[string(failover.UID)] = true
+		}
+	}
+	runner := TestFailover{Context: ctx}
+	err = runner.Cleanup(keep)
+
+	return
+}
+
+//
+// Sorted list of pending test failovers.
+func (r *Reconciler) pendingTestFailovers(plan *api.Plan) (list []*api.TestFailover, err error) {
+	all := &api.TestFailoverList{}
+	err = r.List(context.TODO(), all)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	list = []*api.TestFailover{}
+	for i := range all.Items {
+		failover := &all.Items[i]
+		if !failover.Match(plan) || failover.Status.MarkedCompleted() {
+			continue
+		}
+		list = append(list, failover)
+	}
+	sort.Slice(
+		list,
+		func(i, j int) bool {
+			mi := list[i].ObjectMeta
+			mj := list[j].ObjectMeta
+			return mi.CreationTimestamp.Before(&mj.CreationTimestamp)
+		})
+
+	return
+}
+
+//
+// Sorted list of pending rollbacks.
+func (r *Reconciler) pendingRollbacks(plan *api.Plan) (list []*api.Rollback, err error) {
+	all := &api.RollbackList{}
+	err = r.List(context.TODO(), all)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	list = []*api.Rollback{}
+	for i := range all.Items {
+		rollback := &all.Items[i]
+		if !rollback.Match(plan) || rollback.Status.MarkedCompleted() {
+			continue
+		}
+		list = append(list, rollback)
+	}
+	sort.Slice(
+		list,
+		func(i, j int) bool {
+			mi := list[i].ObjectMeta
+			mj := list[j].ObjectMeta
+			return mi.CreationTimestamp.Before(&mj.CreationTimestamp)
+		})
 
 	return
 }
@@ -476,7 +738,7 @@ func (r *Reconciler) activeMigration(plan *api.Plan) (migration *api.Migration,
 		}
 	}()
 	// the migration is inactive if it's reached a terminal state
-	if snapshot.HasAnyCondition(Canceled, Failed, Succeeded) {
+	if snapshot.HasAnyCondition(Canceled, Failed, Succeeded, Skipped) {
 		return
 	}
 	deleted := libcnd.Condition{
@@ -542,7 +804,7 @@ func (r *Reconciler) pendingMigrations(plan *api.Plan) (list []*api.Migration, e
 				continue
 			}
 		}
-		if migration.Status.HasAnyCondition(Succeeded, Failed, Canceled) {
+		if migration.Status.HasAnyCondition(Succeeded, Failed, Canceled, Skipped) || !migration.Spec.ScheduleValid() {
 			r.Log.Info(
 				"Migration ignored.",
 				"migration",
@@ -19,5 +19,17 @@ Each plan execution:
 
 Each plan execution is idempotent. Subsequent migrations will only affect
 incomplete or failed VM migrations.
+
+The plan controller also watches Rollback CRs. When the plan is not
+executing, each pending Rollback stops and deletes (or preserves) the
+destination VM for the listed VMs and powers on the source VM. Rolled
+back VMs are migrated again by subsequent migrations.
+
+When the plan is replicating (continuous warm migration), the plan
+controller also watches TestFailover CRs. Each pending TestFailover
+clones the latest synced disks of the listed VMs into the (isolated)
+test namespace and boots them. The test VMs and cloned disks are
+deleted when the TestFailover is deleted. The (real) failover is the
+cutover.
 */
 package plan
@@ -0,0 +1,434 @@
+package plan
+
+import (
+	"context"
+	"fmt"
+	libcnd "github.com/konveyor/controller/pkg/condition"
+	liberr "github.com/konveyor/controller/pkg/error"
+	"github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1/plan"
+	"gopkg.in/yaml.v2"
+	core "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	cnv "kubevirt.io/client-go/api/v1"
+	"net"
+	"path"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	k8sutil "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"strings"
+)
+
+//
+// Guest network configuration volume.
+const (
+	GuestNetworkVolume = "guestnetwork"
+	// NoCloud volume label.
+	cidataLabel = "cidata"
+)
+
+//
+// Inject the guest network configuration captured from the source
+// into the VM created by the import as cloud-init (NoCloud) network
+// data. The addresses are assigned to the NICs by MAC address.
+// The VM is started by the CustomizeVM step.
+func (r *KubeVirt) ConfigureGuestNetwork(vm *plan.VMStatus, imp *VmImport) (err error) {
+	network := vm.GuestNetwork
+	staticIPs := r.Plan.Spec.StaticIPs
+	if staticIPs == nil {
+		staticIPs = &plan.StaticIPs{}
+	}
+	configure := false
+	switch {
+	case network == nil || len(network.Interfaces) == 0:
+		err = r.guestNetworkNotConfigured(
+			vm,
+			NotFound,
+			"Static IP addresses not captured from the source guest.")
+	case network.OS == "":
+		err = r.guestNetworkNotConfigured(
+			vm,
+			NotSupported,
+			"Guest OS not supported.")
+	case network.OS == plan.GuestWindows && !staticIPs.CloudbaseInit:
+		err = r.guestNetworkNotConfigured(
+			vm,
+			NotSupported,
+			"cloudbase-init not installed in the Windows guest.")
+	default:
+		configure = true
+	}
+	if err != nil {
+		return
+	}
+	if !configure {
+		return
+	}
+	object := &cnv.VirtualMachine{}
+	err = r.Destination.Client.Get(
+		context.TODO(),
+		client.ObjectKey{
+			Namespace: imp.Namespace,
+			Name:      imp.Status.TargetVMName,
+		},
+		object)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	data, err := r.guestNetworkData(network)
+	if err != nil {
+		return
+	}
+	err = r.setNetworkData(vm, object, data)
+	if err != nil {
+		return
+	}
+	err = r.Destination.Client.Update(context.TODO(), object)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+
+	r.Log.Info(
+		"Configured guest network.",
+		"target",
+		path.Join(
+			object.Namespace,
+			object.Name),
+		"vm",
+		vm.String(),
+		"configured",
+		configure)
+
+	return
+}
+
+//
+// Report that the guest network cannot be configured.
+// Fails the step when static IP preservation is required.
+func (r *KubeVirt) guestNetworkNotConfigured(vm *plan.VMStatus, reason, message string) (err error) {
+	r.Log.Info(
+		message+" Network not configured.",
+		"vm",
+		vm.String())
+	vm.SetCondition(
+		libcnd.Condition{
+			Type:     GuestNetNotConfig,
+			Status:   True,
+			Category: Warn,
+			Reason:   reason,
+			Message:  message + " The guest network was not configured.",
+			Durable:  true,
+		})
+	if staticIPs := r.Plan.Spec.StaticIPs; staticIPs != nil && staticIPs.Required {
+		err = liberr.New(message)
+	}
+
+	return
+}
+
+//
+// Set the cloud-init network data.
+// An existing NoCloud volume is updated. Otherwise, the NoCloud
+// data (including the meta-data) is provided by a config map.
+func (r *KubeVirt) setNetworkData(vm *plan.VMStatus, object *cnv.VirtualMachine, data string) (err error) {
+	if object.Spec.Template == nil {
+		object.Spec.Template = &cnv.VirtualMachineInstanceTemplateSpec{}
+	}
+	spec := &object.Spec.Template.Spec
+	for i := range spec.Volumes {
+		volume := &spec.Volumes[i]
+		if volume.CloudInitNoCloud != nil {
+			volume.CloudInitNoCloud.NetworkData = data
+			return
+		}
+	}
+	configMap, err := r.ensureNetworkConfigMap(vm, object, data)
+	if err != nil {
+		return
+	}
+	for _, volume := range spec.Volumes {
+		if volume.Name == GuestNetworkVolume {
+			return
+		}
+	}
+	spec.Domain.Devices.Disks = append(
+		spec.Domain.Devices.Disks,
+		cnv.Disk{
+			Name: GuestNetworkVolume,
+			DiskDevice: cnv.DiskDevice{
+				Disk: &cnv.DiskTarget{
+					Bus: "sata",
+				},
+			},
+		})
+	spec.Volumes = append(
+		spec.Volumes,
+		cnv.Volume{
+			Name: GuestNetworkVolume,
+			VolumeSource: cnv.VolumeSource{
+				ConfigMap: &cnv.ConfigMapVolumeSource{
+					LocalObjectReference: core.LocalObjectReference{
+						Name: configMap.Name,
+					},
+					VolumeLabel: cidataLabel,
+				},
+			},
+		})
+
+	return
+}
+
+//
+// Create or update the config map containing the NoCloud data.
+// The source VM ID is the (stable) instance-id so the network
+// configuration is applied on the first boot only. The config
+// map is owned by (and deleted with) the VM.
+func (r *KubeVirt) ensureNetworkConfigMap(vm *plan.VMStatus, object *cnv.VirtualMachine, data string) (configMap *core.ConfigMap, err error) {
+	metaData, err := yaml.Marshal(
+		map[string]interface{}{
+			"instance-id": vm.ID,
+		})
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	configMap = &core.ConfigMap{}
+	err = r.Destination.Client.Get(
+		context.TODO(),
+		client.ObjectKey{
+			Namespace: object.Namespace,
+			Name:      object.Name + "-" + GuestNetworkVolume,
+		},
+		configMap)
+	found := true
+	if err != nil {
+		if !k8serr.IsNotFound(err) {
+			err = liberr.Wrap(err)
+			return
+		}
+		found = false
+		configMap = &core.ConfigMap{
+			ObjectMeta: meta.ObjectMeta{
+				Namespace: object.Namespace,
+				Name:      object.Name + "-" + GuestNetworkVolume,
+				Labels:    r.vmLabels(vm.Ref),
+			},
+		}
+	}
+	configMap.Data = map[string]string{
+		"meta-data":      string(metaData),
+		"user-data":      "#cloud-config\n",
+		"network-config": data,
+	}
+	err = k8sutil.SetOwnerReference(object, configMap, scheme.Scheme)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	if found {
+		err = r.Destination.Client.Update(context.TODO(), configMap)
+	} else {
+		err = r.Destination.Client.Create(context.TODO(), configMap)
+	}
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+
+	return
+}
+
+//
+// Build the cloud-init network data.
+// Linux guests: version 2. The NICs are matched by MAC address and the
+// reported interface names are set; otherwise the names are not changed.
+// Windows guests: version 1 (the only version supported by cloudbase-init).
+// Source addresses are replaced as specified by the plan IP map.
+func (r *KubeVirt) guestNetworkData(network *plan.GuestNetwork) (data string, err error) {
+	var config map[string]interface{}
+	if network.OS == plan.GuestWindows {
+		config, err = r.networkConfigV1(network)
+	} else {
+		config, err = r.networkConfigV2(network)
+	}
+	if err != nil {
+		return
+	}
+	content, err := yaml.Marshal(config)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+
+	data = string(content)
+	return
+}
+
+//
+// Build the network configuration (version 1).
+func (r *KubeVirt) networkConfigV1(network *plan.GuestNetwork) (config map[string]interface{}, err error) {
+	list := []interface{}{}
+	for i, nic := range network.Interfaces {
+		addresses, aErr := r.guestAddresses(network, &nic)
+		if aErr != nil {
+			err = aErr
+			return
+		}
+		subnets := []interface{}{}
+		for _, address := range addresses {
+			subnet := map[string]interface{}{
+				"type":    "static",
+				"address": address.Address,
+			}
+			if address.Gateway != "" {
+				subnet["gateway"] = address.Gateway
+			}
+			if len(address.DNS) > 0 {
+				subnet["dns_nameservers"] = address.DNS
+			}
+			subnets = append(subnets, subnet)
+		}
+		list = append(
+			list,
+			map[string]interface{}{
+				"type":        "physical",
+				"name":        r.interfaceID(&nic, i),
+				"mac_address": strings.ToLower(nic.MAC),
+				"subnets":     subnets,
+			})
+	}
+	if len(network.DNS) > 0 {
+		list = append(
+			list,
+			map[string]interface{}{
+				"type":    "nameserver",
+				"address": network.DNS,
+			})
+	}
+	config = map[string]interface{}{
+		"version": 1,
+		"config":  list,
+	}
+
+	return
+}
+
+//
+// Build the network configuration (version 2).
+func (r *KubeVirt) networkConfigV2(network *plan.GuestNetwork) (config map[string]interface{}, err error) {
+	ethernets := map[string]interface{}{}
+	for i, nic := range network.Interfaces {
+		addresses, aErr := r.guestAddresses(network, &nic)
+		if aErr != nil {
+			err = aErr
+			return
+		}
+		ethernet := map[string]interface{}{
+			"match": map[string]interface{}{
+				"macaddress": strings.ToLower(nic.MAC),
+			},
+		}
+		if nic.Name != "" {
+			ethernet["set-name"] = nic.Name
+		}
+		list := []string{}
+		dns := []string{}
+		for _, address := range addresses {
+			list = append(list, address.Address)
+			key := "gateway6"
+			if ip, _, _ := net.ParseCIDR(address.Address); ip.To4() != nil {
+				key = "gateway4"
+			}
+			if _, set := ethernet[key]; !set && address.Gateway != "" {
+				ethernet[key] = address.Gateway
+			}
+			dns = append(dns, address.DNS...)
+		}
+		ethernet["addresses"] = list
+		if len(dns) == 0 {
+			dns = network.DNS
+		}
+		if len(dns) > 0 {
+			ethernet["nameservers"] = map[string]interface{}{
+				"addresses": dns,
+			}
+		}
+		ethernets[r.interfaceID(&nic, i)] = ethernet
+	}
+	config = map[string]interface{}{
+		"version":   2,
+		"ethernets": ethernets,
+	}
+
+	return
+}
+
+//
+// Guest (static) address.
+type guestAddress struct {
+	// Address (CIDR).
+	Address string
+	// Default gateway.
+	Gateway string
+	// DNS servers (mapped).
+	DNS []string
+}
+
+//
+// Resolve the addresses of a guest interface.
+// Source addresses are replaced as specified by the plan IP map.
+func (r *KubeVirt) guestAddresses(network *plan.GuestNetwork, nic *plan.GuestInterface) (list []guestAddress, err error) {
+	staticIPs := r.Plan.Spec.StaticIPs
+	if staticIPs == nil {
+		staticIPs = &plan.StaticIPs{}
+	}
+	for _, address := range nic.Addresses {
+		ip, _, pErr := net.ParseCIDR(address)
+		if pErr != nil {
+			err = liberr.Wrap(pErr)
+			return
+		}
+		resolved := guestAddress{Address: address}
+		if mapping, found := staticIPs.Find(ip.String()); found {
+			resolved.Address = mapping.Destination
+			resolved.Gateway = mapping.Gateway
+			resolved.DNS = mapping.DNS
+		}
+		if resolved.Gateway == "" {
+			resolved.Gateway = r.gateway(resolved.Address, network.Gateways)
+		}
+		list = append(list, resolved)
+	}
+
+	return
+}
+
+//
+// The interface identifier in the network configuration.
+// The reported interface name when known.
+func (r *KubeVirt) interfaceID(nic *plan.GuestInterface, index int) string {
+	if nic.Name != "" {
+		return nic.Name
+	}
+
+	return fmt.Sprintf("nic%d", index)
+}
+
+//
+// Find the gateway on the subnet of the address (CIDR).
+func (r *KubeVirt) gateway(address string, gateways []string) (gateway string) {
+	_, subnet, err := net.ParseCIDR(address)
+	if err != nil {
+		return
+	}
+	for _, gw := range gateways {
+		if ip := net.ParseIP(gw); ip != nil && subnet.Contains(ip) {
+			gateway = gw
+			break
+		}
+	}
+
+	return
+}
@@ -0,0 +1,111 @@
+package plan
+
+import (
+	api "github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1"
+	"github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1/plan"
+	plancontext "github.com/konveyor/forklift-controller/pkg/controller/plan/context"
+	"github.com/onsi/gomega"
+	"testing"
+)
+
+func TestGuestNetworkData(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	kubevirt := &KubeVirt{
+		Context: &plancontext.Context{
+			Plan: &api.Plan{},
+		},
+	}
+	kubevirt.Plan.Spec.StaticIPs = &plan.StaticIPs{
+		Map: []plan.IPMapping{
+			{
+				Source:      "10.0.0.2",
+				Destination: "10.1.0.2/24",
+				Gateway:     "10.1.0.1",
+				DNS:         []string{"10.1.0.53"},
+			},
+		},
+	}
+	interfaces := []plan.GuestInterface{
+		{
+			MAC:       "00:50:56:AA:BB:01",
+			Name:      "ens192",
+			Addresses: []string{"10.0.0.2/24"},
+		},
+		{
+			MAC:       "00:50:56:AA:BB:02",
+			Addresses: []string{"10.2.0.2/24"},
+		},
+	}
+
+	cases := []struct {
+		name     string
+		os       string
+		expected string
+	}{
+		{
+			name: "linux",
+			os:   plan.GuestLinux,
+			expected: `ethernets:
+  ens192:
+    addresses:
+    - 10.1.0.2/24
+    gateway4: 10.1.0.1
+    match:
+      macaddress: 00:50:56:aa:bb:01
+    nameservers:
+      addresses:
+      - 10.1.0.53
+    set-name: ens192
+  nic1:
+    addresses:
+    - 10.2.0.2/24
+    gateway4: 10.2.0.1
+    match:
+      macaddress: 00:50:56:aa:bb:02
+    nameservers:
+      addresses:
+      - 10.0.0.53
+version: 2
+`,
+		},
+		{
+			name: "windows",
+			os:   plan.GuestWindows,
+			expected: `config:
+- mac_address: 00:50:56:aa:bb:01
+  name: ens192
+  subnets:
+  - address: 10.1.0.2/24
+    dns_nameservers:
+    - 10.1.0.53
+    gateway: 10.1.0.1
+    type: static
+  type: physical
+- mac_address: 00:50:56:aa:bb:02
+  name: nic1
+  subnets:
+  - address: 10.2.0.2/24
+    gateway: 10.2.0.1
+    type: static
+  type: physical
+- address:
+  - 10.0.0.53
+  type: nameserver
+version: 1
+`,
+		},
+	}
+
+	for _, c := range cases {
+		data, err := kubevirt.guestNetworkData(
+			&plan.GuestNetwork{
+				OS:         c.os,
+				Interfaces: interfaces,
+				Gateways:   []string{"10.0.0.1", "10.2.0.1"},
+				DNS:        []string{"10.0.0.53"},
+			})
+		g.Expect(err).To(gomega.BeNil(), c.name)
+		g.Expect(data).To(gomega.Equal(c.expected), c.name)
+	}
+}
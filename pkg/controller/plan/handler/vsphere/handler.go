@@ -9,6 +9,7 @@ import (
 	"github.com/konveyor/forklift-controller/pkg/controller/watch/handler"
 	"golang.org/x/net/context"
 	"path"
+	"reflect"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"strings"
 )
@@ -59,7 +60,9 @@ func (r *Handler) Created(e libweb.Event) {
 func (r *Handler) Updated(e libweb.Event) {
 	if vm, cast := e.Resource.(*vsphere.VM); cast {
 		updated := e.Updated.(*vsphere.VM)
-		if updated.Path != vm.Path {
+		if updated.Path != vm.Path ||
+			!reflect.DeepEqual(updated.Tags, vm.Tags) ||
+			!reflect.DeepEqual(updated.CustomAttributes, vm.CustomAttributes) {
 			r.changed(vm, updated)
 		}
 	}
@@ -94,7 +97,7 @@ func (r *Handler) changed(models ...*vsphere.VM) {
 		if !r.MatchProvider(ref) {
 			continue
 		}
-		referenced := false
+		referenced := plan.Spec.VMSelector != nil
 		for _, planVM := range plan.Spec.VMs {
 			ref := planVM.Ref
 			for _, vm := range models {
@@ -2,6 +2,8 @@ package plan
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"path"
 	"reflect"
 	"strconv"
@@ -9,15 +11,22 @@ import (
 
 	libcnd "github.com/konveyor/controller/pkg/condition"
 	liberr "github.com/konveyor/controller/pkg/error"
+	api "github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1"
 	"github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1/plan"
 	"github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1/ref"
 	"github.com/konveyor/forklift-controller/pkg/controller/plan/adapter"
 	plancontext "github.com/konveyor/forklift-controller/pkg/controller/plan/context"
 	core "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
 	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
+	cnv "kubevirt.io/client-go/api/v1"
 	cdi "kubevirt.io/containerized-data-importer/pkg/apis/core/v1beta1"
 	vmio "kubevirt.io/vm-import-operator/pkg/apis/v2v/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -28,6 +37,9 @@ import (
 const (
 	// transfer network annotation (value=network-attachment-definition name)
 	annDefaultNetwork = "v1.multus-cni.io/default-network"
+	// start the VM after the guest network configuration and
+	// target VM overrides have been applied.
+	annStartVM = "forklift.konveyor.io/startVM"
 )
 
 // Labels
@@ -38,6 +50,16 @@ const (
 	kPlan = "plan"
 	// VM label (value=vmID)
 	kVM = "vmID"
+	// test failover label (value=UID)
+	kTestFailover = "testFailover"
+	// excluded disk (placeholder) DataVolume label (value=true)
+	kExcluded = "excludedDisk"
+)
+
+//
+// Network policy isolating the test failover VMs.
+const (
+	TestNetworkPolicy = "forklift-test-failover"
 )
 
 //
@@ -149,10 +171,22 @@ func (r *KubeVirt) EnsureImport(vm *plan.VMStatus) (err error) {
 	if len(list.Items) > 0 {
 		vmImport = &list.Items[0]
 		// Update the existing VM import if the cutover date has changed.
+		// The cutover date is cleared (null) while the VM is paused.
 		if !reflect.DeepEqual(vmImport.Spec.FinalizeDate, newImport.Spec.FinalizeDate) {
-			patch := vmImport.DeepCopy()
-			patch.Spec.FinalizeDate = newImport.Spec.FinalizeDate
-			err = r.Destination.Client.Patch(context.TODO(), patch, client.MergeFrom(vmImport))
+			patch, mErr := json.Marshal(
+				map[string]interface{}{
+					"spec": map[string]interface{}{
+						"finalizeDate": newImport.Spec.FinalizeDate,
+					},
+				})
+			if mErr != nil {
+				err = liberr.Wrap(mErr)
+				return
+			}
+			err = r.Destination.Client.Patch(
+				context.TODO(),
+				vmImport,
+				client.RawPatch(types.MergePatchType, patch))
 			if err != nil {
 				err = liberr.Wrap(err)
 				return
@@ -235,12 +269,739 @@ func (r *KubeVirt) DeleteImport(vm *plan.VMStatus) (err error) {
 	return
 }
 
+//
+// Create the placeholder DataVolumes for the excluded disks.
+// VMIO copies every disk of the source VM but does not copy
+// the disks for which a DataVolume already exists and does
+// not attach them to the VM. The placeholders are blank and
+// are deleted once the import has completed.
+func (r *KubeVirt) EnsureExcludedDisks(vm *plan.VMStatus) (err error) {
+	vmName := vm.TargetVMName
+	if vmName == "" {
+		vmName = vm.Name
+	}
+	names, err := r.Builder.ExcludedDataVolumes(vm.Ref, vmName)
+	if err != nil {
+		return
+	}
+	dvLabels := r.vmLabels(vm.Ref)
+	dvLabels[kExcluded] = "true"
+	for _, name := range names {
+		object := &cdi.DataVolume{
+			ObjectMeta: meta.ObjectMeta{
+				Namespace: r.Plan.Spec.TargetNamespace,
+				Name:      name,
+				Labels:    dvLabels,
+			},
+			Spec: cdi.DataVolumeSpec{
+				Source: cdi.DataVolumeSource{
+					Blank: &cdi.DataVolumeBlankImage{},
+				},
+				PVC: &core.PersistentVolumeClaimSpec{
+					AccessModes: []core.PersistentVolumeAccessMode{
+						core.ReadWriteOnce,
+					},
+					Resources: core.ResourceRequirements{
+						Requests: core.ResourceList{
+							core.ResourceStorage: resource.MustParse("1Mi"),
+						},
+					},
+				},
+			},
+		}
+		err = r.Destination.Client.Create(context.TODO(), object)
+		if err != nil {
+			if k8serr.IsAlreadyExists(err) {
+				err = nil
+				continue
+			}
+			err = liberr.Wrap(err)
+			return
+		}
+		r.Log.Info(
+			"Created (excluded disk) DataVolume.",
+			"dv",
+			path.Join(
+				object.Namespace,
+				object.Name),
+			"vm",
+			vm.String())
+	}
+
+	return
+}
+
+//
+// Delete the placeholder DataVolumes for the excluded disks.
+func (r *KubeVirt) DeleteExcludedDisks(vm *plan.VMStatus) (err error) {
+	list := &cdi.DataVolumeList{}
+	err = r.Destination.Client.List(
+		context.TODO(),
+		list,
+		&client.ListOptions{
+			LabelSelector: labels.SelectorFromSet(
+				map[string]string{
+					kPlan:     string(r.Plan.GetUID()),
+					kVM:       vm.ID,
+					kExcluded: "true",
+				}),
+			Namespace: r.Plan.Spec.TargetNamespace,
+		})
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	for i := range list.Items {
+		object := &list.Items[i]
+		err = r.Destination.Client.Delete(context.TODO(), object)
+		if err != nil {
+			if k8serr.IsNotFound(err) {
+				err = nil
+				continue
+			}
+			err = liberr.Wrap(err)
+			return
+		}
+		r.Log.Info(
+			"Deleted (excluded disk) DataVolume.",
+			"dv",
+			path.Join(
+				object.Namespace,
+				object.Name),
+			"vm",
+			vm.String())
+	}
+
+	return
+}
+
+//
+// Roll back the destination resources for the VM.
+// The destination VM is found by the VM labels (applied by the
+// CustomizeVM step) or the target VM name. The DataVolumes are
+// found by the VM labels or the volumes of the VM. The VM is
+// stopped and, when not preserved, the VM, the DataVolumes and
+// the imports created by any migration of the plan are deleted.
+// Fails when the destination VM and DataVolumes are not found.
+func (r *KubeVirt) RollbackVM(vm *plan.VMStatus, preserve bool) (err error) {
+	selector := labels.SelectorFromSet(
+		map[string]string{
+			kPlan: string(r.Plan.GetUID()),
+			kVM:   vm.ID,
+		})
+	vms, err := r.destinationVMs(vm, selector)
+	if err != nil {
+		return
+	}
+	dvs, err := r.destinationDVs(vms, selector)
+	if err != nil {
+		return
+	}
+	if len(vms) == 0 && len(dvs) == 0 {
+		err = liberr.New(
+			fmt.Sprintf(
+				"Destination VM and DataVolumes for VM %s not found.",
+				vm.String()))
+		return
+	}
+	if preserve {
+		for i := range vms {
+			err = r.setVMRunning(&vms[i], false)
+			if err != nil {
+				return
+			}
+		}
+		return
+	}
+	imports := &vmio.VirtualMachineImportList{}
+	err = r.Destination.Client.List(
+		context.TODO(),
+		imports,
+		&client.ListOptions{
+			LabelSelector: selector,
+			Namespace:     r.Plan.Spec.TargetNamespace,
+		},
+	)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	objects := []runtime.Object{}
+	for i := range vms {
+		objects = append(objects, &vms[i])
+	}
+	for i := range dvs {
+		objects = append(objects, &dvs[i])
+	}
+	for i := range imports.Items {
+		objects = append(objects, &imports.Items[i])
+	}
+	for _, object := range objects {
+		err = r.Destination.Client.Delete(context.TODO(), object)
+		if err != nil {
+			if k8serr.IsNotFound(err) {
+				err = nil
+				continue
+			}
+			err = liberr.Wrap(err)
+			return
+		}
+	}
+
+	r.Log.Info(
+		"Deleted destination VM, DataVolumes and VM Imports.",
+		"vm",
+		vm.String(),
+		"vms",
+		len(vms),
+		"dataVolumes",
+		len(dvs),
+		"imports",
+		len(imports.Items))
+
+	return
+}
+
+//
+// Find the destination VMs by label or target VM name.
+func (r *KubeVirt) destinationVMs(vm *plan.VMStatus, selector labels.Selector) (vms []cnv.VirtualMachine, err error) {
+	list := &cnv.VirtualMachineList{}
+	err = r.Destination.Client.List(
+		context.TODO(),
+		list,
+		&client.ListOptions{
+			LabelSelector: selector,
+			Namespace:     r.Plan.Spec.TargetNamespace,
+		},
+	)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	vms = list.Items
+	if len(vms) > 0 || vm.TargetVMName == "" {
+		return
+	}
+	object := cnv.VirtualMachine{}
+	err = r.Destination.Client.Get(
+		context.TODO(),
+		client.ObjectKey{
+			Namespace: r.Plan.Spec.TargetNamespace,
+			Name:      vm.TargetVMName,
+		},
+		&object)
+	if err != nil {
+		if k8serr.IsNotFound(err) {
+			err = nil
+		} else {
+			err = liberr.Wrap(err)
+		}
+		return
+	}
+
+	vms = append(vms, object)
+
+	return
+}
+
+//
+// Find the destination DataVolumes by label and
+// the volumes of the destination VMs.
+func (r *KubeVirt) destinationDVs(vms []cnv.VirtualMachine, selector labels.Selector) (dvs []cdi.DataVolume, err error) {
+	list := &cdi.DataVolumeList{}
+	err = r.Destination.Client.List(
+		context.TODO(),
+		list,
+		&client.ListOptions{
+			LabelSelector: selector,
+			Namespace:     r.Plan.Spec.TargetNamespace,
+		},
+	)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	dvs = list.Items
+	found := map[string]bool{}
+	for _, dv := range dvs {
+		found[dv.Name] = true
+	}
+	for _, object := range vms {
+		for _, volume := range object.Spec.Template.Spec.Volumes {
+			if volume.DataVolume == nil || found[volume.DataVolume.Name] {
+				continue
+			}
+			dv := cdi.DataVolume{}
+			err = r.Destination.Client.Get(
+				context.TODO(),
+				client.ObjectKey{
+					Namespace: object.Namespace,
+					Name:      volume.DataVolume.Name,
+				},
+				&dv)
+			if err != nil {
+				if k8serr.IsNotFound(err) {
+					err = nil
+					continue
+				}
+				err = liberr.Wrap(err)
+				return
+			}
+			found[dv.Name] = true
+			dvs = append(dvs, dv)
+		}
+	}
+
+	return
+}
+
+//
+// Start the VM created by the import.
+func (r *KubeVirt) StartVM(imp *VmImport) (err error) {
+	err = r.setRunning(imp.VirtualMachineImport, true)
+	return
+}
+
+//
+// Start/stop the VM created by the import.
+func (r *KubeVirt) setRunning(imp *vmio.VirtualMachineImport, running bool) (err error) {
+	if imp.Status.TargetVMName == "" {
+		return
+	}
+	object := &cnv.VirtualMachine{}
+	err = r.Destination.Client.Get(
+		context.TODO(),
+		client.ObjectKey{
+			Namespace: imp.Namespace,
+			Name:      imp.Status.TargetVMName,
+		},
+		object)
+	if err != nil {
+		if k8serr.IsNotFound(err) {
+			err = nil
+		} else {
+			err = liberr.Wrap(err)
+		}
+		return
+	}
+	err = r.setVMRunning(object, running)
+
+	return
+}
+
+//
+// Start/stop the destination VM.
+func (r *KubeVirt) setVMRunning(object *cnv.VirtualMachine, running bool) (err error) {
+	spec := map[string]interface{}{}
+	if object.Spec.RunStrategy != nil {
+		if running {
+			spec["runStrategy"] = cnv.RunStrategyAlways
+		} else {
+			spec["runStrategy"] = cnv.RunStrategyHalted
+		}
+	} else {
+		spec["running"] = running
+	}
+	patch, err := json.Marshal(map[string]interface{}{"spec": spec})
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	err = r.Destination.Client.Patch(
+		context.TODO(),
+		object,
+		client.RawPatch(types.MergePatchType, patch))
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+
+	r.Log.Info(
+		"Destination VM run state updated.",
+		"vm",
+		path.Join(
+			object.Namespace,
+			object.Name),
+		"running",
+		running)
+
+	return
+}
+
+//
+// Set the time of the next (warm) precopy.
+// The import controller schedules the next precopy using the
+// time in the import status and does not replace a time that
+// is in the future. The patch is conditional on the resource
+// version so that a concurrent update by the import controller
+// is not overwritten. On conflict, the time is set again on
+// the next reconcile.
+func (r *KubeVirt) SetNextPrecopy(imp *VmImport, next meta.Time) (err error) {
+	object := imp.VirtualMachineImport.DeepCopy()
+	object.Status.WarmImport.NextStageTime = &next
+	err = r.Destination.Client.Status().Patch(
+		context.TODO(),
+		object,
+		client.MergeFromWithOptions(
+			imp.VirtualMachineImport,
+			client.MergeFromWithOptimisticLock{}))
+	if err != nil {
+		if k8serr.IsConflict(err) {
+			err = nil
+			r.Log.V(1).Info(
+				"Next precopy not set (conflict).",
+				"import",
+				path.Join(
+					object.Namespace,
+					object.Name))
+		} else {
+			err = liberr.Wrap(err)
+		}
+		return
+	}
+
+	return
+}
+
+//
+// Find the VMI for the VM created by the import.
+func (r *KubeVirt) VMI(imp *VmImport) (object *cnv.VirtualMachineInstance, found bool, err error) {
+	if imp.Status.TargetVMName == "" {
+		return
+	}
+	object = &cnv.VirtualMachineInstance{}
+	err = r.Destination.Client.Get(
+		context.TODO(),
+		client.ObjectKey{
+			Namespace: imp.Namespace,
+			Name:      imp.Status.TargetVMName,
+		},
+		object)
+	if err != nil {
+		if k8serr.IsNotFound(err) {
+			err = nil
+		} else {
+			err = liberr.Wrap(err)
+		}
+		return
+	}
+
+	found = true
+
+	return
+}
+
 //
 // Ensure the namespace exists on the destination.
 func (r *KubeVirt) EnsureNamespace() (err error) {
+	err = r.ensureNamespace(r.Plan.Spec.TargetNamespace)
+	return
+}
+
+//
+// Ensure the test VM exists in the (isolated) test namespace.
+// The (paused) DataVolumes are cloned and the VM is created
+// (running) with the cloned disks attached. The VM is connected
+// only to the pod network and isolated by a network policy. The
+// guest has not been converted so the disks are attached using
+// the SATA bus. The VM and DataVolumes are named for the failover
+// so the resources of each failover are distinct.
+func (r *KubeVirt) EnsureTestVM(
+	vm *plan.VMStatus,
+	imp *VmImport,
+	failover *api.TestFailover,
+	template *cnv.VirtualMachineInstanceSpec) (test *api.TestVM, err error) {
+	test, found := failover.Status.FindVM(vm.ID)
+	if !found {
+		name := vm.TargetVMName
+		if name == "" {
+			name = vm.Name
+		}
+		failover.Status.VMs = append(
+			failover.Status.VMs,
+			api.TestVM{
+				Ref:       vm.Ref,
+				Namespace: r.Plan.Spec.Replication.GetTestNamespace(r.Plan.Spec.TargetNamespace),
+				TestName:  r.testName(failover, name, ""),
+			})
+		test = &failover.Status.VMs[len(failover.Status.VMs)-1]
+	}
+	if test.Created {
+		return
+	}
+	err = r.ensureNamespace(test.Namespace)
+	if err != nil {
+		return
+	}
+	err = r.ensureTestNetworkPolicy(test.Namespace)
+	if err != nil {
+		return
+	}
+	labels := r.vmLabels(vm.Ref)
+	labels[kTestFailover] = string(failover.UID)
+	test.DataVolumes = []string{}
+	disks := []cnv.Disk{}
+	volumes := []cnv.Volume{}
+	for i, dv := range imp.DataVolumes {
+		name := r.testName(failover, test.TestName, fmt.Sprintf("-disk-%d", i))
+		object := &cdi.DataVolume{
+			ObjectMeta: meta.ObjectMeta{
+				Namespace: test.Namespace,
+				Name:      name,
+				Labels:    labels,
+			},
+			Spec: cdi.DataVolumeSpec{
+				Source: cdi.DataVolumeSource{
+					PVC: &cdi.DataVolumeSourcePVC{
+						Namespace: dv.Namespace,
+						Name:      dv.Name,
+					},
+				},
+				PVC: dv.Spec.PVC.DeepCopy(),
+			},
+		}
+		err = r.Destination.Client.Create(context.TODO(), object)
+		if err != nil {
+			if k8serr.IsAlreadyExists(err) {
+				err = nil
+			} else {
+				err = liberr.Wrap(err)
+				return
+			}
+		}
+		test.DataVolumes = append(test.DataVolumes, name)
+		disk := cnv.Disk{
+			Name: name,
+			DiskDevice: cnv.DiskDevice{
+				Disk: &cnv.DiskTarget{
+					Bus: "sata",
+				},
+			},
+		}
+		if i == 0 {
+			bootOrder := uint(1)
+			disk.BootOrder = &bootOrder
+		}
+		disks = append(disks, disk)
+		volumes = append(
+			volumes,
+			cnv.Volume{
+				Name: name,
+				VolumeSource: cnv.VolumeSource{
+					DataVolume: &cnv.DataVolumeSource{
+						Name: name,
+					},
+				},
+			})
+	}
+	running := true
+	spec := template.DeepCopy()
+	spec.Domain.Devices.Disks = disks
+	spec.Domain.Devices.Interfaces = []cnv.Interface{
+		{
+			Name: "default",
+			InterfaceBindingMethod: cnv.InterfaceBindingMethod{
+				Masquerade: &cnv.InterfaceMasquerade{},
+			},
+		},
+	}
+	spec.Networks = []cnv.Network{
+		*cnv.DefaultPodNetwork(),
+	}
+	spec.Volumes = volumes
+	object := &cnv.VirtualMachine{
+		ObjectMeta: meta.ObjectMeta{
+			Namespace: test.Namespace,
+			Name:      test.TestName,
+			Labels:    labels,
+		},
+		Spec: cnv.VirtualMachineSpec{
+			Running: &running,
+			Template: &cnv.VirtualMachineInstanceTemplateSpec{
+				ObjectMeta: meta.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: *spec,
+			},
+		},
+	}
+	err = r.Destination.Client.Create(context.TODO(), object)
+	if err != nil {
+		if k8serr.IsAlreadyExists(err) {
+			err = nil
+		} else {
+			err = liberr.Wrap(err)
+			return
+		}
+	}
+	test.Created = true
+
+	r.Log.Info(
+		"Test VM created.",
+		"vm",
+		vm.String(),
+		"test",
+		path.Join(
+			test.Namespace,
+			test.TestName))
+
+	return
+}
+
+//
+// Name of a test failover resource.
+// The (truncated) name is suffixed by the failover UID (prefix).
+func (r *KubeVirt) testName(failover *api.TestFailover, name string, suffix string) string {
+	uid := string(failover.UID)
+	if len(uid) > 8 {
+		uid = uid[:8]
+	}
+	suffix = "-" + uid + suffix
+	name = strings.TrimSuffix(name, "-"+uid)
+
+	return truncateName(name, len(suffix)) + suffix
+}
+
+//
+// Ensure the network policy isolating the test VMs exists.
+// The test VMs may only communicate with other test VMs.
+func (r *KubeVirt) ensureTestNetworkPolicy(namespace string) (err error) {
+	selector := meta.LabelSelector{
+		MatchExpressions: []meta.LabelSelectorRequirement{
+			{
+				Key:      kTestFailover,
+				Operator: meta.LabelSelectorOpExists,
+			},
+		},
+	}
+	policy := &networking.NetworkPolicy{
+		ObjectMeta: meta.ObjectMeta{
+			Namespace: namespace,
+			Name:      TestNetworkPolicy,
+		},
+		Spec: networking.NetworkPolicySpec{
+			PodSelector: selector,
+			PolicyTypes: []networking.PolicyType{
+				networking.PolicyTypeIngress,
+				networking.PolicyTypeEgress,
+			},
+			Ingress: []networking.NetworkPolicyIngressRule{
+				{
+					From: []networking.NetworkPolicyPeer{
+						{PodSelector: &selector},
+					},
+				},
+			},
+			Egress: []networking.NetworkPolicyEgressRule{
+				{
+					To: []networking.NetworkPolicyPeer{
+						{PodSelector: &selector},
+					},
+				},
+			},
+		},
+	}
+	err = r.Destination.Client.Create(context.TODO(), policy)
+	if err != nil {
+		if k8serr.IsAlreadyExists(err) {
+			err = nil
+		} else {
+			err = liberr.Wrap(err)
+		}
+		return
+	}
+
+	r.Log.Info(
+		"Created (test) network policy.",
+		"policy",
+		path.Join(
+			policy.Namespace,
+			policy.Name))
+
+	return
+}
+
+//
+// Delete the test VMs and cloned DataVolumes created
+// by test failovers that are not listed to be kept.
+func (r *KubeVirt) DeleteTestVMs(keep map[string]bool) (err error) {
+	exists, err := labels.NewRequirement(kTestFailover, selection.Exists, nil)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	selector := labels.SelectorFromSet(
+		map[string]string{
+			kPlan: string(r.Plan.GetUID()),
+		})
+	options := &client.ListOptions{
+		LabelSelector: selector.Add(*exists),
+		Namespace:     r.Plan.Spec.Replication.GetTestNamespace(r.Plan.Spec.TargetNamespace),
+	}
+	vmList := &cnv.VirtualMachineList{}
+	err = r.Destination.Client.List(context.TODO(), vmList, options)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	for i := range vmList.Items {
+		object := &vmList.Items[i]
+		if !keep[object.Labels[kTestFailover]] {
+			err = r.deleteTestObject(object)
+			if err != nil {
+				return
+			}
+		}
+	}
+	dvList := &cdi.DataVolumeList{}
+	err = r.Destination.Client.List(context.TODO(), dvList, options)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	for i := range dvList.Items {
+		object := &dvList.Items[i]
+		if !keep[object.Labels[kTestFailover]] {
+			err = r.deleteTestObject(object)
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	return
+}
+
+//
+// Delete a test failover resource.
+func (r *KubeVirt) deleteTestObject(object runtime.Object) (err error) {
+	err = r.Destination.Client.Delete(context.TODO(), object)
+	if err != nil {
+		if k8serr.IsNotFound(err) {
+			err = nil
+		} else {
+			err = liberr.Wrap(err)
+		}
+		return
+	}
+	if mObject, cast := object.(meta.Object); cast {
+		r.Log.Info(
+			"Deleted (test) resource.",
+			"resource",
+			path.Join(
+				mObject.GetNamespace(),
+				mObject.GetName()))
+	}
+
+	return
+}
+
+//
+// Ensure a namespace exists on the destination.
+func (r *KubeVirt) ensureNamespace(name string) (err error) {
 	ns := &core.Namespace{
 		ObjectMeta: meta.ObjectMeta{
-			Name: r.Plan.Spec.TargetNamespace,
+			Name: name,
 		},
 	}
 	err = r.Destination.Client.Create(context.TODO(), ns)
@@ -327,9 +1088,13 @@ func (r *KubeVirt) vmImport(
 		return
 	}
 	annotations := make(map[string]string)
-	if r.Plan.Spec.TransferNetwork != nil {
+	transferNetwork := vm.TransferNetwork
+	if transferNetwork == nil {
+		transferNetwork = r.Plan.Spec.TransferNetwork
+	}
+	if transferNetwork != nil {
 		annotations[annDefaultNetwork] = path.Join(
-			r.Plan.Spec.TransferNetwork.Namespace, r.Plan.Spec.TransferNetwork.Name)
+			transferNetwork.Namespace, transferNetwork.Name)
 	}
 	object = &vmio.VirtualMachineImport{
 		ObjectMeta: meta.ObjectMeta{
@@ -353,14 +1118,240 @@ func (r *KubeVirt) vmImport(
 	if err != nil {
 		return
 	}
-	if vm.Name != "" {
+	if vm.TargetVMName != "" {
+		object.Spec.TargetVMName = &vm.TargetVMName
+	} else if vm.Name != "" {
 		object.Spec.TargetVMName = &vm.Name
 	}
+	// the source VM was powered on before the source shutdown step.
+	if step, found := vm.FindStep(ShutdownSource); found {
+		if step.Annotations[annPoweredOn] == "true" {
+			start := true
+			object.Spec.StartVM = &start
+		}
+	}
 
 	// the value set on the migration, if any, takes precedence over the value set on the plan.
+	// the cutover is deferred while the VM migration is paused.
+	// the earliest of the (per-VM) cutover and the automatic cutover is used.
 	if r.Plan.Spec.Warm {
 		object.Spec.Warm = true
-		object.Spec.FinalizeDate = r.Migration.Spec.Cutover
+		if !r.Migration.Spec.Paused(vm.Ref) {
+			cutover := r.Migration.Spec.CutoverFor(vm.Ref)
+			if vm.Warm != nil && vm.Warm.Cutover != nil {
+				if cutover == nil || vm.Warm.Cutover.Before(cutover) {
+					cutover = vm.Warm.Cutover
+				}
+			}
+			object.Spec.FinalizeDate = cutover
+		}
+	}
+	// the VM is started after the guest network configuration
+	// and the target VM customization have been applied.
+	if object.Spec.StartVM != nil && *object.Spec.StartVM {
+		annotations[annStartVM] = "true"
+		start := false
+		object.Spec.StartVM = &start
+	}
+
+	return
+}
+
+//
+// Customize the VM created by the import. The devices
+// built by the provider and the target VM overrides are
+// applied. The VM is started as needed.
+func (r *KubeVirt) CustomizeVM(vm *plan.VMStatus, imp *VmImport) (err error) {
+	object := &cnv.VirtualMachine{}
+	err = r.Destination.Client.Get(
+		context.TODO(),
+		client.ObjectKey{
+			Namespace: imp.Namespace,
+			Name:      imp.Status.TargetVMName,
+		},
+		object)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	dvs := []*cdi.DataVolume{}
+	for _, dv := range imp.DataVolumes {
+		dvs = append(dvs, dv.DataVolume)
+	}
+	vmiSpec := &object.Spec.Template.Spec
+	err = r.Builder.Devices(vm.Ref, dvs, vmiSpec)
+	if err != nil {
+		return
+	}
+	// The VM labels are applied so that the destination
+	// VM and DataVolumes can be found by the rollback.
+	vmLabels := r.vmLabels(vm.Ref)
+	err = r.labelDVs(imp, vmLabels)
+	if err != nil {
+		return
+	}
+	target := &plan.TargetVM{}
+	if found := r.Plan.Spec.FindTargetVM(&vm.VM); found != nil {
+		*target = *found
+	}
+	for k, v := range target.Labels {
+		vmLabels[k] = v
+	}
+	target.Labels = vmLabels
+	start := imp.Annotations[annStartVM] == "true"
+	patch, err := json.Marshal(r.targetVMPatch(target, vmiSpec, start))
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	err = r.Destination.Client.Patch(
+		context.TODO(),
+		object,
+		client.RawPatch(types.MergePatchType, patch))
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+
+	r.Log.Info(
+		"Customized target VM.",
+		"target",
+		path.Join(
+			object.Namespace,
+			object.Name),
+		"vm",
+		vm.String())
+
+	return
+}
+
+//
+// Label the DataVolumes created by the import.
+func (r *KubeVirt) labelDVs(imp *VmImport, dvLabels map[string]string) (err error) {
+	patch, err := json.Marshal(
+		map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"labels": dvLabels,
+			},
+		})
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	for _, dv := range imp.DataVolumes {
+		err = r.Destination.Client.Patch(
+			context.TODO(),
+			dv.DataVolume,
+			client.RawPatch(types.MergePatchType, patch))
+		if err != nil {
+			err = liberr.Wrap(err)
+			return
+		}
+	}
+
+	return
+}
+
+//
+// Build the (merge) patch for the devices, networks and target VM overrides.
+// The CPU and memory set by the import are removed when
+// an instance type is specified.
+func (r *KubeVirt) targetVMPatch(target *plan.TargetVM, vmi *cnv.VirtualMachineInstanceSpec, start bool) (patch map[string]interface{}) {
+	metadata := map[string]interface{}{}
+	if len(target.Labels) > 0 {
+		metadata["labels"] = target.Labels
+	}
+	if len(target.Annotations) > 0 {
+		metadata["annotations"] = target.Annotations
+	}
+	domain := map[string]interface{}{}
+	if target.CPU != nil {
+		cpu := map[string]interface{}{}
+		if target.CPU.Sockets > 0 {
+			cpu["sockets"] = target.CPU.Sockets
+		}
+		if target.CPU.Cores > 0 {
+			cpu["cores"] = target.CPU.Cores
+		}
+		if target.CPU.Threads > 0 {
+			cpu["threads"] = target.CPU.Threads
+		}
+		domain["cpu"] = cpu
+	}
+	if target.Memory != nil {
+		domain["resources"] = map[string]interface{}{
+			"requests": map[string]interface{}{
+				"memory": target.Memory.String(),
+			},
+		}
+	}
+	if target.MachineType != "" {
+		domain["machine"] = map[string]interface{}{
+			"type": target.MachineType,
+		}
+	}
+	if vmi != nil {
+		domain["devices"] = map[string]interface{}{
+			"disks":      vmi.Domain.Devices.Disks,
+			"interfaces": vmi.Domain.Devices.Interfaces,
+		}
+	}
+	spec := map[string]interface{}{}
+	if target.InstanceType != nil {
+		kind := target.InstanceType.Kind
+		if kind == "" {
+			kind = plan.ClusterInstanceTypeKind
+		}
+		spec["instancetype"] = map[string]interface{}{
+			"name": target.InstanceType.Name,
+			"kind": kind,
+		}
+		domain["cpu"] = nil
+		domain["resources"] = map[string]interface{}{
+			"requests": map[string]interface{}{
+				"memory": nil,
+			},
+		}
+	}
+	if target.Preference != nil {
+		kind := target.Preference.Kind
+		if kind == "" {
+			kind = plan.ClusterPreferenceKind
+		}
+		spec["preference"] = map[string]interface{}{
+			"name": target.Preference.Name,
+			"kind": kind,
+		}
+	}
+	vmiSpec := map[string]interface{}{}
+	if len(domain) > 0 {
+		vmiSpec["domain"] = domain
+	}
+	if vmi != nil {
+		vmiSpec["networks"] = vmi.Networks
+	}
+	if len(target.NodeSelector) > 0 {
+		vmiSpec["nodeSelector"] = target.NodeSelector
+	}
+	if len(target.Tolerations) > 0 {
+		vmiSpec["tolerations"] = target.Tolerations
+	}
+	if len(vmiSpec) > 0 {
+		spec["template"] = map[string]interface{}{
+			"spec": vmiSpec,
+		}
+	}
+	if target.RunStrategy != "" {
+		spec["running"] = nil
+		spec["runStrategy"] = target.RunStrategy
+	} else if start {
+		spec["running"] = true
+	}
+	patch = map[string]interface{}{
+		"spec": spec,
+	}
+	if len(metadata) > 0 {
+		patch["metadata"] = metadata
 	}
 
 	return
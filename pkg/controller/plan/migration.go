@@ -6,12 +6,15 @@ import (
 	libcnd "github.com/konveyor/controller/pkg/condition"
 	liberr "github.com/konveyor/controller/pkg/error"
 	libitr "github.com/konveyor/controller/pkg/itinerary"
+	api "github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1"
 	"github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1/plan"
 	"github.com/konveyor/forklift-controller/pkg/controller/plan/adapter"
 	plancontext "github.com/konveyor/forklift-controller/pkg/controller/plan/context"
 	"github.com/konveyor/forklift-controller/pkg/controller/plan/scheduler"
 	"github.com/konveyor/forklift-controller/pkg/controller/provider/web"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 	vmio "kubevirt.io/vm-import-operator/pkg/apis/v2v/v1beta1"
+	"strings"
 	"time"
 )
 
@@ -22,22 +25,50 @@ const (
 	PollReQ = time.Second * 3
 )
 
+//
+// Warm precopy hold.
+// The next precopy of a paused VM is deferred by this interval
+// and the deferral is extended while the VM remains paused.
+// The hold expires on its own when no longer extended.
+const (
+	PrecopyHold = time.Minute * 5
+)
+
+//
+// Source VM snapshot removal timeout.
+const (
+	SnapshotRemovalTimeout = time.Minute * 30
+)
+
 //
 // Predicates.
 var (
 	HasPreHook  libitr.Flag = 0x01
 	HasPostHook libitr.Flag = 0x02
+	HasShutdown libitr.Flag = 0x08
+	HasUpdate   libitr.Flag = 0x10
+	HasVerify   libitr.Flag = 0x20
+	HasSnapshot libitr.Flag = 0x40
+	HasRemoval  libitr.Flag = 0x80
+	HasStaticIP libitr.Flag = 0x100
 )
 
 //
 // Phases.
 const (
-	Started       = "Started"
-	PreHook       = "PreHook"
-	CreateImport  = "CreateImport"
-	ImportCreated = "ImportCreated"
-	PostHook      = "PostHook"
-	Completed     = "Completed"
+	Started        = "Started"
+	PreHook        = "PreHook"
+	ShutdownSource = "ShutdownSource"
+	CreateSnapshot = "CreateSnapshot"
+	CreateImport   = "CreateImport"
+	ImportCreated  = "ImportCreated"
+	CustomizeVM    = "CustomizeVM"
+	GuestNetwork   = "GuestNetwork"
+	Verify         = "Verify"
+	PostHook       = "PostHook"
+	RemoveSnapshot = "RemoveSnapshot"
+	UpdateSource   = "UpdateSource"
+	Completed      = "Completed"
 )
 
 //
@@ -47,15 +78,37 @@ const (
 	ImageConversion = "ImageConversion"
 )
 
+//
+// Step annotations.
+const (
+	// The source VM was powered on before shutdown.
+	annPoweredOn = "poweredOn"
+	// The source VM snapshot ID.
+	annSnapshot = "snapshot"
+	// The source VM name before rename.
+	annSourceName = "sourceName"
+	// The source VM folder before the move.
+	annSourceFolder = "sourceFolder"
+	// The tag attached to the source VM.
+	annSourceTag = "sourceTag"
+)
+
 var (
 	itinerary = libitr.Itinerary{
 		Name: "",
 		Pipeline: libitr.Pipeline{
 			{Name: Started},
 			{Name: PreHook, All: HasPreHook},
+			{Name: ShutdownSource, All: HasShutdown},
+			{Name: CreateSnapshot, All: HasSnapshot},
 			{Name: CreateImport},
 			{Name: ImportCreated},
+			{Name: GuestNetwork, All: HasStaticIP},
+			{Name: CustomizeVM},
+			{Name: Verify, All: HasVerify},
 			{Name: PostHook, All: HasPostHook},
+			{Name: RemoveSnapshot, All: HasRemoval},
+			{Name: UpdateSource, All: HasUpdate},
 			{Name: Completed},
 		},
 	}
@@ -73,6 +126,12 @@ type Migration struct {
 	importMap ImportMap
 	// VM scheduler
 	scheduler scheduler.Scheduler
+	// Target VM names.
+	namer Namer
+	// Transfer networks.
+	transfer TransferNetworks
+	// Source client.
+	client adapter.Client
 }
 
 //
@@ -90,6 +149,7 @@ func (r *Migration) Run() (reQ time.Duration, err error) {
 		err = liberr.Wrap(err)
 		return
 	}
+	defer r.closeClient()
 	err = r.begin()
 	if err != nil {
 		err = liberr.Wrap(err)
@@ -97,6 +157,20 @@ func (r *Migration) Run() (reQ time.Duration, err error) {
 	}
 
 	r.resolveCanceledRefs()
+	r.resolvePausedRefs()
+	r.resolveCutoverRefs()
+
+	if r.Context.Migration.Spec.Pause {
+		snapshot := r.Plan.Status.Migration.ActiveSnapshot()
+		snapshot.SetCondition(
+			libcnd.Condition{
+				Type:     Paused,
+				Status:   True,
+				Category: Advisory,
+				Reason:   UserRequested,
+				Message:  "The plan execution is PAUSED.",
+			})
+	}
 
 	for _, vm := range r.runningVMs() {
 		err = r.step(vm)
@@ -105,15 +179,20 @@ func (r *Migration) Run() (reQ time.Duration, err error) {
 		}
 	}
 
-	vm, hasNext, err := r.scheduler.Next()
-	if err != nil {
-		return
-	}
-	if hasNext {
-		err = r.step(vm)
-		if err != nil {
+	if r.Context.Migration.Spec.Expired() {
+		r.skipUnstarted()
+	} else {
+		vm, hasNext, nErr := r.scheduler.Next()
+		if nErr != nil {
+			err = nErr
 			return
 		}
+		if hasNext {
+			err = r.step(vm)
+			if err != nil {
+				return
+			}
+		}
 	}
 
 	completed, err := r.end()
@@ -124,6 +203,31 @@ func (r *Migration) Run() (reQ time.Duration, err error) {
 	return
 }
 
+//
+// Skip VMs not started before the deadline.
+func (r *Migration) skipUnstarted() {
+	for _, vm := range r.Plan.Status.Migration.VMs {
+		if vm.MarkedStarted() || vm.MarkedCompleted() {
+			continue
+		}
+		vm.SetCondition(
+			libcnd.Condition{
+				Type:     Skipped,
+				Status:   True,
+				Category: Advisory,
+				Reason:   Expired,
+				Message:  "The VM migration was not started before the deadline.",
+				Durable:  true,
+			})
+		vm.Phase = Completed
+		vm.MarkCompleted()
+		r.Log.Info(
+			"Migration [SKIPPED]",
+			"vm",
+			vm.String())
+	}
+}
+
 //
 // Steps a VM through the migration itinerary
 // and updates its status.
@@ -146,8 +250,19 @@ func (r *Migration) step(vm *plan.VMStatus) (err error) {
 			vm.String())
 		return
 	}
+	// check whether the VM has been paused by the user
+	if r.paused(vm) {
+		r.Log.Info(
+			"Migration [PAUSED]",
+			"vm",
+			vm.String(),
+			"phase",
+			vm.Phase)
+		return
+	}
 	itinerary.Predicate = &Predicate{
-		vm: &vm.VM,
+		plan: r.Plan,
+		vm:   &vm.VM,
 	}
 
 	r.Log.Info(
@@ -164,6 +279,14 @@ func (r *Migration) step(vm *plan.VMStatus) (err error) {
 	switch vm.Phase {
 	case Started:
 		vm.MarkStarted()
+		if r.Plan.Spec.StaticIPs != nil && vm.GuestNetwork == nil {
+			vm.GuestNetwork, err = r.builder.GuestNetwork(vm.Ref)
+			if err != nil {
+				vm.AddError(err.Error())
+				err = nil
+				break
+			}
+		}
 		vm.Phase = r.next(vm.Phase)
 	case PreHook, PostHook:
 		runner := HookRunner{Context: r.Context}
@@ -178,7 +301,63 @@ func (r *Migration) step(vm *plan.VMStatus) (err error) {
 		} else {
 			vm.Phase = Completed
 		}
+	case ShutdownSource:
+		step, found := vm.ActiveStep()
+		if !found {
+			vm.Phase = r.next(vm.Phase)
+			break
+		}
+		err = r.shutdownSource(vm, step)
+		if err != nil {
+			return
+		}
+		if step.MarkedCompleted() && step.Error == nil {
+			vm.Phase = r.next(vm.Phase)
+		}
+	case CreateSnapshot:
+		step, found := vm.ActiveStep()
+		if !found {
+			vm.Phase = r.next(vm.Phase)
+			break
+		}
+		err = r.createSnapshot(vm, step)
+		if err != nil {
+			return
+		}
+		if step.MarkedCompleted() && step.Error == nil {
+			vm.Phase = r.next(vm.Phase)
+		}
+	case RemoveSnapshot:
+		step, found := vm.ActiveStep()
+		if !found {
+			vm.Phase = r.next(vm.Phase)
+			break
+		}
+		err = r.removeSnapshot(vm, step)
+		if err != nil {
+			return
+		}
+		if step.MarkedCompleted() && step.Error == nil {
+			vm.Phase = r.next(vm.Phase)
+		}
+	case UpdateSource:
+		step, found := vm.ActiveStep()
+		if !found {
+			vm.Phase = r.next(vm.Phase)
+			break
+		}
+		err = r.updateSource(vm, step)
+		if err != nil {
+			return
+		}
+		vm.Phase = r.next(vm.Phase)
 	case CreateImport:
+		err = r.kubevirt.EnsureExcludedDisks(vm)
+		if err != nil {
+			vm.AddError(err.Error())
+			err = nil
+			break
+		}
 		err = r.kubevirt.EnsureImport(vm)
 		if err != nil {
 			if !errors.As(err, &web.ProviderNotReadyError{}) {
@@ -215,8 +394,87 @@ func (r *Migration) step(vm *plan.VMStatus) (err error) {
 				vm.Phase = Completed
 			}
 		}
+	case GuestNetwork:
+		step, found := vm.ActiveStep()
+		if !found {
+			vm.Phase = r.next(vm.Phase)
+			break
+		}
+		step.MarkStarted()
+		imp, found, fErr := r.findImport(vm)
+		if fErr != nil {
+			err = liberr.Wrap(fErr)
+			return
+		}
+		if !found {
+			vm.AddError("Import CR not found.")
+			break
+		}
+		err = r.kubevirt.ConfigureGuestNetwork(vm, &imp)
+		if err != nil {
+			step.AddError(err.Error())
+			step.MarkCompleted()
+			err = nil
+			break
+		}
+		step.Progress.Completed = step.Progress.Total
+		step.MarkCompleted()
+		vm.Phase = r.next(vm.Phase)
+	case CustomizeVM:
+		step, found := vm.ActiveStep()
+		if !found {
+			vm.Phase = r.next(vm.Phase)
+			break
+		}
+		step.MarkStarted()
+		imp, found, fErr := r.findImport(vm)
+		if fErr != nil {
+			err = liberr.Wrap(fErr)
+			return
+		}
+		if !found {
+			vm.AddError("Import CR not found.")
+			break
+		}
+		err = r.kubevirt.DeleteExcludedDisks(vm)
+		if err == nil {
+			err = r.kubevirt.CustomizeVM(vm, &imp)
+		}
+		if err != nil {
+			step.AddError(err.Error())
+			step.MarkCompleted()
+			err = nil
+			break
+		}
+		step.Progress.Completed = step.Progress.Total
+		step.MarkCompleted()
+		vm.Phase = r.next(vm.Phase)
+	case Verify:
+		step, found := vm.ActiveStep()
+		if !found {
+			vm.Phase = r.next(vm.Phase)
+			break
+		}
+		err = r.verify(vm, step)
+		if err != nil {
+			return
+		}
+		if step.MarkedCompleted() && step.Error == nil {
+			vm.Phase = r.next(vm.Phase)
+		}
 	case Completed:
 		vm.MarkCompleted()
+		// the steps that follow the import have run.
+		if vm.Error == nil {
+			vm.SetCondition(
+				libcnd.Condition{
+					Type:     Succeeded,
+					Status:   True,
+					Category: Advisory,
+					Message:  "The VM migration has SUCCEEDED.",
+					Durable:  true,
+				})
+		}
 		r.Log.Info(
 			"Migration [COMPLETED]",
 			"vm",
@@ -264,6 +522,10 @@ func (r *Migration) Cancel() (err error) {
 				err = liberr.Wrap(err)
 				return
 			}
+			err = r.kubevirt.DeleteExcludedDisks(vm)
+			if err != nil {
+				return
+			}
 			vm.MarkCompleted()
 			for _, step := range vm.Pipeline {
 				if step.MarkedStarted() {
@@ -286,6 +548,62 @@ func (r *Migration) resolveCanceledRefs() {
 	}
 }
 
+//
+// Best effort attempt to resolve paused refs.
+func (r *Migration) resolvePausedRefs() {
+	for i := range r.Context.Migration.Spec.PauseVMs {
+		// resolve the VM ref in place
+		ref := &r.Context.Migration.Spec.PauseVMs[i]
+		_, _ = r.Source.Inventory.VM(ref)
+	}
+}
+
+//
+// Best effort attempt to resolve (per-VM) cutover refs.
+func (r *Migration) resolveCutoverRefs() {
+	for i := range r.Context.Migration.Spec.VMCutovers {
+		// resolve the VM ref in place
+		ref := &r.Context.Migration.Spec.VMCutovers[i].Ref
+		_, _ = r.Source.Inventory.VM(ref)
+	}
+}
+
+//
+// Determine whether the VM is held by a user requested pause.
+// Phases that would start new work are held. Work already in
+// progress (hook jobs and imports) is allowed to finish but the
+// steps that follow are not started until resumed. The next
+// warm precopy of an import is held (see: holdPrecopy()).
+// Resumed VMs continue from the current pipeline step.
+func (r *Migration) paused(vm *plan.VMStatus) (held bool) {
+	if !r.Context.Migration.Spec.Paused(vm.Ref) {
+		if cnd := vm.FindCondition(Paused); cnd != nil && cnd.Reason == UserRequested {
+			vm.DeleteCondition(Paused)
+		}
+		return
+	}
+	vm.SetCondition(
+		libcnd.Condition{
+			Type:     Paused,
+			Status:   True,
+			Category: Advisory,
+			Reason:   UserRequested,
+			Message:  "The VM migration is PAUSED.",
+			Durable:  true,
+		})
+	switch vm.Phase {
+	case PreHook, PostHook:
+		step, found := vm.ActiveStep()
+		held = found && !step.MarkedStarted()
+	case ImportCreated, Completed:
+		held = false
+	default:
+		held = true
+	}
+
+	return
+}
+
 //
 func (r *Migration) runningVMs() (vms []*plan.VMStatus) {
 	vms = make([]*plan.VMStatus, 0)
@@ -311,10 +629,25 @@ func (r *Migration) init() (err error) {
 		err = liberr.Wrap(err)
 		return
 	}
+	validator, err := adapter.Validator(r.Plan)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
 	r.kubevirt = KubeVirt{
 		Context: r.Context,
 		Builder: r.builder,
 	}
+	r.namer = Namer{
+		Plan:        r.Plan,
+		Validator:   validator,
+		Source:      r.Source.Inventory,
+		Destination: r.Destination.Inventory,
+	}
+	r.transfer = TransferNetworks{
+		Plan:      r.Plan,
+		Validator: validator,
+	}
 	r.scheduler, err = scheduler.New(r.Context)
 	if err != nil {
 		return
@@ -343,7 +676,7 @@ func (r *Migration) next(phase string) (next string) {
 // Begin the migration.
 func (r *Migration) begin() (err error) {
 	snapshot := r.Plan.Status.Migration.ActiveSnapshot()
-	if snapshot.HasAnyCondition(Executing, Succeeded, Failed, Canceled) {
+	if snapshot.HasAnyCondition(Executing, Succeeded, Failed, Canceled, Skipped) {
 		return
 	}
 	r.Plan.Status.Migration.MarkReset()
@@ -383,20 +716,38 @@ func (r *Migration) begin() (err error) {
 	list := []*plan.VMStatus{}
 	for _, vm := range r.Plan.Spec.VMs {
 		var status *plan.VMStatus
-		itinerary.Predicate = &Predicate{vm: &vm}
+		itinerary.Predicate = &Predicate{plan: r.Plan, vm: &vm}
 		step, _ := itinerary.First()
 		if current, found := r.Plan.Status.Migration.FindVM(vm.Ref); !found {
 			status = &plan.VMStatus{VM: vm}
 		} else {
 			status = current
 		}
-		if status.Phase != Completed || status.HasAnyCondition(Canceled, Failed) {
+		if status.TargetVMName == "" {
+			status.TargetVMName, err = r.namer.Name(&vm)
+			if err != nil {
+				return
+			}
+		}
+		reset := status.Phase != Completed || status.HasAnyCondition(Canceled, Failed, RolledBack, Skipped)
+		if reset {
+			// Resolved again; the plan or the source
+			// placement may have changed.
+			status.TransferNetwork = nil
+		}
+		if status.TransferNetwork == nil {
+			status.TransferNetwork, err = r.transfer.Network(&vm)
+			if err != nil {
+				return
+			}
+		}
+		if reset {
 			pipeline, pErr := r.buildPipeline(&vm)
 			if pErr != nil {
 				err = liberr.Wrap(pErr)
 				return
 			}
-			status.DeleteCondition(Canceled, Failed)
+			status.DeleteCondition(Canceled, Failed, Succeeded, RolledBack, RollbackFailed, Skipped)
 			status.MarkReset()
 			status.Pipeline = pipeline
 			status.Phase = step.Name
@@ -425,7 +776,7 @@ func (r *Migration) begin() (err error) {
 //
 // Build the pipeline for a VM status.
 func (r *Migration) buildPipeline(vm *plan.VM) (pipeline []*plan.Step, err error) {
-	itinerary.Predicate = &Predicate{vm: vm}
+	itinerary.Predicate = &Predicate{plan: r.Plan, vm: vm}
 	step, _ := itinerary.First()
 	for {
 		switch step.Name {
@@ -439,6 +790,26 @@ func (r *Migration) buildPipeline(vm *plan.VM) (pipeline []*plan.Step, err error
 						Progress:    libitr.Progress{Total: 1},
 					},
 				})
+		case ShutdownSource:
+			pipeline = append(
+				pipeline,
+				&plan.Step{
+					Task: plan.Task{
+						Name:        ShutdownSource,
+						Description: "Shut down the source VM.",
+						Progress:    libitr.Progress{Total: 1},
+					},
+				})
+		case CreateSnapshot:
+			pipeline = append(
+				pipeline,
+				&plan.Step{
+					Task: plan.Task{
+						Name:        CreateSnapshot,
+						Description: "Create the source VM snapshot.",
+						Progress:    libitr.Progress{Total: 1},
+					},
+				})
 		case CreateImport:
 			tasks, pErr := r.builder.Tasks(vm.Ref)
 			if pErr != nil {
@@ -473,6 +844,36 @@ func (r *Migration) buildPipeline(vm *plan.VM) (pipeline []*plan.Step, err error
 						Progress:    libitr.Progress{Total: 1},
 					},
 				})
+		case GuestNetwork:
+			pipeline = append(
+				pipeline,
+				&plan.Step{
+					Task: plan.Task{
+						Name:        GuestNetwork,
+						Description: "Configure the guest network (static IPs).",
+						Progress:    libitr.Progress{Total: 1},
+					},
+				})
+		case CustomizeVM:
+			pipeline = append(
+				pipeline,
+				&plan.Step{
+					Task: plan.Task{
+						Name:        CustomizeVM,
+						Description: "Customize the target VM (devices and overrides).",
+						Progress:    libitr.Progress{Total: 1},
+					},
+				})
+		case Verify:
+			pipeline = append(
+				pipeline,
+				&plan.Step{
+					Task: plan.Task{
+						Name:        Verify,
+						Description: "Verify the migrated VM.",
+						Progress:    libitr.Progress{Total: 1},
+					},
+				})
 		case PostHook:
 			pipeline = append(
 				pipeline,
@@ -483,6 +884,26 @@ func (r *Migration) buildPipeline(vm *plan.VM) (pipeline []*plan.Step, err error
 						Progress:    libitr.Progress{Total: 1},
 					},
 				})
+		case RemoveSnapshot:
+			pipeline = append(
+				pipeline,
+				&plan.Step{
+					Task: plan.Task{
+						Name:        RemoveSnapshot,
+						Description: "Remove the source VM snapshot.",
+						Progress:    libitr.Progress{Total: 1},
+					},
+				})
+		case UpdateSource:
+			pipeline = append(
+				pipeline,
+				&plan.Step{
+					Task: plan.Task{
+						Name:        UpdateSource,
+						Description: "Apply source VM actions.",
+						Progress:    libitr.Progress{Total: 1},
+					},
+				})
 		}
 		next, done, _ := itinerary.Next(step.Name)
 		if !done {
@@ -505,6 +926,7 @@ func (r *Migration) buildPipeline(vm *plan.VM) (pipeline []*plan.Step, err error
 func (r *Migration) end() (completed bool, err error) {
 	failed := 0
 	succeeded := 0
+	skipped := []string{}
 	for _, vm := range r.Plan.Status.Migration.VMs {
 		if !vm.MarkedCompleted() {
 			return
@@ -515,10 +937,26 @@ func (r *Migration) end() (completed bool, err error) {
 		if vm.HasCondition(Succeeded) {
 			succeeded++
 		}
+		if vm.HasCondition(Skipped) {
+			skipped = append(skipped, vm.String())
+		}
 	}
 	r.Plan.Status.Migration.MarkCompleted()
 	snapshot := r.Plan.Status.Migration.ActiveSnapshot()
 	snapshot.DeleteCondition(Executing)
+	if len(skipped) > 0 {
+		// VMs not started before the deadline.
+		snapshot.SetCondition(
+			libcnd.Condition{
+				Type:     Skipped,
+				Status:   True,
+				Category: Advisory,
+				Reason:   Expired,
+				Message:  "VM migrations were not started before the deadline.",
+				Items:    skipped,
+				Durable:  true,
+			})
+	}
 
 	if failed > 0 {
 		// if any VMs failed, the migration failed.
@@ -548,6 +986,10 @@ func (r *Migration) end() (completed bool, err error) {
 				Message:  "The plan execution has SUCCEEDED.",
 				Durable:  true,
 			})
+	} else if len(skipped) > 0 {
+		// if there were no failures or successes and
+		// VMs were skipped, then the migration expired.
+		r.Log.Info("Migration [EXPIRED]")
 	} else {
 		// if there were no failures or successes, but
 		// all the VMs are complete, then the migration must
@@ -568,8 +1010,342 @@ func (r *Migration) end() (completed bool, err error) {
 }
 
 //
-// Update VM migration status.
-func (r *Migration) updateVM(vm *plan.VMStatus) (completed bool, failed bool, err error) {
+// Shut down the source VM.
+// The guest is shut down and the step completed once the VM
+// is powered off. When the guest has not shut down within
+// the timeout, the VM is powered off (when forced).
+func (r *Migration) shutdownSource(vm *plan.VMStatus, step *plan.Step) (err error) {
+	client, err := r.sourceClient()
+	if err != nil {
+		return
+	}
+	shutdown := r.Plan.Spec.SourceVM.Shutdown
+	failed := func(pErr error) {
+		step.AddError(liberr.Unwrap(pErr).Error())
+		step.MarkCompleted()
+	}
+	if !step.MarkedStarted() {
+		step.MarkStarted()
+		off, pErr := client.PoweredOff(vm.Ref)
+		if pErr != nil {
+			failed(pErr)
+			return
+		}
+		if !off {
+			if step.Annotations == nil {
+				step.Annotations = map[string]string{}
+			}
+			step.Annotations[annPoweredOn] = "true"
+			pErr = client.Shutdown(vm.Ref)
+			if pErr != nil {
+				r.Log.Info(
+					"Guest shutdown failed.",
+					"vm",
+					vm.String(),
+					"reason",
+					pErr.Error())
+				if !shutdown.Force {
+					failed(pErr)
+					return
+				}
+				pErr = client.PowerOff(vm.Ref)
+				if pErr != nil {
+					failed(pErr)
+					return
+				}
+			}
+		}
+	}
+	off, pErr := client.PoweredOff(vm.Ref)
+	if pErr != nil {
+		failed(pErr)
+		return
+	}
+	if !off {
+		timeout := time.Duration(shutdown.GetTimeout()) * time.Second
+		if time.Since(step.Started.Time) < timeout {
+			return
+		}
+		if !shutdown.Force {
+			step.AddError("The guest shutdown has timed out.")
+			step.MarkCompleted()
+			return
+		}
+		r.Log.Info(
+			"Guest shutdown timed out, powering off.",
+			"vm",
+			vm.String())
+		pErr = client.PowerOff(vm.Ref)
+		if pErr != nil {
+			failed(pErr)
+			return
+		}
+	}
+	step.Progress.Completed = step.Progress.Total
+	step.MarkCompleted()
+
+	return
+}
+
+//
+// Create the source VM snapshot.
+// The snapshot ID is recorded on the step and the step
+// completed once the snapshot is ready.
+func (r *Migration) createSnapshot(vm *plan.VMStatus, step *plan.Step) (err error) {
+	client, err := r.sourceClient()
+	if err != nil {
+		return
+	}
+	failed := func(pErr error) {
+		step.AddError(liberr.Unwrap(pErr).Error())
+		step.MarkCompleted()
+	}
+	if !step.MarkedStarted() {
+		step.MarkStarted()
+		name := r.Plan.Spec.SourceVM.Snapshot.Name
+		if name == "" {
+			name = "forklift-" + r.Plan.Name
+		}
+		id, pErr := client.CreateSnapshot(vm.Ref, name)
+		if pErr != nil {
+			failed(pErr)
+			return
+		}
+		if step.Annotations == nil {
+			step.Annotations = map[string]string{}
+		}
+		step.Annotations[annSnapshot] = id
+		r.Log.Info(
+			"Source VM snapshot created.",
+			"vm",
+			vm.String(),
+			"snapshot",
+			id)
+	}
+	ready, pErr := client.SnapshotReady(vm.Ref, step.Annotations[annSnapshot])
+	if pErr != nil {
+		failed(pErr)
+		return
+	}
+	if ready {
+		step.Progress.Completed = step.Progress.Total
+		step.MarkCompleted()
+	}
+
+	return
+}
+
+//
+// Remove the source VM snapshot.
+// The snapshot is retained when the verification has failed.
+// The removal (and disk consolidation) is polled. The VM has
+// been migrated so failures are reported by a (warning)
+// condition rather than failing the VM.
+func (r *Migration) removeSnapshot(vm *plan.VMStatus, step *plan.Step) (err error) {
+	client, err := r.sourceClient()
+	if err != nil {
+		return
+	}
+	completed := func() {
+		step.Progress.Completed = step.Progress.Total
+		step.MarkCompleted()
+	}
+	failed := func(pErr error) {
+		vm.SetCondition(
+			libcnd.Condition{
+				Type:     SnapshotNotRemoved,
+				Status:   True,
+				Category: Warn,
+				Message:  "The source VM snapshot could not be removed.",
+				Items:    []string{liberr.Unwrap(pErr).Error()},
+				Durable:  true,
+			})
+		completed()
+	}
+	id := ""
+	if created, found := vm.FindStep(CreateSnapshot); found {
+		id = created.Annotations[annSnapshot]
+	}
+	if !step.MarkedStarted() {
+		step.MarkStarted()
+		if id == "" || vm.HasCondition(VerificationFailed) {
+			completed()
+			return
+		}
+		pErr := client.RemoveSnapshot(vm.Ref, id)
+		if pErr != nil {
+			failed(pErr)
+			return
+		}
+	}
+	removed, pErr := client.SnapshotRemoved(vm.Ref, id)
+	if pErr != nil {
+		failed(pErr)
+		return
+	}
+	if !removed {
+		if time.Since(step.Started.Time) > SnapshotRemovalTimeout {
+			failed(liberr.New("The snapshot removal has timed out."))
+		}
+		return
+	}
+	r.Log.Info(
+		"Source VM snapshot removed.",
+		"vm",
+		vm.String(),
+		"snapshot",
+		id)
+	completed()
+
+	return
+}
+
+//
+// Apply the source VM actions after migration.
+// The VM has been migrated so failed actions are reported
+// by a (warning) condition rather than failing the VM.
+func (r *Migration) updateSource(vm *plan.VMStatus, step *plan.Step) (err error) {
+	client, err := r.sourceClient()
+	if err != nil {
+		return
+	}
+	step.MarkStarted()
+	after := r.Plan.Spec.SourceVM.AfterMigration
+	vmRef := vm.Ref
+	failed := []string{}
+	defer func() {
+		if err != nil {
+			return
+		}
+		if len(failed) > 0 {
+			vm.SetCondition(
+				libcnd.Condition{
+					Type:     SourceNotUpdated,
+					Status:   True,
+					Category: Warn,
+					Message:  "The source VM could not be (fully) updated after migration.",
+					Items:    failed,
+					Durable:  true,
+				})
+		}
+		step.Progress.Completed = step.Progress.Total
+		step.MarkCompleted()
+	}()
+	_, pErr := r.Source.Inventory.VM(&vmRef)
+	if pErr != nil {
+		if errors.As(pErr, &web.ProviderNotReadyError{}) {
+			err = pErr
+		} else {
+			failed = append(failed, pErr.Error())
+		}
+		return
+	}
+	// The original state is recorded so the
+	// updates can be undone by the rollback.
+	annotate := func(key, value string) {
+		if step.Annotations == nil {
+			step.Annotations = map[string]string{}
+		}
+		step.Annotations[key] = value
+	}
+	actions := []func() error{}
+	if after.PowerOff {
+		actions = append(
+			actions,
+			func() error {
+				return client.PowerOff(vmRef)
+			})
+	}
+	if after.DisableAutostart {
+		actions = append(
+			actions,
+			func() error {
+				return client.DisableAutostart(vmRef)
+			})
+	}
+	if after.Tag != "" {
+		actions = append(
+			actions,
+			func() (err error) {
+				err = client.Tag(vmRef, after.Tag)
+				if err == nil {
+					annotate(annSourceTag, after.Tag)
+				}
+				return
+			})
+	}
+	if after.Folder != "" {
+		actions = append(
+			actions,
+			func() (err error) {
+				folder, err := client.Folder(vmRef)
+				if err != nil {
+					return
+				}
+				err = client.MoveToFolder(vmRef, after.Folder)
+				if err == nil {
+					annotate(annSourceFolder, folder)
+				}
+				return
+			})
+	}
+	if after.NameSuffix != "" && !strings.HasSuffix(vmRef.Name, after.NameSuffix) {
+		actions = append(
+			actions,
+			func() (err error) {
+				err = client.Rename(vmRef, vmRef.Name+after.NameSuffix)
+				if err == nil {
+					annotate(annSourceName, vmRef.Name)
+				}
+				return
+			})
+	}
+	for _, action := range actions {
+		pErr := action()
+		if pErr != nil {
+			failed = append(failed, liberr.Unwrap(pErr).Error())
+		}
+	}
+	r.Log.Info(
+		"Source VM actions applied.",
+		"vm",
+		vm.String())
+
+	return
+}
+
+//
+// Get the (lazily connected) source client.
+func (r *Migration) sourceClient() (client adapter.Client, err error) {
+	if r.client == nil {
+		adapter, nErr := adapter.New(r.Context.Source.Provider)
+		if nErr != nil {
+			err = nErr
+			return
+		}
+		r.client, err = adapter.Client(r.Context)
+		if err != nil {
+			err = liberr.Wrap(err)
+			return
+		}
+	}
+	client = r.client
+
+	return
+}
+
+//
+// Close the source client.
+func (r *Migration) closeClient() {
+	if r.client != nil {
+		r.client.Close()
+		r.client = nil
+	}
+}
+
+//
+// Find the import CR for a VM.
+func (r *Migration) findImport(vm *plan.VMStatus) (imp VmImport, found bool, err error) {
 	if r.importMap == nil {
 		r.importMap, err = r.kubevirt.ImportMap()
 		if err != nil {
@@ -577,9 +1353,19 @@ func (r *Migration) updateVM(vm *plan.VMStatus) (completed bool, failed bool, er
 			return
 		}
 	}
-	var imp VmImport
-	found := false
-	if imp, found = r.importMap[vm.ID]; !found {
+	imp, found = r.importMap[vm.ID]
+
+	return
+}
+
+//
+// Update VM migration status.
+func (r *Migration) updateVM(vm *plan.VMStatus) (completed bool, failed bool, err error) {
+	imp, found, err := r.findImport(vm)
+	if err != nil {
+		return
+	}
+	if !found {
 		msg := "Import CR not found."
 		vm.AddError(msg)
 		return
@@ -588,20 +1374,11 @@ func (r *Migration) updateVM(vm *plan.VMStatus) (completed bool, failed bool, er
 	conditions := imp.Conditions()
 	cnd := conditions.FindCondition(Succeeded)
 	if cnd != nil {
-		vm.MarkCompleted()
 		completed = true
 		if cnd.Status != True {
+			vm.MarkCompleted()
 			vm.AddError(cnd.Message)
 			failed = true
-		} else {
-			vm.SetCondition(
-				libcnd.Condition{
-					Type:     Succeeded,
-					Status:   True,
-					Category: Advisory,
-					Message:  "The VM migration has SUCCEEDED.",
-					Durable:  true,
-				})
 		}
 	} else {
 		cnd = conditions.FindCondition(string(vmio.Processing))
@@ -628,6 +1405,14 @@ func (r *Migration) updateVM(vm *plan.VMStatus) (completed bool, failed bool, er
 
 	if imp.Spec.Warm {
 		updateWarmStatus(vm, imp)
+		if r.Context.Migration.Spec.Paused(vm.Ref) {
+			err = r.holdPrecopy(vm, &imp)
+			return
+		}
+		err = r.warmPolicy(vm, &imp)
+		if err != nil {
+			return
+		}
 	}
 
 	return
@@ -662,6 +1447,178 @@ func updateWarmStatus(vm *plan.VMStatus, imp VmImport) {
 	}
 }
 
+//
+// Apply the warm migration settings.
+// The bytes changed are recorded for completed precopies, the
+// precopy interval is applied and the automatic cutover is
+// triggered as needed.
+func (r *Migration) warmPolicy(vm *plan.VMStatus, imp *VmImport) (err error) {
+	err = r.precopyBytes(vm, imp)
+	if err != nil {
+		return
+	}
+	policy := r.Plan.Spec.WarmPolicy
+	if policy == nil {
+		return
+	}
+	if policy.PrecopyInterval > 0 && len(vm.Warm.Precopies) > 0 {
+		next := imp.Status.WarmImport.NextStageTime
+		last := vm.Warm.Precopies[len(vm.Warm.Precopies)-1]
+		if next != nil && last.Start != nil {
+			interval := time.Duration(policy.PrecopyInterval) * time.Minute
+			wanted := meta.NewTime(last.Start.Add(interval))
+			if next.Sub(wanted.Time) > time.Second || wanted.Sub(next.Time) > time.Second {
+				err = r.kubevirt.SetNextPrecopy(imp, wanted)
+				if err != nil {
+					return
+				}
+			}
+		}
+	}
+	if vm.Warm.Cutover == nil && !r.Plan.Spec.Replicating() {
+		if reason, triggered := policy.Cutover(vm.Warm); triggered {
+			now := meta.Now()
+			vm.Warm.Cutover = &now
+			vm.Warm.CutoverReason = reason
+			r.Log.Info(
+				"Automatic cutover triggered.",
+				"vm",
+				vm.String(),
+				"reason",
+				reason)
+		}
+	}
+
+	return
+}
+
+//
+// Hold the next warm precopy of a paused VM.
+// The import does not support pausing so the next precopy
+// is deferred. A precopy already in progress is allowed
+// to finish. On resume, the deferral is either replaced by
+// the precopy interval (warm policy) or expires.
+func (r *Migration) holdPrecopy(vm *plan.VMStatus, imp *VmImport) (err error) {
+	now := time.Now()
+	next := imp.Status.WarmImport.NextStageTime
+	if next != nil && next.After(now.Add(PrecopyHold/2)) {
+		return
+	}
+	err = r.kubevirt.SetNextPrecopy(imp, meta.NewTime(now.Add(PrecopyHold)))
+	if err != nil {
+		return
+	}
+
+	r.Log.Info(
+		"Next precopy held.",
+		"vm",
+		vm.String())
+
+	return
+}
+
+//
+// Record the precopy snapshots and the bytes changed by
+// completed precopies. The precopies are matched to the
+// DataVolume checkpoints by snapshot ID. The disk change IDs
+// of the snapshot are recorded when the precopy starts since
+// the snapshot may have been removed by the time the bytes
+// changed by the next precopy are determined. The provider is
+// not contacted once the change IDs and the bytes changed by
+// the completed precopies have been recorded.
+func (r *Migration) precopyBytes(vm *plan.VMStatus, imp *VmImport) (err error) {
+	if len(imp.DataVolumes) == 0 || len(vm.Warm.Precopies) == 0 {
+		return
+	}
+	matched := map[string]bool{}
+	for _, precopy := range vm.Warm.Precopies {
+		if precopy.Snapshot != "" {
+			matched[precopy.Snapshot] = true
+		}
+	}
+	checkpoints := imp.DataVolumes[0].Spec.Checkpoints
+	current := &vm.Warm.Precopies[len(vm.Warm.Precopies)-1]
+	if current.Snapshot == "" && len(checkpoints) > 0 {
+		snapshot := checkpoints[len(checkpoints)-1].Current
+		if !matched[snapshot] {
+			current.Snapshot = snapshot
+		}
+	}
+	if !r.precopyPending(vm) {
+		return
+	}
+	client, err := r.sourceClient()
+	if err != nil {
+		return
+	}
+	if current.Snapshot != "" && current.ChangeIds == nil {
+		changeIds, supported, cErr := client.ChangeIds(vm.Ref, current.Snapshot)
+		if cErr != nil {
+			r.Log.Info(
+				"Precopy change IDs not recorded.",
+				"vm",
+				vm.String(),
+				"reason",
+				cErr.Error())
+		} else if supported {
+			current.ChangeIds = changeIds
+		}
+	}
+	for i := range vm.Warm.Precopies {
+		precopy := &vm.Warm.Precopies[i]
+		if precopy.End == nil || precopy.Bytes != nil || precopy.Snapshot == "" {
+			continue
+		}
+		var changeIds map[string]string
+		if i > 0 {
+			changeIds = vm.Warm.Precopies[i-1].ChangeIds
+			if len(changeIds) == 0 {
+				continue
+			}
+		}
+		bytes, supported, bErr := client.ChangedBytes(
+			vm.Ref,
+			changeIds,
+			precopy.Snapshot)
+		if bErr != nil {
+			r.Log.Info(
+				"Precopy bytes changed not determined.",
+				"vm",
+				vm.String(),
+				"reason",
+				bErr.Error())
+			return
+		}
+		if !supported {
+			return
+		}
+		precopy.Bytes = &bytes
+	}
+
+	return
+}
+
+//
+// Determine whether the change IDs of the current precopy
+// or the bytes changed by a completed precopy need to be
+// recorded.
+func (r *Migration) precopyPending(vm *plan.VMStatus) bool {
+	current := vm.Warm.Precopies[len(vm.Warm.Precopies)-1]
+	if current.Snapshot != "" && current.ChangeIds == nil {
+		return true
+	}
+	for i, precopy := range vm.Warm.Precopies {
+		if precopy.End == nil || precopy.Bytes != nil || precopy.Snapshot == "" {
+			continue
+		}
+		if i == 0 || len(vm.Warm.Precopies[i-1].ChangeIds) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
 //
 // Update the pipeline.
 func (r *Migration) updatePipeline(vm *plan.VMStatus, imp *VmImport) {
@@ -752,6 +1709,8 @@ func (r *Migration) updatePipeline(vm *plan.VMStatus, imp *VmImport) {
 //
 // Step predicate.
 type Predicate struct {
+	// Plan.
+	plan *api.Plan
 	// VM listed on the plan.
 	vm *plan.VM
 }
@@ -759,14 +1718,23 @@ type Predicate struct {
 //
 // Evaluate predicate flags.
 func (r *Predicate) Evaluate(flag libitr.Flag) (allowed bool, err error) {
-	if len(r.vm.Hooks) == 0 {
-		return
-	}
 	switch flag {
 	case HasPreHook:
 		_, allowed = r.vm.FindHook(PreHook)
 	case HasPostHook:
 		_, allowed = r.vm.FindHook(PostHook)
+	case HasStaticIP:
+		allowed = r.plan.Spec.StaticIPs != nil
+	case HasShutdown:
+		allowed = r.plan.Spec.ShutdownSource()
+	case HasUpdate:
+		allowed = r.plan.Spec.UpdateSource()
+	case HasVerify:
+		allowed = r.plan.Spec.Verify != nil
+	case HasSnapshot:
+		allowed = r.plan.Spec.SnapshotSource()
+	case HasRemoval:
+		allowed = r.plan.Spec.RemoveSnapshot()
 	}
 
 	return
@@ -0,0 +1,241 @@
+package plan
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	liberr "github.com/konveyor/controller/pkg/error"
+	api "github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1"
+	"github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1/plan"
+	"github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1/ref"
+	"github.com/konveyor/forklift-controller/pkg/controller/plan/adapter"
+	"github.com/konveyor/forklift-controller/pkg/controller/provider/web"
+	"path"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+//
+// Name limits.
+const (
+	// DNS-1123 label.
+	MaxNameLength = 63
+	// Hash suffix length.
+	NameHashLength = 8
+)
+
+//
+// Characters not valid in a DNS-1123 label.
+var nameNotValidPattern = regexp.MustCompile(`[^a-z0-9-]+`)
+
+//
+// Data available to the naming template.
+type NameData struct {
+	// Source VM name.
+	Name string
+	// Source VM ID.
+	ID string
+	// Folder (vSphere) or cluster (oVirt) name.
+	Folder string
+	// Plan name.
+	Plan string
+	// Target namespace.
+	Namespace string
+}
+
+//
+// Parse the naming template.
+func NameTemplate(naming *plan.Naming) (tmpl *template.Template, err error) {
+	text := "{{.Name}}"
+	if naming != nil && naming.Template != "" {
+		text = naming.Template
+	}
+	tmpl, err = template.New("name").Parse(text)
+	if err != nil {
+		err = liberr.Wrap(err)
+	}
+
+	return
+}
+
+//
+// Sanitize a name as a DNS-1123 label.
+// The name is lowercased, invalid characters are replaced
+// by `-` and names that are too long are truncated with a
+// hash (of the original name) suffix.
+func SanitizeName(name string) (sanitized string) {
+	sanitized = strings.ToLower(name)
+	sanitized = nameNotValidPattern.ReplaceAllString(sanitized, "-")
+	sanitized = strings.Trim(sanitized, "-")
+	if sanitized == "" {
+		sanitized = "vm"
+	}
+	if len(sanitized) > MaxNameLength {
+		sum := sha256.Sum256([]byte(name))
+		hash := fmt.Sprintf("%x", sum)[:NameHashLength]
+		sanitized = truncateName(sanitized, len(hash)+1) + "-" + hash
+	}
+
+	return
+}
+
+//
+// Truncate a name to leave room for a suffix
+// of the specified length.
+func truncateName(name string, suffix int) string {
+	if len(name)+suffix > MaxNameLength {
+		name = name[:MaxNameLength-suffix]
+	}
+
+	return strings.TrimRight(name, "-")
+}
+
+//
+// Resolves target VM names.
+// Precedence:
+//   - The name assigned when the migration was started.
+//   - The name specified on the plan VM.
+//   - The name rendered by the plan naming policy.
+type Namer struct {
+	// Plan.
+	Plan *api.Plan
+	// Source provider validator.
+	Validator adapter.Validator
+	// Source inventory.
+	Source web.Client
+	// Destination inventory.
+	// Collisions with existing VMs are not
+	// resolved when not set.
+	Destination web.Client
+	// Names assigned.
+	assigned map[string]bool
+}
+
+//
+// Resolve the target name for a VM.
+func (r *Namer) Name(vm *plan.VM) (name string, err error) {
+	if r.assigned == nil {
+		r.assigned = map[string]bool{}
+	}
+	defer func() {
+		if err == nil {
+			r.assigned[name] = true
+		}
+	}()
+	if status, found := r.Plan.Status.Migration.FindVM(vm.Ref); found {
+		if status.TargetVMName != "" {
+			name = status.TargetVMName
+			return
+		}
+	}
+	if vm.TargetName != "" {
+		name = vm.TargetName
+		return
+	}
+	vmRef := vm.Ref
+	if vmRef.Name == "" || vmRef.ID == "" {
+		_, err = r.Source.VM(&vmRef)
+		if err != nil {
+			err = liberr.Wrap(err)
+			return
+		}
+	}
+	naming := r.Plan.Spec.Naming
+	if naming == nil {
+		name = vmRef.Name
+		return
+	}
+	name, err = r.render(naming, vmRef)
+	if err != nil {
+		return
+	}
+	if naming.Sanitize {
+		name = SanitizeName(name)
+	}
+	if naming.Unique {
+		name, err = r.unique(name)
+	}
+
+	return
+}
+
+//
+// Render the naming template.
+func (r *Namer) render(naming *plan.Naming, vmRef ref.Ref) (name string, err error) {
+	tmpl, err := NameTemplate(naming)
+	if err != nil {
+		return
+	}
+	data := NameData{
+		Name:      vmRef.Name,
+		ID:        vmRef.ID,
+		Plan:      r.Plan.Name,
+		Namespace: r.Plan.Spec.TargetNamespace,
+	}
+	if strings.Contains(naming.Template, ".Folder") {
+		data.Folder, err = r.Validator.Folder(vmRef)
+		if err != nil {
+			return
+		}
+	}
+	content := &bytes.Buffer{}
+	err = tmpl.Execute(content, data)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+
+	name = content.String()
+	return
+}
+
+//
+// Resolve collisions with the names already assigned
+// and the VMs in the target namespace by appending
+// a numeric suffix.
+func (r *Namer) unique(name string) (unique string, err error) {
+	unique = name
+	for n := 1; ; n++ {
+		taken := r.assigned[unique]
+		if !taken {
+			taken, err = r.exists(unique)
+			if err != nil {
+				return
+			}
+		}
+		if !taken {
+			break
+		}
+		suffix := fmt.Sprintf("-%d", n)
+		unique = truncateName(name, len(suffix)) + suffix
+	}
+
+	return
+}
+
+//
+// Determine whether a VM exists in the target namespace.
+func (r *Namer) exists(name string) (found bool, err error) {
+	if r.Destination == nil {
+		return
+	}
+	_, err = r.Destination.VM(
+		&ref.Ref{
+			Name: path.Join(
+				r.Plan.Spec.TargetNamespace,
+				name),
+		})
+	if err == nil {
+		found = true
+		return
+	}
+	if errors.As(err, &web.NotFoundError{}) {
+		err = nil
+	} else {
+		err = liberr.Wrap(err)
+	}
+
+	return
+}
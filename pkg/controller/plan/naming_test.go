@@ -0,0 +1,107 @@
+package plan
+
+import (
+	"github.com/onsi/gomega"
+	"strings"
+	"testing"
+)
+
+func TestSanitizeName(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	cases := []struct {
+		name     string
+		in       string
+		expected string
+	}{
+		{
+			name:     "valid",
+			in:       "my-vm-1",
+			expected: "my-vm-1",
+		},
+		{
+			name:     "uppercase",
+			in:       "My-VM",
+			expected: "my-vm",
+		},
+		{
+			name:     "invalid characters",
+			in:       "my_vm (copy).example.com",
+			expected: "my-vm-copy-example-com",
+		},
+		{
+			name:     "leading and trailing",
+			in:       "_my vm!",
+			expected: "my-vm",
+		},
+		{
+			name:     "no valid characters",
+			in:       "___",
+			expected: "vm",
+		},
+		{
+			name:     "max length",
+			in:       strings.Repeat("a", MaxNameLength),
+			expected: strings.Repeat("a", MaxNameLength),
+		},
+		{
+			name:     "too long",
+			in:       strings.Repeat("A", 70),
+			expected: strings.Repeat("a", 54) + "-01d3a187",
+		},
+		{
+			name:     "too long truncated at separator",
+			in:       strings.Repeat("a", 53) + "-" + strings.Repeat("b", 20),
+			expected: strings.Repeat("a", 53) + "-af3ec493",
+		},
+	}
+	for _, c := range cases {
+		sanitized := SanitizeName(c.in)
+		g.Expect(sanitized).To(gomega.Equal(c.expected), c.name)
+		g.Expect(len(sanitized) <= MaxNameLength).To(gomega.BeTrue(), c.name)
+	}
+}
+
+func TestTruncateName(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	cases := []struct {
+		name     string
+		in       string
+		suffix   int
+		expected string
+	}{
+		{
+			name:     "fits",
+			in:       "my-vm",
+			suffix:   9,
+			expected: "my-vm",
+		},
+		{
+			name:     "exact fit",
+			in:       strings.Repeat("a", 54),
+			suffix:   9,
+			expected: strings.Repeat("a", 54),
+		},
+		{
+			name:     "truncated",
+			in:       strings.Repeat("a", 60),
+			suffix:   9,
+			expected: strings.Repeat("a", 54),
+		},
+		{
+			name:     "trailing separator trimmed",
+			in:       strings.Repeat("a", 53) + "-" + strings.Repeat("b", 10),
+			suffix:   9,
+			expected: strings.Repeat("a", 53),
+		},
+		{
+			name:     "no suffix",
+			in:       strings.Repeat("a", 70),
+			expected: strings.Repeat("a", MaxNameLength),
+		},
+	}
+	for _, c := range cases {
+		g.Expect(truncateName(c.in, c.suffix)).To(gomega.Equal(c.expected), c.name)
+	}
+}
@@ -301,3 +301,114 @@ func RequestForMigration(a k8shandler.MapObject) (list []reconcile.Request) {
 
 	return
 }
+
+type RollbackPredicate struct {
+	predicate.Funcs
+}
+
+func (r RollbackPredicate) Create(e event.CreateEvent) bool {
+	object, cast := e.Object.(*api.Rollback)
+	if !cast {
+		return false
+	}
+	pending := !object.Status.MarkedCompleted()
+	return pending
+}
+
+func (r RollbackPredicate) Update(e event.UpdateEvent) bool {
+	old, cast := e.ObjectOld.(*api.Rollback)
+	if !cast {
+		return false
+	}
+	new, cast := e.ObjectNew.(*api.Rollback)
+	if !cast {
+		return false
+	}
+	changed := old.Generation != new.Generation
+	return changed
+}
+
+func (r RollbackPredicate) Delete(e event.DeleteEvent) bool {
+	return false
+}
+
+func (r RollbackPredicate) Generic(e event.GenericEvent) bool {
+	return false
+}
+
+//
+// Plan request for Rollback.
+func RequestForRollback(a k8shandler.MapObject) (list []reconcile.Request) {
+	if m, cast := a.Object.(*api.Rollback); cast {
+		ref := &m.Spec.Plan
+		if !libref.RefSet(ref) {
+			return
+		}
+		list = append(
+			list,
+			reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Namespace: ref.Namespace,
+					Name:      ref.Name,
+				},
+			})
+	}
+
+	return
+}
+
+type TestFailoverPredicate struct {
+	predicate.Funcs
+}
+
+func (r TestFailoverPredicate) Create(e event.CreateEvent) bool {
+	object, cast := e.Object.(*api.TestFailover)
+	if !cast {
+		return false
+	}
+	pending := !object.Status.MarkedCompleted()
+	return pending
+}
+
+func (r TestFailoverPredicate) Update(e event.UpdateEvent) bool {
+	old, cast := e.ObjectOld.(*api.TestFailover)
+	if !cast {
+		return false
+	}
+	new, cast := e.ObjectNew.(*api.TestFailover)
+	if !cast {
+		return false
+	}
+	changed := old.Generation != new.Generation
+	return changed
+}
+
+func (r TestFailoverPredicate) Delete(e event.DeleteEvent) bool {
+	_, cast := e.Object.(*api.TestFailover)
+	return cast
+}
+
+func (r TestFailoverPredicate) Generic(e event.GenericEvent) bool {
+	return false
+}
+
+//
+// Plan request for TestFailover.
+func RequestForTestFailover(a k8shandler.MapObject) (list []reconcile.Request) {
+	if m, cast := a.Object.(*api.TestFailover); cast {
+		ref := &m.Spec.Plan
+		if !libref.RefSet(ref) {
+			return
+		}
+		list = append(
+			list,
+			reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Namespace: ref.Namespace,
+					Name:      ref.Name,
+				},
+			})
+	}
+
+	return
+}
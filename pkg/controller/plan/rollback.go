@@ -0,0 +1,195 @@
+package plan
+
+import (
+	"errors"
+	libcnd "github.com/konveyor/controller/pkg/condition"
+	liberr "github.com/konveyor/controller/pkg/error"
+	api "github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1"
+	"github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1/plan"
+	"github.com/konveyor/forklift-controller/pkg/controller/plan/adapter"
+	plancontext "github.com/konveyor/forklift-controller/pkg/controller/plan/context"
+	"github.com/konveyor/forklift-controller/pkg/controller/provider/web"
+)
+
+//
+// Rollback of migrated VMs.
+type Rollback struct {
+	*plancontext.Context
+	// kubevirt.
+	kubevirt KubeVirt
+	// Source client.
+	client adapter.Client
+}
+
+//
+// Run the rollback.
+// For each VM: the updates to the source VM after migration are
+// undone, the destination VM is stopped and deleted (or preserved)
+// and the source VM is powered on. The outcome is recorded on the
+// VM status and the Rollback status. When a prior attempt failed
+// after the destination was rolled back, it is not rolled back again.
+func (r *Rollback) Run(rollback *api.Rollback) (err error) {
+	err = r.init()
+	if err != nil {
+		return
+	}
+	defer r.client.Close()
+	rollback.Status.MarkStarted()
+	notMigrated := []string{}
+	failed := []string{}
+	for i := range rollback.Spec.VMs {
+		ref := &rollback.Spec.VMs[i]
+		_, err = r.Source.Inventory.VM(ref)
+		if err != nil {
+			if errors.As(err, &web.NotFoundError{}) ||
+				errors.As(err, &web.RefNotUniqueError{}) {
+				notMigrated = append(notMigrated, ref.String())
+				err = nil
+				continue
+			}
+			return
+		}
+		vm, found := r.Plan.Status.Migration.FindVM(*ref)
+		if !found || !vm.HasCondition(Succeeded) || vm.HasCondition(RolledBack) {
+			notMigrated = append(notMigrated, ref.String())
+			continue
+		}
+		destination := false
+		if cnd := vm.FindCondition(RollbackFailed); cnd != nil {
+			destination = cnd.Reason == RolledBackTarget
+		}
+		vm.DeleteCondition(RollbackFailed)
+		rErr := r.restoreSource(vm)
+		if rErr == nil && !destination {
+			rErr = r.kubevirt.RollbackVM(vm, rollback.Spec.Preserve)
+			destination = rErr == nil
+		}
+		if rErr == nil {
+			rErr = r.client.PowerOn(vm.Ref)
+		}
+		if rErr != nil {
+			reason := UserRequested
+			if destination {
+				reason = RolledBackTarget
+			}
+			r.Log.Error(
+				rErr,
+				"Rollback failed.",
+				"vm",
+				vm.String())
+			vm.SetCondition(
+				libcnd.Condition{
+					Type:     RollbackFailed,
+					Status:   True,
+					Category: Advisory,
+					Reason:   reason,
+					Message:  liberr.Unwrap(rErr).Error(),
+					Durable:  true,
+				})
+			failed = append(failed, ref.String())
+			continue
+		}
+		vm.SetCondition(
+			libcnd.Condition{
+				Type:     RolledBack,
+				Status:   True,
+				Category: Advisory,
+				Reason:   UserRequested,
+				Message:  "The VM migration has been ROLLED BACK.",
+				Durable:  true,
+			})
+		r.Log.Info(
+			"Rollback [COMPLETED]",
+			"vm",
+			vm.String())
+	}
+	rollback.Status.MarkCompleted()
+	if len(notMigrated) > 0 {
+		rollback.Status.SetCondition(
+			libcnd.Condition{
+				Type:     VMNotMigrated,
+				Status:   True,
+				Category: Warn,
+				Reason:   NotFound,
+				Message:  "VM not found or not successfully migrated.",
+				Items:    notMigrated,
+				Durable:  true,
+			})
+	}
+	if len(failed) > 0 {
+		rollback.Status.SetCondition(
+			libcnd.Condition{
+				Type:     Failed,
+				Status:   True,
+				Category: Advisory,
+				Message:  "The rollback has FAILED.",
+				Items:    failed,
+				Durable:  true,
+			})
+	} else {
+		rollback.Status.SetCondition(
+			libcnd.Condition{
+				Type:     Succeeded,
+				Status:   True,
+				Category: Advisory,
+				Message:  "The rollback has SUCCEEDED.",
+				Durable:  true,
+			})
+	}
+
+	return
+}
+
+//
+// Undo the updates to the source VM after migration.
+// The tag is detached, the VM is moved back into the original
+// folder and renamed. Each update undone is removed from the
+// step annotations so the rollback can be retried.
+func (r *Rollback) restoreSource(vm *plan.VMStatus) (err error) {
+	step, found := vm.FindStep(UpdateSource)
+	if !found {
+		return
+	}
+	if tag, found := step.Annotations[annSourceTag]; found {
+		err = r.client.Untag(vm.Ref, tag)
+		if err != nil {
+			return
+		}
+		delete(step.Annotations, annSourceTag)
+	}
+	if folder, found := step.Annotations[annSourceFolder]; found {
+		err = r.client.MoveToFolder(vm.Ref, folder)
+		if err != nil {
+			return
+		}
+		delete(step.Annotations, annSourceFolder)
+	}
+	if name, found := step.Annotations[annSourceName]; found {
+		err = r.client.Rename(vm.Ref, name)
+		if err != nil {
+			return
+		}
+		delete(step.Annotations, annSourceName)
+	}
+
+	return
+}
+
+//
+// Get/Build resources.
+func (r *Rollback) init() (err error) {
+	adapter, err := adapter.New(r.Context.Source.Provider)
+	if err != nil {
+		return
+	}
+	r.client, err = adapter.Client(r.Context)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	r.kubevirt = KubeVirt{
+		Context: r.Context,
+	}
+
+	return
+}
@@ -0,0 +1,167 @@
+package fairshare
+
+import (
+	api "github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1"
+	"github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1/plan"
+	"github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1/ref"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sync"
+)
+
+//
+// Shared ledger.
+// Updated by the plan controller watch events.
+var Shared = NewLedger()
+
+//
+// Executing plan recorded in the ledger.
+type Entry struct {
+	// Plan UID.
+	UID types.UID
+	// Plan priority.
+	Priority int
+	// Source provider.
+	Source core.ObjectReference
+	// Active migration.
+	Migration types.UID
+	// VM status for the active migration.
+	VMs []*plan.VMStatus
+	// Active migration spec.
+	spec *api.MigrationSpec
+}
+
+//
+// Determine whether a VM migration is paused.
+func (r *Entry) Paused(vmRef ref.Ref) bool {
+	return r.spec != nil && r.spec.Paused(vmRef)
+}
+
+//
+// Capacity ledger.
+// Records the VM migrations of executing plans as reported
+// by (plan, VMMigration and Migration) watch events so the
+// schedulers need not list the plans (and VM migrations)
+// each time the next VM is scheduled.
+type Ledger struct {
+	mutex sync.RWMutex
+	// Executing plans keyed by UID.
+	plans map[types.UID]*Entry
+	// VM status keyed by plan UID and VMMigration name.
+	vms map[types.UID]map[string]*api.VMMigration
+	// Migration spec keyed by UID.
+	migrations map[types.UID]*api.MigrationSpec
+}
+
+//
+// New ledger.
+func NewLedger() *Ledger {
+	return &Ledger{
+		plans:      map[types.UID]*Entry{},
+		vms:        map[types.UID]map[string]*api.VMMigration{},
+		migrations: map[types.UID]*api.MigrationSpec{},
+	}
+}
+
+//
+// The executing plans for a source provider.
+func (r *Ledger) Plans(source core.ObjectReference) (list []*Entry) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	for _, p := range r.plans {
+		if p.Source != source {
+			continue
+		}
+		entry := &Entry{
+			UID:       p.UID,
+			Priority:  p.Priority,
+			Source:    p.Source,
+			Migration: p.Migration,
+			spec:      r.migrations[p.Migration],
+		}
+		for _, object := range r.vms[p.UID] {
+			if object.Spec.Migration.UID == p.Migration {
+				entry.VMs = append(entry.VMs, &object.Status)
+			}
+		}
+		list = append(list, entry)
+	}
+
+	return
+}
+
+//
+// Record a created or updated resource.
+func (r *Ledger) Updated(object runtime.Object) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	switch object := object.(type) {
+	case *api.Plan:
+		snapshot := object.Status.Migration.ActiveSnapshot()
+		if !snapshot.HasCondition("Executing") {
+			delete(r.plans, object.UID)
+			return
+		}
+		r.plans[object.UID] = &Entry{
+			UID:       object.UID,
+			Priority:  object.Spec.Priority,
+			Source:    object.Spec.Provider.Source,
+			Migration: snapshot.Migration.UID,
+		}
+	case *api.VMMigration:
+		vms, found := r.vms[object.Spec.Plan.UID]
+		if !found {
+			vms = map[string]*api.VMMigration{}
+			r.vms[object.Spec.Plan.UID] = vms
+		}
+		vms[object.Name] = object.DeepCopy()
+	case *api.Migration:
+		r.migrations[object.UID] = object.Spec.DeepCopy()
+	}
+}
+
+//
+// Record a deleted resource.
+func (r *Ledger) Deleted(object runtime.Object) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	switch object := object.(type) {
+	case *api.Plan:
+		delete(r.plans, object.UID)
+		delete(r.vms, object.UID)
+	case *api.VMMigration:
+		if vms, found := r.vms[object.Spec.Plan.UID]; found {
+			delete(vms, object.Name)
+			if len(vms) == 0 {
+				delete(r.vms, object.Spec.Plan.UID)
+			}
+		}
+	case *api.Migration:
+		delete(r.migrations, object.UID)
+	}
+}
+
+//
+// Watch event handler.
+// Events are recorded in the ledger and never queued.
+type Handler struct {
+	Ledger *Ledger
+}
+
+func (r *Handler) Create(e event.CreateEvent, _ workqueue.RateLimitingInterface) {
+	r.Ledger.Updated(e.Object)
+}
+
+func (r *Handler) Update(e event.UpdateEvent, _ workqueue.RateLimitingInterface) {
+	r.Ledger.Updated(e.ObjectNew)
+}
+
+func (r *Handler) Delete(e event.DeleteEvent, _ workqueue.RateLimitingInterface) {
+	r.Ledger.Deleted(e.Object)
+}
+
+func (r *Handler) Generic(event.GenericEvent, workqueue.RateLimitingInterface) {
+}
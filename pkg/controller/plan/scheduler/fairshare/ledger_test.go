@@ -0,0 +1,73 @@
+package fairshare
+
+import (
+	libcnd "github.com/konveyor/controller/pkg/condition"
+	api "github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1"
+	"github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1/plan"
+	"github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1/ref"
+	"github.com/onsi/gomega"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"testing"
+)
+
+func TestLedger(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	source := core.ObjectReference{Namespace: "test", Name: "vsphere"}
+	p := &api.Plan{}
+	p.UID = "plan"
+	p.Spec.Priority = 2
+	p.Spec.Provider.Source = source
+	migration := &api.Migration{}
+	migration.UID = "migration"
+	migration.Spec.PauseVMs = []ref.Ref{{ID: "vm-1"}}
+	vm := func(name, migration string) *api.VMMigration {
+		object := &api.VMMigration{}
+		object.Name = name
+		object.Spec.Plan.UID = p.UID
+		object.Spec.Migration.UID = types.UID(migration)
+		object.Status.ID = name
+		return object
+	}
+	ledger := NewLedger()
+
+	// Plans are recorded only while executing.
+	ledger.Updated(p)
+	g.Expect(ledger.Plans(source)).To(gomega.BeEmpty())
+	snapshot := plan.Snapshot{}
+	snapshot.Migration.UID = migration.UID
+	snapshot.SetCondition(libcnd.Condition{Type: "Executing", Status: libcnd.True})
+	p.Status.Migration.NewSnapshot(snapshot)
+	ledger.Updated(p)
+	ledger.Updated(migration)
+	ledger.Updated(vm("vm-1", "migration"))
+	ledger.Updated(vm("vm-2", "migration"))
+	ledger.Updated(vm("vm-3", "other"))
+	list := ledger.Plans(source)
+	g.Expect(list).To(gomega.HaveLen(1))
+	g.Expect(list[0].UID).To(gomega.Equal(p.UID))
+	g.Expect(list[0].Priority).To(gomega.Equal(2))
+	g.Expect(list[0].Paused(ref.Ref{ID: "vm-1"})).To(gomega.BeTrue())
+	g.Expect(list[0].Paused(ref.Ref{ID: "vm-2"})).To(gomega.BeFalse())
+
+	// Only the VMs of the active migration are listed.
+	g.Expect(list[0].VMs).To(gomega.HaveLen(2))
+
+	// Other source providers.
+	g.Expect(ledger.Plans(core.ObjectReference{Name: "ovirt"})).To(gomega.BeEmpty())
+
+	// Deleted VM migrations.
+	ledger.Deleted(vm("vm-2", "migration"))
+	g.Expect(ledger.Plans(source)[0].VMs).To(gomega.HaveLen(1))
+
+	// Plans no longer executing.
+	p.Status.Migration.History[0].DeleteCondition("Executing")
+	ledger.Updated(p)
+	g.Expect(ledger.Plans(source)).To(gomega.BeEmpty())
+
+	// Deleted plans.
+	ledger.Updated(p)
+	ledger.Deleted(p)
+	g.Expect(ledger.vms).To(gomega.BeEmpty())
+}
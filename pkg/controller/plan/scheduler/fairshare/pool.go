@@ -0,0 +1,124 @@
+package fairshare
+
+import (
+	api "github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+//
+// Plan demand on a shared capacity pool.
+type Demand struct {
+	// Plan priority.
+	Priority int
+	// Capacity used by running VM migrations.
+	InFlight int
+	// Cost of each pending VM migration.
+	Pending []int
+}
+
+//
+// Determine whether a pending VM fits the free capacity.
+func (r *Demand) fits(free int) bool {
+	for _, cost := range r.Pending {
+		if cost <= free {
+			return true
+		}
+	}
+
+	return false
+}
+
+//
+// Shared capacity pool.
+// A host (vSphere) or provider (oVirt) shared
+// by all of the executing plans for a provider.
+type Pool struct {
+	// Total capacity.
+	Capacity int
+	// Demand by plan UID.
+	Demand map[types.UID]*Demand
+}
+
+//
+// New pool.
+func New(capacity int) *Pool {
+	return &Pool{
+		Capacity: capacity,
+		Demand:   map[types.UID]*Demand{},
+	}
+}
+
+//
+// Find (or add) the demand for a plan.
+func (r *Pool) Plan(plan *api.Plan) (d *Demand) {
+	d = r.Add(plan.UID, plan.Spec.Priority)
+	return
+}
+
+//
+// Find (or add) the demand for a plan by UID.
+func (r *Pool) Add(uid types.UID, priority int) (d *Demand) {
+	d, found := r.Demand[uid]
+	if !found {
+		d = &Demand{Priority: priority}
+		r.Demand[uid] = d
+	}
+
+	return
+}
+
+//
+// Total capacity used by running VM migrations.
+func (r *Pool) InFlight() (n int) {
+	for _, d := range r.Demand {
+		n += d.InFlight
+	}
+
+	return
+}
+
+//
+// Determine whether a plan may start a VM migration
+// with the specified cost.
+// The VM must fit the free capacity. New starts are
+// deferred while a higher priority plan has a pending
+// VM that fits. Plans with equal priority are allotted
+// an equal share of the capacity. A plan using its share
+// may only start another VM when none of its peers is
+// using less than its share.
+func (r *Pool) Allowed(uid types.UID, cost int) bool {
+	self, found := r.Demand[uid]
+	if !found {
+		return true
+	}
+	free := r.Capacity - r.InFlight()
+	if cost > free {
+		return false
+	}
+	peers := []*Demand{}
+	for id, d := range r.Demand {
+		if id == uid || !d.fits(free) {
+			continue
+		}
+		if d.Priority > self.Priority {
+			return false
+		}
+		if d.Priority == self.Priority {
+			peers = append(peers, d)
+		}
+	}
+	share := r.Capacity / (len(peers) + 1)
+	if share < 1 {
+		share = 1
+	}
+	if self.InFlight < share {
+		return true
+	}
+	for _, d := range peers {
+		if d.InFlight < share {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,55 @@
+package fairshare
+
+import (
+	"github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+	"testing"
+)
+
+func TestPool(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	planA := types.UID("planA")
+	planB := types.UID("planB")
+	planC := types.UID("planC")
+
+	// Unknown plans are not constrained.
+	pool := New(10)
+	g.Expect(pool.Allowed(planA, 4)).To(gomega.BeTrue())
+
+	// The VM must fit the free capacity.
+	pool.Demand[planA] = &Demand{InFlight: 8, Pending: []int{3}}
+	g.Expect(pool.Allowed(planA, 3)).To(gomega.BeFalse())
+	g.Expect(pool.Allowed(planA, 2)).To(gomega.BeTrue())
+
+	// A higher priority plan with a pending VM
+	// that fits preempts new starts.
+	pool = New(10)
+	pool.Demand[planA] = &Demand{Priority: 0, Pending: []int{1}}
+	pool.Demand[planB] = &Demand{Priority: 1, Pending: []int{2}}
+	g.Expect(pool.Allowed(planA, 1)).To(gomega.BeFalse())
+	g.Expect(pool.Allowed(planB, 2)).To(gomega.BeTrue())
+
+	// Unless the pending VM does not fit.
+	pool.Demand[planB].Pending = []int{11}
+	g.Expect(pool.Allowed(planA, 1)).To(gomega.BeTrue())
+
+	// Equal priority plans share the capacity.
+	pool = New(10)
+	pool.Demand[planA] = &Demand{InFlight: 5, Pending: []int{1}}
+	pool.Demand[planB] = &Demand{InFlight: 1, Pending: []int{1}}
+	g.Expect(pool.Allowed(planA, 1)).To(gomega.BeFalse())
+	g.Expect(pool.Allowed(planB, 1)).To(gomega.BeTrue())
+
+	// Unused shares may be used by peers.
+	pool.Demand[planB].Pending = nil
+	g.Expect(pool.Allowed(planA, 1)).To(gomega.BeTrue())
+
+	// Shares are recalculated as plans compete.
+	pool = New(9)
+	pool.Demand[planA] = &Demand{InFlight: 3, Pending: []int{1}}
+	pool.Demand[planB] = &Demand{InFlight: 3, Pending: []int{1}}
+	pool.Demand[planC] = &Demand{InFlight: 2, Pending: []int{1}}
+	g.Expect(pool.Allowed(planA, 1)).To(gomega.BeFalse())
+	g.Expect(pool.Allowed(planC, 1)).To(gomega.BeTrue())
+}
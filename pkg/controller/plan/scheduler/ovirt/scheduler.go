@@ -1,11 +1,10 @@
 package ovirt
 
 import (
-	"context"
-	liberr "github.com/konveyor/controller/pkg/error"
-	api "github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1"
 	"github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1/plan"
+	"github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1/ref"
 	plancontext "github.com/konveyor/forklift-controller/pkg/controller/plan/context"
+	"github.com/konveyor/forklift-controller/pkg/controller/plan/scheduler/fairshare"
 	"sync"
 )
 
@@ -28,38 +27,29 @@ func (r *Scheduler) Next() (vm *plan.VMStatus, hasNext bool, err error) {
 	mutex.Lock()
 	defer mutex.Unlock()
 
-	planList := &api.PlanList{}
-	err = r.List(context.TODO(), planList)
-	if err != nil {
-		err = liberr.Wrap(err)
-		return
-	}
-
-	inFlight := 0
-	for _, p := range planList.Items {
-		// ignore plans that aren't using the same source provider
-		if p.Spec.Provider.Source != r.Plan.Spec.Provider.Source {
-			continue
-		}
-
-		// skip plans that aren't being executed
-		snapshot := p.Status.Migration.ActiveSnapshot()
-		if !snapshot.HasCondition("Executing") {
+	pool := fairshare.New(r.MaxInFlight)
+	r.demand(
+		pool.Plan(r.Plan),
+		r.Plan.Status.Migration.VMs,
+		r.Migration.Spec.Paused)
+	for _, entry := range fairshare.Shared.Plans(r.Plan.Spec.Provider.Source) {
+		if entry.UID == r.Plan.UID {
 			continue
 		}
-
-		for _, vmStatus := range p.Status.Migration.VMs {
-			if vmStatus.Running() {
-				inFlight++
-			}
-		}
+		r.demand(
+			pool.Add(entry.UID, entry.Priority),
+			entry.VMs,
+			entry.Paused)
 	}
 
-	if inFlight >= r.MaxInFlight {
+	if !pool.Allowed(r.Plan.UID, 1) {
 		return
 	}
 
 	for _, vmStatus := range r.Plan.Status.Migration.VMs {
+		if r.Migration.Spec.Paused(vmStatus.Ref) {
+			continue
+		}
 		if !vmStatus.MarkedStarted() && !vmStatus.MarkedCompleted() {
 			vm = vmStatus
 			hasNext = true
@@ -69,3 +59,20 @@ func (r *Scheduler) Next() (vm *plan.VMStatus, hasNext bool, err error) {
 
 	return
 }
+
+//
+// Add the running and pending VMs to the plan demand.
+func (r *Scheduler) demand(demand *fairshare.Demand, vms []*plan.VMStatus, paused func(ref.Ref) bool) {
+	for _, vmStatus := range vms {
+		if vmStatus.Running() {
+			demand.InFlight++
+			continue
+		}
+		if paused(vmStatus.Ref) {
+			continue
+		}
+		if !vmStatus.MarkedStarted() && !vmStatus.MarkedCompleted() {
+			demand.Pending = append(demand.Pending, 1)
+		}
+	}
+}
@@ -1,15 +1,13 @@
 package vsphere
 
 import (
-	"context"
 	"errors"
 	"github.com/konveyor/forklift-controller/pkg/controller/provider/web"
 	"sync"
 
-	liberr "github.com/konveyor/controller/pkg/error"
-	api "github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1"
 	"github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1/plan"
 	plancontext "github.com/konveyor/forklift-controller/pkg/controller/plan/context"
+	"github.com/konveyor/forklift-controller/pkg/controller/plan/scheduler/fairshare"
 	model "github.com/konveyor/forklift-controller/pkg/controller/provider/web/vsphere"
 )
 
@@ -33,6 +31,9 @@ type Scheduler struct {
 	// Mapping of hosts by ID to lists of VMs
 	// that are waiting to be migrated.
 	pending map[string][]*pendingVM
+	// Mapping of hosts by ID to the capacity
+	// shared by all plans for the provider.
+	pools map[string]*fairshare.Pool
 }
 
 //
@@ -91,7 +92,9 @@ func (r *Scheduler) buildSchedule() (err error) {
 		"inflight",
 		r.inFlight,
 		"pending",
-		r.pending)
+		r.pending,
+		"pools",
+		r.pools)
 
 	return
 }
@@ -101,6 +104,7 @@ func (r *Scheduler) buildSchedule() (err error) {
 // are currently in flight for each host.
 func (r *Scheduler) buildInFlight() (err error) {
 	r.inFlight = make(map[string]int)
+	r.pools = make(map[string]*fairshare.Pool)
 
 	// Since we modify the plan VMStatuses in memory,
 	// we need to use the plan from the context rather
@@ -113,53 +117,61 @@ func (r *Scheduler) buildInFlight() (err error) {
 		}
 		if vmStatus.Running() {
 			r.inFlight[vm.Host] += len(vm.Disks)
+			r.pool(vm.Host).Plan(r.Plan).InFlight += len(vm.Disks)
 		}
 	}
 
-	planList := &api.PlanList{}
-	err = r.List(context.TODO(), planList)
-	if err != nil {
-		return liberr.Wrap(err)
-	}
-	for _, p := range planList.Items {
+	for _, entry := range fairshare.Shared.Plans(r.Plan.Spec.Provider.Source) {
 		// skip this plan, it's already done.
-		if p.Name == r.Plan.Name && p.Namespace == r.Plan.Namespace {
-			continue
-		}
-
-		// ignore plans that aren't using the same source provider
-		if p.Spec.Provider.Source != r.Plan.Spec.Provider.Source {
-			continue
-		}
-
-		// skip plans that aren't being executed
-		snapshot := p.Status.Migration.ActiveSnapshot()
-		if !snapshot.HasCondition("Executing") {
+		if entry.UID == r.Plan.UID {
 			continue
 		}
-
-		for _, vmStatus := range p.Status.Migration.VMs {
-			if !vmStatus.Running() {
+		for _, vmStatus := range entry.VMs {
+			running := vmStatus.Running()
+			pending := !vmStatus.MarkedStarted() &&
+				!vmStatus.MarkedCompleted() &&
+				!entry.Paused(vmStatus.Ref)
+			if !running && !pending {
 				continue
 			}
 			vm := &model.VM{}
 			err = r.Source.Inventory.Find(vm, vmStatus.Ref)
 			if err != nil {
 				if errors.As(err, &web.NotFoundError{}) {
+					err = nil
 					continue
 				}
 				if errors.As(err, &web.RefNotUniqueError{}) {
+					err = nil
 					continue
 				}
-				return err
+				return
+			}
+			demand := r.pool(vm.Host).Add(entry.UID, entry.Priority)
+			if running {
+				r.inFlight[vm.Host] += len(vm.Disks)
+				demand.InFlight += len(vm.Disks)
+			} else {
+				demand.Pending = append(demand.Pending, len(vm.Disks))
 			}
-			r.inFlight[vm.Host] += len(vm.Disks)
 		}
 	}
 
 	return
 }
 
+//
+// Find (or add) the capacity pool for a host.
+func (r *Scheduler) pool(host string) (pool *fairshare.Pool) {
+	pool, found := r.pools[host]
+	if !found {
+		pool = fairshare.New(r.MaxInFlight)
+		r.pools[host] = pool
+	}
+
+	return
+}
+
 //
 // Build the map of pending VMs belonging to each host.
 func (r *Scheduler) buildPending() (err error) {
@@ -172,12 +184,17 @@ func (r *Scheduler) buildPending() (err error) {
 			return
 		}
 
+		if r.Migration.Spec.Paused(vmStatus.Ref) {
+			continue
+		}
 		if !vmStatus.MarkedStarted() && !vmStatus.MarkedCompleted() {
 			pending := &pendingVM{
 				status: vmStatus,
 				cost:   len(vm.Disks),
 			}
 			r.pending[vm.Host] = append(r.pending[vm.Host], pending)
+			demand := r.pool(vm.Host).Plan(r.Plan)
+			demand.Pending = append(demand.Pending, pending.cost)
 		}
 	}
 	return
@@ -185,7 +202,8 @@ func (r *Scheduler) buildPending() (err error) {
 
 //
 // Return a map of all the VMs that could be scheduled
-// based on the available host capacities.
+// based on the available host capacities and the
+// (fair) share of the capacity allotted to the plan.
 func (r *Scheduler) schedulable() (schedulable map[string][]*pendingVM) {
 	schedulable = make(map[string][]*pendingVM)
 	for host, vms := range r.pending {
@@ -193,7 +211,7 @@ func (r *Scheduler) schedulable() (schedulable map[string][]*pendingVM) {
 			continue
 		}
 		for i := range vms {
-			if vms[i].cost+r.inFlight[host] <= r.MaxInFlight {
+			if vms[i].cost+r.inFlight[host] <= r.MaxInFlight && r.allowed(host, vms[i].cost) {
 				schedulable[host] = append(schedulable[host], vms[i])
 			}
 		}
@@ -201,3 +219,15 @@ func (r *Scheduler) schedulable() (schedulable map[string][]*pendingVM) {
 
 	return
 }
+
+//
+// Determine whether the plan may start a VM
+// with the specified cost on the host.
+func (r *Scheduler) allowed(host string, cost int) bool {
+	pool, found := r.pools[host]
+	if !found {
+		return true
+	}
+
+	return pool.Allowed(r.Plan.UID, cost)
+}
@@ -0,0 +1,209 @@
+package plan
+
+import (
+	"errors"
+	libcnd "github.com/konveyor/controller/pkg/condition"
+	liberr "github.com/konveyor/controller/pkg/error"
+	api "github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1"
+	"github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1/plan"
+	"github.com/konveyor/forklift-controller/pkg/controller/plan/adapter"
+	plancontext "github.com/konveyor/forklift-controller/pkg/controller/plan/context"
+	"github.com/konveyor/forklift-controller/pkg/controller/provider/web"
+	cnv "kubevirt.io/client-go/api/v1"
+	cdi "kubevirt.io/containerized-data-importer/pkg/apis/core/v1beta1"
+)
+
+//
+// Test failover of replicated VMs.
+type TestFailover struct {
+	*plancontext.Context
+	// Builder
+	builder adapter.Builder
+	// kubevirt.
+	kubevirt KubeVirt
+}
+
+//
+// Run the test failover.
+// For each VM: the latest synced disks are cloned into the test
+// namespace and the test VM is created. VMs being precopied are
+// tested once the precopy has completed. The outcome is recorded
+// on the TestFailover status.
+func (r *TestFailover) Run(failover *api.TestFailover) (completed bool, err error) {
+	failover.Status.MarkStarted()
+	if !r.Plan.Spec.Replicating() || r.Migration.UID == "" {
+		failover.Status.MarkCompleted()
+		failover.Status.SetCondition(
+			libcnd.Condition{
+				Type:     Failed,
+				Status:   True,
+				Category: Advisory,
+				Reason:   NotValid,
+				Message:  "The plan is not replicating.",
+				Durable:  true,
+			})
+		completed = true
+		return
+	}
+	err = r.init()
+	if err != nil {
+		return
+	}
+	importMap, err := r.kubevirt.ImportMap()
+	if err != nil {
+		return
+	}
+	vms, notFound, err := r.vms(failover)
+	if err != nil {
+		return
+	}
+	notReplicated := notFound
+	failed := []string{}
+	pending := false
+	for _, vm := range vms {
+		if test, found := failover.Status.FindVM(vm.ID); found && test.Created {
+			continue
+		}
+		imp, found := importMap[vm.ID]
+		if !found || vm.MarkedCompleted() || vm.Warm == nil || vm.Warm.Successes == 0 {
+			notReplicated = append(notReplicated, vm.String())
+			continue
+		}
+		if !r.synced(&imp) {
+			pending = true
+			continue
+		}
+		template := &cnv.VirtualMachineInstanceSpec{}
+		tErr := r.builder.Template(vm.Ref, template)
+		if tErr == nil {
+			_, tErr = r.kubevirt.EnsureTestVM(vm, &imp, failover, template)
+		}
+		if tErr != nil {
+			r.Log.Error(
+				tErr,
+				"Test failover failed.",
+				"vm",
+				vm.String())
+			failed = append(failed, vm.String())
+		}
+	}
+	if pending {
+		return
+	}
+	failover.Status.MarkCompleted()
+	if len(notReplicated) > 0 {
+		failover.Status.SetCondition(
+			libcnd.Condition{
+				Type:     VMNotReplicated,
+				Status:   True,
+				Category: Warn,
+				Reason:   NotFound,
+				Message:  "VM not found or not replicated.",
+				Items:    notReplicated,
+				Durable:  true,
+			})
+	}
+	if len(failed) > 0 {
+		failover.Status.SetCondition(
+			libcnd.Condition{
+				Type:     Failed,
+				Status:   True,
+				Category: Advisory,
+				Message:  "The test failover has FAILED.",
+				Items:    failed,
+				Durable:  true,
+			})
+	} else {
+		failover.Status.SetCondition(
+			libcnd.Condition{
+				Type:     Succeeded,
+				Status:   True,
+				Category: Advisory,
+				Message:  "The test failover has SUCCEEDED.",
+				Durable:  true,
+			})
+	}
+	completed = true
+
+	return
+}
+
+//
+// Delete the test VMs (and cloned DataVolumes) created by
+// test failovers that are not listed to be kept.
+func (r *TestFailover) Cleanup(keep map[string]bool) (err error) {
+	err = r.init()
+	if err != nil {
+		return
+	}
+	err = r.kubevirt.DeleteTestVMs(keep)
+
+	return
+}
+
+//
+// The VMs to be tested.
+// Default: all of the VMs.
+func (r *TestFailover) vms(failover *api.TestFailover) (list []*plan.VMStatus, notFound []string, err error) {
+	if len(failover.Spec.VMs) == 0 {
+		list = r.Plan.Status.Migration.VMs
+		return
+	}
+	for i := range failover.Spec.VMs {
+		ref := &failover.Spec.VMs[i]
+		_, err = r.Source.Inventory.VM(ref)
+		if err != nil {
+			if errors.As(err, &web.NotFoundError{}) ||
+				errors.As(err, &web.RefNotUniqueError{}) {
+				notFound = append(notFound, ref.String())
+				err = nil
+				continue
+			}
+			return
+		}
+		vm, found := r.Plan.Status.Migration.FindVM(*ref)
+		if !found {
+			notFound = append(notFound, ref.String())
+			continue
+		}
+		list = append(list, vm)
+	}
+
+	return
+}
+
+//
+// Determine whether the disks are synced.
+// The DataVolumes are paused between precopies.
+func (r *TestFailover) synced(imp *VmImport) bool {
+	if len(imp.DataVolumes) == 0 {
+		return false
+	}
+	for _, dv := range imp.DataVolumes {
+		if dv.Status.Phase != cdi.Paused {
+			return false
+		}
+	}
+
+	return true
+}
+
+//
+// Get/Build resources.
+func (r *TestFailover) init() (err error) {
+	adapter, err := adapter.New(r.Context.Source.Provider)
+	if err != nil {
+		return
+	}
+	r.builder, err = adapter.Builder(r.Context)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	r.kubevirt = KubeVirt{
+		Context: r.Context,
+		Builder: r.builder,
+	}
+
+	return
+}
@@ -0,0 +1,141 @@
+package plan
+
+import (
+	"encoding/json"
+	api "github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1"
+	"github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1/plan"
+	"github.com/konveyor/forklift-controller/pkg/controller/plan/adapter"
+	core "k8s.io/api/core/v1"
+	"net"
+	"strings"
+)
+
+//
+// Resolves the transfer network for VMs.
+// Precedence:
+//   - The network selected when the migration was started.
+//   - The network specified on the plan VM.
+//   - The network selected for the source host.
+//   - The network selected for the source cluster.
+//   - The plan transfer network.
+type TransferNetworks struct {
+	// Plan.
+	Plan *api.Plan
+	// Source provider validator.
+	Validator adapter.Validator
+}
+
+//
+// Resolve the transfer network for a VM.
+// Returns nil when the pod network is used.
+func (r *TransferNetworks) Network(vm *plan.VM) (network *core.ObjectReference, err error) {
+	if status, found := r.Plan.Status.Migration.FindVM(vm.Ref); found {
+		if status.TransferNetwork != nil {
+			network = status.TransferNetwork
+			return
+		}
+	}
+	if vm.TransferNetwork != nil {
+		network = vm.TransferNetwork
+		return
+	}
+	network = r.Plan.Spec.TransferNetwork
+	if len(r.Plan.Spec.TransferNetworks) == 0 {
+		return
+	}
+	host, cluster, err := r.Validator.Placement(vm.Ref)
+	if err != nil {
+		return
+	}
+	for i := range r.Plan.Spec.TransferNetworks {
+		selected := &r.Plan.Spec.TransferNetworks[i]
+		if selected.MatchHost(host) {
+			network = &selected.Network
+			return
+		}
+	}
+	for i := range r.Plan.Spec.TransferNetworks {
+		selected := &r.Plan.Spec.TransferNetworks[i]
+		if selected.MatchCluster(cluster) {
+			network = &selected.Network
+			return
+		}
+	}
+
+	return
+}
+
+//
+// CNI IPAM configuration.
+// Supports the host-local, static and whereabouts plugins.
+type ipamConfig struct {
+	// host-local (legacy) and whereabouts.
+	Subnet string `json:"subnet"`
+	// whereabouts.
+	Range string `json:"range"`
+	// whereabouts.
+	IPRanges []struct {
+		Range string `json:"range"`
+	} `json:"ipRanges"`
+	// host-local.
+	Ranges [][]struct {
+		Subnet string `json:"subnet"`
+	} `json:"ranges"`
+	// static.
+	Addresses []struct {
+		Address string `json:"address"`
+	} `json:"addresses"`
+}
+
+//
+// CNI network configuration (or configuration list).
+type cniConfig struct {
+	IPAM    ipamConfig `json:"ipam"`
+	Plugins []struct {
+		IPAM ipamConfig `json:"ipam"`
+	} `json:"plugins"`
+}
+
+//
+// The subnets of a network attachment definition
+// found in the (CNI) IPAM configuration.
+// Empty when the subnets cannot be determined (DHCP).
+func TransferSubnets(config string) (subnets []*net.IPNet) {
+	cni := cniConfig{}
+	err := json.Unmarshal([]byte(config), &cni)
+	if err != nil {
+		return
+	}
+	ipam := []ipamConfig{cni.IPAM}
+	for _, plugin := range cni.Plugins {
+		ipam = append(ipam, plugin.IPAM)
+	}
+	cidrs := []string{}
+	for _, c := range ipam {
+		cidrs = append(cidrs, c.Subnet, c.Range)
+		for _, r := range c.IPRanges {
+			cidrs = append(cidrs, r.Range)
+		}
+		for _, set := range c.Ranges {
+			for _, r := range set {
+				cidrs = append(cidrs, r.Subnet)
+			}
+		}
+		for _, a := range c.Addresses {
+			cidrs = append(cidrs, a.Address)
+		}
+	}
+	for _, cidr := range cidrs {
+		// whereabouts: <start>-<end>/<prefix>
+		if n := strings.LastIndex(cidr, "-"); n != -1 {
+			cidr = cidr[n+1:]
+		}
+		_, subnet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			continue
+		}
+		subnets = append(subnets, subnet)
+	}
+
+	return
+}
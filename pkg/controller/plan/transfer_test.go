@@ -0,0 +1,78 @@
+package plan
+
+import (
+	"github.com/onsi/gomega"
+	"testing"
+)
+
+func TestTransferSubnets(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	cases := []struct {
+		name     string
+		config   string
+		expected []string
+	}{
+		{
+			name:   "not valid",
+			config: `{"ipam":`,
+		},
+		{
+			name:   "dhcp",
+			config: `{"type":"bridge","ipam":{"type":"dhcp"}}`,
+		},
+		{
+			name:     "host-local subnet",
+			config:   `{"ipam":{"type":"host-local","subnet":"10.10.0.0/16"}}`,
+			expected: []string{"10.10.0.0/16"},
+		},
+		{
+			name: "host-local ranges",
+			config: `{"ipam":{"type":"host-local","ranges":[
+				[{"subnet":"10.10.0.0/24"},{"subnet":"10.20.0.0/24"}],
+				[{"subnet":"fd00::/64"}]]}}`,
+			expected: []string{"10.10.0.0/24", "10.20.0.0/24", "fd00::/64"},
+		},
+		{
+			name:     "whereabouts range",
+			config:   `{"ipam":{"type":"whereabouts","range":"192.168.2.225/28"}}`,
+			expected: []string{"192.168.2.224/28"},
+		},
+		{
+			name:     "whereabouts start and end",
+			config:   `{"ipam":{"type":"whereabouts","range":"192.168.2.10-192.168.2.20/24"}}`,
+			expected: []string{"192.168.2.0/24"},
+		},
+		{
+			name:     "whereabouts ip ranges",
+			config:   `{"ipam":{"type":"whereabouts","ipRanges":[{"range":"10.1.0.0/24"},{"range":"10.2.0.0/24"}]}}`,
+			expected: []string{"10.1.0.0/24", "10.2.0.0/24"},
+		},
+		{
+			name:     "static",
+			config:   `{"ipam":{"type":"static","addresses":[{"address":"10.10.0.1/24"}]}}`,
+			expected: []string{"10.10.0.0/24"},
+		},
+		{
+			name: "plugin list",
+			config: `{"cniVersion":"0.3.1","plugins":[
+				{"type":"bridge","ipam":{"type":"host-local","subnet":"10.10.0.0/16"}},
+				{"type":"tuning"}]}`,
+			expected: []string{"10.10.0.0/16"},
+		},
+		{
+			name:   "invalid cidr",
+			config: `{"ipam":{"type":"host-local","subnet":"10.10.0.0"}}`,
+		},
+	}
+	for _, c := range cases {
+		subnets := []string{}
+		for _, subnet := range TransferSubnets(c.config) {
+			subnets = append(subnets, subnet.String())
+		}
+		if c.expected == nil {
+			c.expected = []string{}
+		}
+		g.Expect(subnets).To(gomega.Equal(c.expected), c.name)
+	}
+}
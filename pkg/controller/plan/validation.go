@@ -9,14 +9,20 @@ import (
 	liberr "github.com/konveyor/controller/pkg/error"
 	libref "github.com/konveyor/controller/pkg/ref"
 	api "github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1"
+	planapi "github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1/plan"
 	refapi "github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1/ref"
 	"github.com/konveyor/forklift-controller/pkg/controller/plan/adapter"
 	"github.com/konveyor/forklift-controller/pkg/controller/provider/web"
 	"github.com/konveyor/forklift-controller/pkg/controller/validation"
+	"io/ioutil"
+	core "k8s.io/api/core/v1"
 	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
+	gonet "net"
 	"path"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"strings"
 )
 
 //
@@ -24,18 +30,38 @@ import (
 const (
 	NamespaceNotValid   = "NamespaceNotValid"
 	TransferNetNotValid = "TransferNetworkNotValid"
+	NetNotReachable     = "TransferNetworkNotReachable"
 	NetRefNotValid      = "NetworkMapRefNotValid"
 	NetMapNotReady      = "NetworkMapNotReady"
 	DsMapNotReady       = "StorageMapNotReady"
 	DsRefNotValid       = "StorageRefNotValid"
 	VMRefNotValid       = "VMRefNotValid"
+	VMSelectorNotValid  = "VMSelectorNotValid"
+	VMSelectorNoMatch   = "VMSelectorNoMatch"
 	VMNotFound          = "VMNotFound"
 	VMAlreadyExists     = "VMAlreadyExists"
 	VMNetworksNotMapped = "VMNetworksNotMapped"
 	VMStorageNotMapped  = "VMStorageNotMapped"
+	VMDiskNotValid      = "VMDiskNotValid"
+	VMDiskNotSupported  = "VMDiskNotSupported"
+	VMNICNotValid       = "VMNICNotValid"
+	VMNICNotSupported   = "VMNICNotSupported"
+	VMDevNotSupported   = "VMDeviceNotSupported"
+	VMPodNetNotUnique   = "VMPodNetworkNotUnique"
+	TargetVMNotValid    = "TargetVMNotValid"
+	SourceVMNotValid    = "SourceVMNotValid"
+	VerifyNotValid      = "VerifyNotValid"
+	StaticIPsNotValid   = "StaticIPsNotValid"
+	GuestNetNotConfig   = "GuestNetworkNotConfigured"
+	WarmPolicyNotValid  = "WarmPolicyNotValid"
 	HostNotReady        = "HostNotReady"
 	DuplicateVM         = "DuplicateVM"
 	NameNotValid        = "TargetNameNotValid"
+	NameNotUnique       = "TargetNameNotUnique"
+	NamingNotValid      = "NamingNotValid"
+	QuotaExceeded       = "QuotaExceeded"
+	LimitRangeExceeded  = "LimitRangeExceeded"
+	CapacityExceeded    = "CapacityExceeded"
 	HookNotValid        = "HookNotValid"
 	HookNotReady        = "HookNotReady"
 	HookStepNotValid    = "HookStepNotValid"
@@ -48,6 +74,15 @@ const (
 	Pending             = "Pending"
 	Running             = "Running"
 	Blocked             = "Blocked"
+	RolledBack          = "RolledBack"
+	RollbackFailed      = "RollbackFailed"
+	VMNotMigrated       = "VMNotMigrated"
+	VMNotReplicated     = "VMNotReplicated"
+	Skipped             = "Skipped"
+	ReplicationNotValid = "ReplicationNotValid"
+	VerificationFailed  = "VerificationFailed"
+	SourceNotUpdated    = "SourceNotUpdated"
+	SnapshotNotRemoved  = "SnapshotNotRemoved"
 )
 
 //
@@ -68,9 +103,12 @@ const (
 	NotUnique         = "NotUnique"
 	Ambiguous         = "Ambiguous"
 	NotValid          = "NotValid"
+	NotSupported      = "NotSupported"
 	Modified          = "Modified"
 	UserRequested     = "UserRequested"
+	Expired           = "Expired"
 	InMaintenanceMode = "InMaintenanceMode"
+	RolledBackTarget  = "RolledBackTarget"
 )
 
 //
@@ -112,12 +150,66 @@ func (r *Reconciler) validate(plan *api.Plan) error {
 		return err
 	}
 	//
+	// VM selector.
+	err = r.selectVMs(plan)
+	if err != nil {
+		return err
+	}
+	//
+	// Target VM naming policy.
+	err = r.validateNaming(plan)
+	if err != nil {
+		return err
+	}
+	//
 	// VM list.
 	err = r.validateVM(plan)
 	if err != nil {
 		return err
 	}
 	//
+	// Destination capacity.
+	err = r.validateCapacity(plan)
+	if err != nil {
+		return err
+	}
+	//
+	// Target VM overrides.
+	err = r.validateTargetVM(plan)
+	if err != nil {
+		return err
+	}
+	//
+	// Source VM actions.
+	err = r.validateSourceVM(plan)
+	if err != nil {
+		return err
+	}
+	//
+	// Post-migration verification.
+	err = r.validateVerify(plan)
+	if err != nil {
+		return err
+	}
+	//
+	// Static IP preservation.
+	err = r.validateStaticIPs(plan)
+	if err != nil {
+		return err
+	}
+	//
+	// Warm migration settings.
+	err = r.validateWarmPolicy(plan)
+	if err != nil {
+		return err
+	}
+	//
+	// Continuous replication.
+	err = r.validateReplication(plan)
+	if err != nil {
+		return err
+	}
+	//
 	// Transfer network
 	err = r.validateTransferNetwork(plan)
 	if err != nil {
@@ -132,6 +224,380 @@ func (r *Reconciler) validate(plan *api.Plan) error {
 	return nil
 }
 
+//
+// Validate the source VM lifecycle actions.
+func (r *Reconciler) validateSourceVM(plan *api.Plan) error {
+	source := plan.Spec.SourceVM
+	if source == nil {
+		return nil
+	}
+	notValid := libcnd.Condition{
+		Type:     SourceVMNotValid,
+		Status:   True,
+		Reason:   NotValid,
+		Category: Critical,
+		Message:  "Source VM actions not valid.",
+		Items:    []string{},
+	}
+	if source.Shutdown != nil {
+		if source.Shutdown.Timeout < 0 {
+			notValid.Items = append(
+				notValid.Items,
+				"shutdown timeout must be >= 0")
+		}
+		if plan.Spec.Warm {
+			notValid.Items = append(
+				notValid.Items,
+				"shutdown not supported by warm migration")
+		}
+	}
+	if source.Snapshot != nil && plan.Spec.Warm {
+		notValid.Items = append(
+			notValid.Items,
+			"snapshot not supported by warm migration")
+	}
+	if after := source.AfterMigration; after != nil {
+		provider := plan.Referenced.Provider.Source
+		if after.Folder != "" && provider != nil && provider.Type() != api.VSphere {
+			notValid.Items = append(
+				notValid.Items,
+				"folder only supported by vSphere")
+		}
+	}
+	if len(notValid.Items) > 0 {
+		plan.Status.SetCondition(notValid)
+	}
+
+	return nil
+}
+
+//
+// Validate the post-migration verification.
+func (r *Reconciler) validateVerify(plan *api.Plan) error {
+	verify := plan.Spec.Verify
+	if verify == nil {
+		return nil
+	}
+	notValid := libcnd.Condition{
+		Type:     VerifyNotValid,
+		Status:   True,
+		Reason:   NotValid,
+		Category: Critical,
+		Message:  "Post-migration verification not valid.",
+		Items:    []string{},
+	}
+	if verify.Timeout < 0 {
+		notValid.Items = append(
+			notValid.Items,
+			"timeout must be >= 0")
+	}
+	switch verify.FailurePolicy {
+	case "", planapi.FailurePolicyFail, planapi.FailurePolicyWarn:
+	default:
+		notValid.Items = append(
+			notValid.Items,
+			fmt.Sprintf(
+				"failure policy '%s' not valid",
+				verify.FailurePolicy))
+	}
+	for _, port := range verify.Ports {
+		if port < 1 || port > 65535 {
+			notValid.Items = append(
+				notValid.Items,
+				fmt.Sprintf(
+					"port %d not valid",
+					port))
+		}
+	}
+	if len(notValid.Items) > 0 {
+		plan.Status.SetCondition(notValid)
+	}
+
+	return nil
+}
+
+//
+// Validate the destination capacity.
+// Blocked when resource quotas or limit ranges in the target
+// namespace would be exceeded. The cluster capacity (nodes) is
+// a warning because it is shared and may be scaled.
+// VMs started (and not failed or canceled) are omitted because the
+// resources already created for them are counted as used. While the
+// plan is executing, exceeded quotas and limit ranges are warnings
+// so that the execution is not blocked partway through.
+func (r *Reconciler) validateCapacity(plan *api.Plan) error {
+	category := Critical
+	if plan.Status.Migration.ActiveSnapshot().HasCondition(Executing) {
+		category = Warn
+	}
+	source := plan.Referenced.Provider.Source
+	destination := plan.Referenced.Provider.Destination
+	if source == nil || destination == nil {
+		return nil
+	}
+	pAdapter, err := adapter.New(source)
+	if err != nil {
+		return err
+	}
+	validator, err := pAdapter.Validator(plan)
+	if err != nil {
+		return err
+	}
+	inventory, err := web.NewClient(destination)
+	if err != nil {
+		return liberr.Wrap(err)
+	}
+	capacity := &Capacity{
+		Plan:        plan,
+		Destination: inventory,
+	}
+	for i := range plan.Spec.VMs {
+		vm := &plan.Spec.VMs[i]
+		if status, found := plan.Status.Migration.FindVM(vm.Ref); found {
+			if status.MarkedStarted() && status.Error == nil && !status.HasCondition(Canceled) {
+				continue // migrating or migrated.
+			}
+		}
+		required, err := validator.Requirements(vm.Ref)
+		if err != nil {
+			if errors.As(err, &web.NotFoundError{}) {
+				continue
+			}
+			return err
+		}
+		if target := plan.Spec.FindTargetVM(vm); target != nil {
+			if cpu := target.CPU; cpu != nil {
+				required.CPU = 1
+				for _, n := range []uint32{cpu.Sockets, cpu.Cores, cpu.Threads} {
+					if n > 0 {
+						required.CPU *= int64(n)
+					}
+				}
+			}
+			if target.Memory != nil {
+				required.Memory = target.Memory.Value()
+			}
+		}
+		capacity.Add(vm.Ref, required)
+	}
+	exceeded, err := capacity.Quotas()
+	if err != nil {
+		return err
+	}
+	if len(exceeded) > 0 {
+		plan.Status.SetCondition(libcnd.Condition{
+			Type:     QuotaExceeded,
+			Status:   True,
+			Reason:   NotValid,
+			Category: category,
+			Message:  "VMs exceed the resource quota in the target namespace.",
+			Items:    exceeded,
+		})
+	}
+	exceeded, err = capacity.LimitRanges()
+	if err != nil {
+		return err
+	}
+	if len(exceeded) > 0 {
+		plan.Status.SetCondition(libcnd.Condition{
+			Type:     LimitRangeExceeded,
+			Status:   True,
+			Reason:   NotValid,
+			Category: category,
+			Message:  "VMs exceed the limit range in the target namespace.",
+			Items:    exceeded,
+		})
+	}
+	exceeded, err = capacity.Nodes()
+	if err != nil {
+		return err
+	}
+	if len(exceeded) > 0 {
+		plan.Status.SetCondition(libcnd.Condition{
+			Type:     CapacityExceeded,
+			Status:   True,
+			Reason:   NotValid,
+			Category: Warn,
+			Message:  "VMs may exceed the cluster capacity.",
+			Items:    exceeded,
+		})
+	}
+
+	return nil
+}
+
+//
+// Validate the target VM naming policy.
+// The template is rendered using sample data.
+func (r *Reconciler) validateNaming(plan *api.Plan) error {
+	naming := plan.Spec.Naming
+	if naming == nil {
+		return nil
+	}
+	tmpl, err := NameTemplate(naming)
+	if err == nil {
+		err = tmpl.Execute(
+			ioutil.Discard,
+			NameData{
+				Name:      "vm",
+				ID:        "id",
+				Folder:    "folder",
+				Plan:      plan.Name,
+				Namespace: plan.Spec.TargetNamespace,
+			})
+	}
+	if err != nil {
+		plan.Status.SetCondition(libcnd.Condition{
+			Type:     NamingNotValid,
+			Status:   True,
+			Reason:   NotValid,
+			Category: Critical,
+			Message:  fmt.Sprintf("Naming template not valid: %s", err.Error()),
+		})
+	}
+
+	return nil
+}
+
+//
+// Validate static IP preservation.
+func (r *Reconciler) validateStaticIPs(plan *api.Plan) error {
+	staticIPs := plan.Spec.StaticIPs
+	if staticIPs == nil {
+		return nil
+	}
+	notValid := libcnd.Condition{
+		Type:     StaticIPsNotValid,
+		Status:   True,
+		Reason:   NotValid,
+		Category: Critical,
+		Message:  "Static IP mapping not valid.",
+		Items:    []string{},
+	}
+	for _, mapping := range staticIPs.Map {
+		reasons := []string{}
+		if len(k8svalidation.IsValidIP(mapping.Source)) > 0 {
+			reasons = append(reasons, "source must be an IP address")
+		}
+		if _, valid := mapping.DestinationIP(); !valid {
+			reasons = append(reasons, "destination must be an IP address (CIDR)")
+		}
+		if mapping.Gateway != "" && len(k8svalidation.IsValidIP(mapping.Gateway)) > 0 {
+			reasons = append(reasons, "gateway must be an IP address")
+		}
+		for _, dns := range mapping.DNS {
+			if len(k8svalidation.IsValidIP(dns)) > 0 {
+				reasons = append(reasons, "dns must be IP addresses")
+				break
+			}
+		}
+		if len(reasons) > 0 {
+			notValid.Items = append(
+				notValid.Items,
+				fmt.Sprintf(
+					"%s: %s",
+					mapping.Source,
+					strings.Join(reasons, ", ")))
+		}
+	}
+	if len(notValid.Items) > 0 {
+		plan.Status.SetCondition(notValid)
+	}
+
+	return nil
+}
+
+//
+// Validate the warm migration settings.
+func (r *Reconciler) validateWarmPolicy(plan *api.Plan) error {
+	policy := plan.Spec.WarmPolicy
+	if policy == nil {
+		return nil
+	}
+	notValid := libcnd.Condition{
+		Type:     WarmPolicyNotValid,
+		Status:   True,
+		Reason:   NotValid,
+		Category: Critical,
+		Message:  "Warm migration settings not valid.",
+		Items:    []string{},
+	}
+	if !plan.Spec.Warm {
+		notValid.Items = append(
+			notValid.Items,
+			"warm migration not enabled")
+	}
+	if policy.PrecopyInterval < 0 {
+		notValid.Items = append(
+			notValid.Items,
+			"precopy interval must be >= 0")
+	}
+	if policy.MaxPrecopies < 0 {
+		notValid.Items = append(
+			notValid.Items,
+			"max precopies must be >= 0")
+	}
+	if policy.FailureLimit < 0 {
+		notValid.Items = append(
+			notValid.Items,
+			"failure limit must be >= 0")
+	}
+	if policy.DeltaThreshold < 0 {
+		notValid.Items = append(
+			notValid.Items,
+			"delta threshold must be >= 0")
+	}
+	provider := plan.Referenced.Provider.Source
+	if policy.DeltaThreshold > 0 && provider != nil && provider.Type() != api.VSphere {
+		notValid.Items = append(
+			notValid.Items,
+			"delta threshold only supported by vSphere")
+	}
+	if len(notValid.Items) > 0 {
+		plan.Status.SetCondition(notValid)
+	}
+
+	return nil
+}
+
+//
+// Validate the continuous replication.
+func (r *Reconciler) validateReplication(plan *api.Plan) error {
+	replication := plan.Spec.Replication
+	if replication == nil {
+		return nil
+	}
+	notValid := libcnd.Condition{
+		Type:     ReplicationNotValid,
+		Status:   True,
+		Reason:   NotValid,
+		Category: Critical,
+		Message:  "Continuous replication not valid.",
+		Items:    []string{},
+	}
+	if !plan.Spec.Warm {
+		notValid.Items = append(
+			notValid.Items,
+			"warm migration not enabled")
+	}
+	namespace := replication.GetTestNamespace(plan.Spec.TargetNamespace)
+	if len(k8svalidation.IsDNS1123Label(namespace)) > 0 {
+		notValid.Items = append(
+			notValid.Items,
+			"test namespace not valid")
+	}
+	if namespace == plan.Spec.TargetNamespace {
+		notValid.Items = append(
+			notValid.Items,
+			"test namespace must not be the target namespace")
+	}
+	if len(notValid.Items) > 0 {
+		plan.Status.SetCondition(notValid)
+	}
+
+	return nil
+}
+
 //
 // Validate the target namespace.
 func (r *Reconciler) validateTargetNamespace(plan *api.Plan) (err error) {
@@ -243,6 +709,64 @@ func (r *Reconciler) validateStorageMap(plan *api.Plan) (err error) {
 	return
 }
 
+//
+// Add VMs matched by the VM selector to the VM list.
+// The selection is recorded in the plan status and is
+// only resolved while the plan is not executing so that
+// the VMs being migrated do not change mid-migration.
+func (r *Reconciler) selectVMs(plan *api.Plan) error {
+	if plan.Status.Migration.ActiveSnapshot().HasCondition(Executing) {
+		plan.AddSelectedVMs()
+		return nil
+	}
+	plan.Status.SelectedVMs = nil
+	if plan.Spec.VMSelector == nil {
+		return nil
+	}
+	selector, err := meta.LabelSelectorAsSelector(plan.Spec.VMSelector)
+	if err != nil {
+		plan.Status.SetCondition(libcnd.Condition{
+			Type:     VMSelectorNotValid,
+			Status:   True,
+			Reason:   NotValid,
+			Category: Critical,
+			Message:  "VM selector not valid.",
+			Items:    []string{err.Error()},
+		})
+		return nil
+	}
+	provider := plan.Referenced.Provider.Source
+	if provider == nil {
+		return nil
+	}
+	pAdapter, err := adapter.New(provider)
+	if err != nil {
+		return err
+	}
+	validator, err := pAdapter.Validator(plan)
+	if err != nil {
+		return err
+	}
+	matched, err := validator.SelectVMs(selector)
+	if err != nil {
+		return liberr.Wrap(err)
+	}
+	if len(matched) == 0 {
+		plan.Status.SetCondition(libcnd.Condition{
+			Type:     VMSelectorNoMatch,
+			Status:   True,
+			Reason:   NotFound,
+			Category: Warn,
+			Message:  "VM selector did not match any VMs.",
+		})
+		return nil
+	}
+	plan.Status.SelectedVMs = matched
+	plan.AddSelectedVMs()
+
+	return nil
+}
+
 //
 // Validate listed VMs.
 func (r *Reconciler) validateVM(plan *api.Plan) error {
@@ -281,6 +805,14 @@ func (r *Reconciler) validateVM(plan *api.Plan) error {
 		Message:  "Target VM name not valid.",
 		Items:    []string{},
 	}
+	nameNotUnique := libcnd.Condition{
+		Type:     NameNotUnique,
+		Status:   True,
+		Reason:   NotUnique,
+		Category: Critical,
+		Message:  "Target VM name not unique.",
+		Items:    []string{},
+	}
 	alreadyExists := libcnd.Condition{
 		Type:     VMAlreadyExists,
 		Status:   True,
@@ -305,6 +837,54 @@ func (r *Reconciler) validateVM(plan *api.Plan) error {
 		Message:  "VM has unmapped storage.",
 		Items:    []string{},
 	}
+	diskNotFound := libcnd.Condition{
+		Type:     VMDiskNotValid,
+		Status:   True,
+		Reason:   NotFound,
+		Category: Critical,
+		Message:  "VM disk override references a disk not found on the VM.",
+		Items:    []string{},
+	}
+	diskNotSupported := libcnd.Condition{
+		Type:     VMDiskNotSupported,
+		Status:   True,
+		Reason:   NotValid,
+		Category: Critical,
+		Message:  "VM disk override not supported by the provider or the migration type.",
+		Items:    []string{},
+	}
+	nicNotFound := libcnd.Condition{
+		Type:     VMNICNotValid,
+		Status:   True,
+		Reason:   NotFound,
+		Category: Critical,
+		Message:  "VM NIC override references a NIC not found on the VM.",
+		Items:    []string{},
+	}
+	nicNotSupported := libcnd.Condition{
+		Type:     VMNICNotSupported,
+		Status:   True,
+		Reason:   NotValid,
+		Category: Critical,
+		Message:  "VM NIC override not supported by the provider.",
+		Items:    []string{},
+	}
+	podNotUnique := libcnd.Condition{
+		Type:     VMPodNetNotUnique,
+		Status:   True,
+		Reason:   NotUnique,
+		Category: Critical,
+		Message:  "VM NIC overrides select the pod network more than once.",
+		Items:    []string{},
+	}
+	devNotSupported := libcnd.Condition{
+		Type:     VMDevNotSupported,
+		Status:   True,
+		Reason:   NotValid,
+		Category: Warn,
+		Message:  "VM has devices that will not be migrated.",
+		Items:    []string{},
+	}
 	maintenanceMode := libcnd.Condition{
 		Type:     HostNotReady,
 		Status:   True,
@@ -315,6 +895,8 @@ func (r *Reconciler) validateVM(plan *api.Plan) error {
 	}
 
 	setOf := map[string]bool{}
+	setOfNames := map[string]bool{}
+	var namer *Namer
 	//
 	// Referenced VMs.
 	for i := range plan.Spec.VMs {
@@ -338,6 +920,7 @@ func (r *Reconciler) validateVM(plan *api.Plan) error {
 		if pErr != nil {
 			return liberr.Wrap(pErr)
 		}
+		source := inventory
 		_, pErr = inventory.VM(ref)
 		if pErr != nil {
 			if errors.As(pErr, &web.NotFoundError{}) {
@@ -350,9 +933,6 @@ func (r *Reconciler) validateVM(plan *api.Plan) error {
 			}
 			return liberr.Wrap(pErr)
 		}
-		if len(k8svalidation.IsDNS1123Label(ref.Name)) > 0 {
-			nameNotValid.Items = append(nameNotValid.Items, ref.String())
-		}
 		if _, found := setOf[ref.ID]; found {
 			notUnique.Items = append(notUnique.Items, ref.String())
 		} else {
@@ -384,6 +964,68 @@ func (r *Reconciler) validateVM(plan *api.Plan) error {
 				unmappedStorage.Items = append(unmappedStorage.Items, ref.String())
 			}
 		}
+		if disks := plan.Spec.VMs[i].Disks; len(disks) > 0 {
+			ids, err := validator.Disks(*ref)
+			if err != nil {
+				return err
+			}
+			for j := range disks {
+				disk := &disks[j]
+				item := fmt.Sprintf("%s disk: %s", ref.String(), disk.ID)
+				found := false
+				for _, id := range ids {
+					if id == disk.ID {
+						found = true
+						break
+					}
+				}
+				if !found {
+					diskNotFound.Items = append(diskNotFound.Items, item)
+					continue
+				}
+				supported, err := validator.DiskOverrideSupported(*ref, disk)
+				if err != nil {
+					return err
+				}
+				if !supported || (disk.Exclude && plan.Spec.Warm) {
+					diskNotSupported.Items = append(diskNotSupported.Items, item)
+				}
+			}
+		}
+		if nics := plan.Spec.VMs[i].NICs; len(nics) > 0 {
+			notFound, notSupported, err := validator.NICOverrides(*ref)
+			if err != nil {
+				return err
+			}
+			for _, id := range notFound {
+				nicNotFound.Items = append(
+					nicNotFound.Items,
+					fmt.Sprintf("%s nic: %s", ref.String(), id))
+			}
+			for _, id := range notSupported {
+				nicNotSupported.Items = append(
+					nicNotSupported.Items,
+					fmt.Sprintf("%s nic: %s", ref.String(), id))
+			}
+			pod := 0
+			for _, nic := range nics {
+				if nic.Pod && !nic.Exclude {
+					pod++
+				}
+			}
+			if pod > 1 {
+				podNotUnique.Items = append(podNotUnique.Items, ref.String())
+			}
+		}
+		devices, err := validator.UnsupportedDevices(*ref)
+		if err != nil {
+			return err
+		}
+		for _, device := range devices {
+			devNotSupported.Items = append(
+				devNotSupported.Items,
+				fmt.Sprintf("%s device: %s", ref.String(), device))
+		}
 		ok, err := validator.MaintenanceMode(*ref)
 		if err != nil {
 			return err
@@ -400,9 +1042,36 @@ func (r *Reconciler) validateVM(plan *api.Plan) error {
 		if pErr != nil {
 			return liberr.Wrap(pErr)
 		}
+		if plan.Status.HasCondition(NamingNotValid) {
+			continue
+		}
+		if namer == nil {
+			namer = &Namer{
+				Plan:        plan,
+				Validator:   validator,
+				Source:      source,
+				Destination: inventory,
+			}
+		}
+		name, err := namer.Name(&plan.Spec.VMs[i])
+		if err != nil {
+			return err
+		}
+		if len(k8svalidation.IsDNS1123Label(name)) > 0 {
+			nameNotValid.Items = append(
+				nameNotValid.Items,
+				fmt.Sprintf("%s name: %s", ref.String(), name))
+		}
+		if _, found := setOfNames[name]; found {
+			nameNotUnique.Items = append(
+				nameNotUnique.Items,
+				fmt.Sprintf("%s name: %s", ref.String(), name))
+		} else {
+			setOfNames[name] = true
+		}
 		id := path.Join(
 			plan.Spec.TargetNamespace,
-			ref.Name)
+			name)
 		_, pErr = inventory.VM(&refapi.Ref{Name: id})
 		if pErr == nil {
 			if vm, found := plan.Status.Migration.FindVM(*ref); found {
@@ -431,6 +1100,9 @@ func (r *Reconciler) validateVM(plan *api.Plan) error {
 	if len(nameNotValid.Items) > 0 {
 		plan.Status.SetCondition(nameNotValid)
 	}
+	if len(nameNotUnique.Items) > 0 {
+		plan.Status.SetCondition(nameNotUnique)
+	}
 	if len(ambiguous.Items) > 0 {
 		plan.Status.SetCondition(ambiguous)
 	}
@@ -440,15 +1112,161 @@ func (r *Reconciler) validateVM(plan *api.Plan) error {
 	if len(unmappedStorage.Items) > 0 {
 		plan.Status.SetCondition(unmappedStorage)
 	}
+	if len(diskNotFound.Items) > 0 {
+		plan.Status.SetCondition(diskNotFound)
+	}
+	if len(diskNotSupported.Items) > 0 {
+		plan.Status.SetCondition(diskNotSupported)
+	}
+	if len(nicNotFound.Items) > 0 {
+		plan.Status.SetCondition(nicNotFound)
+	}
+	if len(nicNotSupported.Items) > 0 {
+		plan.Status.SetCondition(nicNotSupported)
+	}
+	if len(podNotUnique.Items) > 0 {
+		plan.Status.SetCondition(podNotUnique)
+	}
+	if len(devNotSupported.Items) > 0 {
+		plan.Status.SetCondition(devNotSupported)
+	}
 
 	return nil
 }
 
+//
+// Validate target VM overrides.
+func (r *Reconciler) validateTargetVM(plan *api.Plan) error {
+	notValid := libcnd.Condition{
+		Type:     TargetVMNotValid,
+		Status:   True,
+		Reason:   NotValid,
+		Category: Critical,
+		Message:  "Target VM overrides not valid.",
+		Items:    []string{},
+	}
+	for i := range plan.Spec.VMs {
+		vm := &plan.Spec.VMs[i]
+		target := plan.Spec.FindTargetVM(vm)
+		if target == nil {
+			continue
+		}
+		reasons := r.targetVMReasons(target)
+		if len(reasons) > 0 {
+			notValid.Items = append(
+				notValid.Items,
+				fmt.Sprintf(
+					"%s: %s",
+					vm.Ref.String(),
+					strings.Join(reasons, "; ")))
+		}
+	}
+	if len(notValid.Items) > 0 {
+		plan.Status.SetCondition(notValid)
+	}
+
+	return nil
+}
+
+//
+// Find the reasons target VM overrides are not valid.
+func (r *Reconciler) targetVMReasons(target *planapi.TargetVM) (reasons []string) {
+	if target.Memory != nil && target.Memory.Sign() <= 0 {
+		reasons = append(reasons, "memory must be > 0")
+	}
+	if target.InstanceType != nil {
+		if target.CPU != nil || target.Memory != nil {
+			reasons = append(
+				reasons,
+				"instanceType cannot be combined with cpu or memory")
+		}
+		reasons = append(
+			reasons,
+			r.matcherReasons(
+				"instanceType",
+				target.InstanceType,
+				planapi.InstanceTypeKind,
+				planapi.ClusterInstanceTypeKind)...)
+	}
+	if target.Preference != nil {
+		reasons = append(
+			reasons,
+			r.matcherReasons(
+				"preference",
+				target.Preference,
+				planapi.PreferenceKind,
+				planapi.ClusterPreferenceKind)...)
+	}
+	switch target.RunStrategy {
+	case "",
+		planapi.RunStrategyAlways,
+		planapi.RunStrategyHalted,
+		planapi.RunStrategyManual,
+		planapi.RunStrategyRerunOnFailure:
+	default:
+		reasons = append(
+			reasons,
+			fmt.Sprintf(
+				"runStrategy: %s unknown",
+				target.RunStrategy))
+	}
+	for _, labels := range []map[string]string{target.Labels, target.NodeSelector} {
+		for k, v := range labels {
+			for _, reason := range k8svalidation.IsQualifiedName(k) {
+				reasons = append(reasons, k+": "+reason)
+			}
+			for _, reason := range k8svalidation.IsValidLabelValue(v) {
+				reasons = append(reasons, k+": "+reason)
+			}
+		}
+	}
+	for k := range target.Annotations {
+		for _, reason := range k8svalidation.IsQualifiedName(k) {
+			reasons = append(reasons, k+": "+reason)
+		}
+	}
+
+	return
+}
+
+//
+// Find the reasons an instance type (or preference) matcher is not valid.
+func (r *Reconciler) matcherReasons(field string, matcher *planapi.Matcher, kinds ...string) (reasons []string) {
+	if matcher.Name == "" {
+		reasons = append(reasons, field+": name required")
+	}
+	if matcher.Kind == "" {
+		return
+	}
+	for _, kind := range kinds {
+		if matcher.Kind == kind {
+			return
+		}
+	}
+	reasons = append(
+		reasons,
+		fmt.Sprintf(
+			"%s: kind %s unknown",
+			field,
+			matcher.Kind))
+
+	return
+}
+
 //
 // Validate transfer network selection.
+//   1. Host and cluster selections are complete.
+//   2. The networks exist.
+//   3. The network resolved for each VM is reachable
+//      from the source host.
 func (r *Reconciler) validateTransferNetwork(plan *api.Plan) (err error) {
-	if plan.Spec.TransferNetwork == nil {
-		return
+	notSet := libcnd.Condition{
+		Type:     TransferNetNotValid,
+		Status:   True,
+		Category: Critical,
+		Reason:   NotSet,
+		Message:  "Transfer network selection requires exactly one of: `host` or `cluster`.",
+		Items:    []string{},
 	}
 	notFound := libcnd.Condition{
 		Type:     TransferNetNotValid,
@@ -456,20 +1274,123 @@ func (r *Reconciler) validateTransferNetwork(plan *api.Plan) (err error) {
 		Category: Critical,
 		Reason:   NotFound,
 		Message:  "Transfer network is not valid.",
+		Items:    []string{},
 	}
-	key := client.ObjectKey{
-		Namespace: plan.Spec.TransferNetwork.Namespace,
-		Name:      plan.Spec.TransferNetwork.Name,
+	notReachable := libcnd.Condition{
+		Type:     NetNotReachable,
+		Status:   True,
+		Category: Warn,
+		Reason:   NotValid,
+		Message:  "Transfer network may not be reachable from the source host.",
+		Items:    []string{},
 	}
-	netAttachDef := &net.NetworkAttachmentDefinition{}
-	err = r.Get(context.TODO(), key, netAttachDef)
-	if k8serr.IsNotFound(err) {
-		err = nil
-		plan.Status.SetCondition(notFound)
+	networks := []*core.ObjectReference{}
+	if plan.Spec.TransferNetwork != nil {
+		networks = append(networks, plan.Spec.TransferNetwork)
+	}
+	for i := range plan.Spec.TransferNetworks {
+		selected := &plan.Spec.TransferNetworks[i]
+		if (selected.Host == nil) == (selected.Cluster == nil) {
+			notSet.Items = append(
+				notSet.Items,
+				fmt.Sprintf("transferNetworks[%d]", i))
+		}
+		networks = append(networks, &selected.Network)
+	}
+	for i := range plan.Spec.VMs {
+		vm := &plan.Spec.VMs[i]
+		if vm.TransferNetwork != nil {
+			networks = append(networks, vm.TransferNetwork)
+		}
+	}
+	if len(networks) == 0 {
+		return
+	}
+	subnets := map[string][]*gonet.IPNet{}
+	for _, network := range networks {
+		key := client.ObjectKey{
+			Namespace: network.Namespace,
+			Name:      network.Name,
+		}
+		if _, found := subnets[key.String()]; found {
+			continue
+		}
+		netAttachDef := &net.NetworkAttachmentDefinition{}
+		err = r.Get(context.TODO(), key, netAttachDef)
+		if k8serr.IsNotFound(err) {
+			err = nil
+			notFound.Items = append(notFound.Items, key.String())
+			continue
+		}
+		if err != nil {
+			err = liberr.Wrap(err)
+			return
+		}
+		subnets[key.String()] = TransferSubnets(netAttachDef.Spec.Config)
+	}
+	defer func() {
+		for _, cnd := range []libcnd.Condition{notSet, notFound, notReachable} {
+			if len(cnd.Items) > 0 {
+				plan.Status.SetCondition(cnd)
+			}
+		}
+	}()
+	if len(notSet.Items) > 0 || len(notFound.Items) > 0 {
+		return
+	}
+	provider := plan.Referenced.Provider.Source
+	if provider == nil {
 		return
 	}
+	pAdapter, err := adapter.New(provider)
 	if err != nil {
-		err = liberr.Wrap(err)
+		return
+	}
+	validator, err := pAdapter.Validator(plan)
+	if err != nil {
+		return
+	}
+	transfer := TransferNetworks{
+		Plan:      plan,
+		Validator: validator,
+	}
+	for i := range plan.Spec.VMs {
+		vm := &plan.Spec.VMs[i]
+		network, nErr := transfer.Network(vm)
+		if nErr != nil {
+			if errors.As(nErr, &web.NotFoundError{}) {
+				continue
+			}
+			err = nErr
+			return
+		}
+		if network == nil {
+			continue
+		}
+		key := client.ObjectKey{
+			Namespace: network.Namespace,
+			Name:      network.Name,
+		}
+		vmSubnets := subnets[key.String()]
+		if len(vmSubnets) == 0 {
+			continue
+		}
+		ok, nErr := validator.TransferReachable(vm.Ref, vmSubnets)
+		if nErr != nil {
+			if errors.As(nErr, &web.NotFoundError{}) {
+				continue
+			}
+			err = nErr
+			return
+		}
+		if !ok {
+			notReachable.Items = append(
+				notReachable.Items,
+				fmt.Sprintf(
+					"%s: %s",
+					vm.String(),
+					key.String()))
+		}
 	}
 
 	return
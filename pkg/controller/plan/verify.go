@@ -0,0 +1,495 @@
+package plan
+
+import (
+	"context"
+	"fmt"
+	libcnd "github.com/konveyor/controller/pkg/condition"
+	liberr "github.com/konveyor/controller/pkg/error"
+	"github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1/plan"
+	batch "k8s.io/api/batch/v1"
+	core "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	cnv "kubevirt.io/client-go/api/v1"
+	"net"
+	"path"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//
+// Verification settings.
+const (
+	// TCP port dial timeout.
+	DialTimeout = time.Second * 3
+	// TCP port check job deadline.
+	PortCheckDeadline = time.Minute * 2
+)
+
+// Annotations
+const (
+	// the IP address checked by the port check job.
+	annAddress = "forklift.konveyor.io/address"
+)
+
+//
+// Prefix of the port check job termination message.
+// Distinguishes a completed check (with all ports responding)
+// from a message that has not been reported.
+const portsChecked = "checked:"
+
+//
+// Verify the migrated VM.
+// The VM is (optionally) started. The step is completed once
+// all of the checks have passed or the timeout has elapsed.
+// When the timeout has elapsed, the failure is either reported
+// as a step error or (advisory) as a VM condition.
+func (r *Migration) verify(vm *plan.VMStatus, step *plan.Step) (err error) {
+	verify := r.Plan.Spec.Verify
+	imp, found, err := r.findImport(vm)
+	if err != nil {
+		return
+	}
+	if !found {
+		step.AddError("Import CR not found.")
+		step.MarkCompleted()
+		return
+	}
+	if !step.MarkedStarted() {
+		step.MarkStarted()
+		vm.Verification = nil
+		vm.DeleteCondition(VerificationFailed)
+		if verify.Start {
+			err = r.kubevirt.StartVM(&imp)
+			if err != nil {
+				return
+			}
+		}
+	}
+	result, failures, err := r.verifyVM(vm, &imp)
+	if err != nil {
+		return
+	}
+	vm.Verification = result
+	if len(failures) == 0 {
+		step.Progress.Completed = step.Progress.Total
+		step.MarkCompleted()
+		err = r.kubevirt.DeletePortCheck(vm)
+		if err != nil {
+			return
+		}
+		r.Log.Info(
+			"Verification [PASSED]",
+			"vm",
+			vm.String())
+		return
+	}
+	timeout := time.Duration(verify.GetTimeout()) * time.Second
+	if time.Since(step.Started.Time) < timeout {
+		return
+	}
+	r.Log.Info(
+		"Verification [FAILED]",
+		"vm",
+		vm.String(),
+		"failures",
+		failures)
+	if verify.Advisory() {
+		vm.SetCondition(
+			libcnd.Condition{
+				Type:     VerificationFailed,
+				Status:   True,
+				Category: Warn,
+				Message:  "The migrated VM verification has FAILED.",
+				Items:    failures,
+				Durable:  true,
+			})
+	} else {
+		step.AddError(failures...)
+	}
+	step.Progress.Completed = step.Progress.Total
+	step.MarkCompleted()
+	err = r.kubevirt.DeletePortCheck(vm)
+
+	return
+}
+
+//
+// Check the migrated VM.
+// Returns the results and a list of failures.
+func (r *Migration) verifyVM(vm *plan.VMStatus, imp *VmImport) (result *plan.Verification, failures []string, err error) {
+	verify := r.Plan.Spec.Verify
+	result = &plan.Verification{}
+	vmi, found, err := r.kubevirt.VMI(imp)
+	if err != nil {
+		return
+	}
+	if !found {
+		failures = append(failures, "VMI not found.")
+		return
+	}
+	result.Running = vmi.Status.Phase == cnv.Running
+	if !result.Running {
+		failures = append(failures, "VMI not running.")
+		return
+	}
+	for _, cnd := range vmi.Status.Conditions {
+		if cnd.Type == cnv.VirtualMachineInstanceAgentConnected {
+			result.GuestAgent = cnd.Status == core.ConditionTrue
+			break
+		}
+	}
+	if verify.GuestAgent && !result.GuestAgent {
+		failures = append(failures, "Guest agent not connected.")
+	}
+	reported := map[string]bool{}
+	for _, nic := range vmi.Status.Interfaces {
+		ips := nic.IPs
+		if len(ips) == 0 && nic.IP != "" {
+			ips = []string{nic.IP}
+		}
+		for _, ip := range ips {
+			if !reported[ip] {
+				reported[ip] = true
+				result.IPs = append(result.IPs, ip)
+			}
+		}
+	}
+	if verify.IPs {
+		expected, gErr := r.expectedIPs(vm)
+		if gErr != nil {
+			err = liberr.Wrap(gErr)
+			return
+		}
+		for _, ip := range expected {
+			if !reported[ip] {
+				result.MissingIPs = append(result.MissingIPs, ip)
+			}
+		}
+		if len(result.MissingIPs) > 0 {
+			failures = append(
+				failures,
+				fmt.Sprintf(
+					"IP addresses not reported: %s.",
+					strings.Join(result.MissingIPs, ", ")))
+		}
+	}
+	// The ports are not checked when the VM has not been
+	// started by the migration or the verification.
+	started := verify.Start || imp.Annotations[annStartVM] == "true"
+	if len(verify.Ports) > 0 && started {
+		address := ""
+		for _, ip := range result.IPs {
+			if !linkLocal(ip) {
+				address = ip
+				break
+			}
+		}
+		if address == "" {
+			failures = append(failures, "TCP ports not checked: IP address not reported.")
+			return
+		}
+		closed, done, cErr := r.kubevirt.CheckPorts(vm, address, verify.Ports)
+		if cErr != nil {
+			err = cErr
+			return
+		}
+		if !done {
+			failures = append(failures, "TCP port check in progress.")
+			return
+		}
+		for _, port := range verify.Ports {
+			if closed[port] {
+				result.ClosedPorts = append(result.ClosedPorts, port)
+			} else {
+				result.OpenPorts = append(result.OpenPorts, port)
+			}
+		}
+		if len(result.ClosedPorts) > 0 {
+			closed := []string{}
+			for _, port := range result.ClosedPorts {
+				closed = append(closed, strconv.Itoa(port))
+			}
+			failures = append(
+				failures,
+				fmt.Sprintf(
+					"TCP ports not responding: %s.",
+					strings.Join(closed, ", ")))
+		}
+	}
+
+	return
+}
+
+//
+// IP addresses expected to be reported by the VMI.
+// The addresses reported by the source guest are replaced
+// as specified by the plan IP map. Link-local addresses
+// are assigned by the guest on each interface and are
+// not expected to be preserved.
+func (r *Migration) expectedIPs(vm *plan.VMStatus) (list []string, err error) {
+	reported, err := r.builder.GuestIPs(vm.Ref)
+	if err != nil {
+		return
+	}
+	for _, ip := range reported {
+		if linkLocal(ip) {
+			continue
+		}
+		if r.Plan.Spec.StaticIPs != nil {
+			if mapping, found := r.Plan.Spec.StaticIPs.Find(ip); found {
+				if address, valid := mapping.DestinationIP(); valid {
+					ip = address
+				}
+			}
+		}
+		list = append(list, ip)
+	}
+
+	return
+}
+
+//
+// Determine whether an IP address is link-local.
+func linkLocal(address string) bool {
+	ip := net.ParseIP(address)
+	return ip != nil && (ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast())
+}
+
+//
+// Check the TCP ports of the migrated VM.
+// The ports are checked (asynchronously) by a job created in
+// the target namespace. The job reports the ports that are not
+// responding in the termination message. The job is deleted
+// once the result has been collected so that the ports are
+// checked again (as needed) on the next reconcile.
+// A job that has not reported the result is inconclusive and
+// is deleted so that the ports are checked again.
+// Returns the ports not responding and whether the check is done.
+func (r *KubeVirt) CheckPorts(vm *plan.VMStatus, address string, ports []int) (closed map[int]bool, done bool, err error) {
+	job, found, err := r.portCheckJob(vm)
+	if err != nil {
+		return
+	}
+	if found && job.Annotations[annAddress] != address {
+		err = r.DeletePortCheck(vm)
+		if err != nil {
+			return
+		}
+		found = false
+	}
+	if !found {
+		err = r.createPortCheckJob(vm, address, ports)
+		return
+	}
+	if job.Status.Succeeded == 0 && job.Status.Failed == 0 {
+		return
+	}
+	closed = map[int]bool{}
+	if job.Status.Succeeded > 0 {
+		message, found, mErr := r.terminationMessage(job)
+		if mErr != nil {
+			err = mErr
+			return
+		}
+		if !found || !strings.HasPrefix(message, portsChecked) {
+			r.Log.Info(
+				"Port check result not reported.",
+				"vm",
+				vm.String())
+			closed = nil
+			err = r.DeletePortCheck(vm)
+			return
+		}
+		message = strings.TrimPrefix(message, portsChecked)
+		for _, field := range strings.Fields(message) {
+			port, pErr := strconv.Atoi(field)
+			if pErr == nil {
+				closed[port] = true
+			}
+		}
+	} else {
+		for _, port := range ports {
+			closed[port] = true
+		}
+	}
+	err = r.DeletePortCheck(vm)
+	if err != nil {
+		return
+	}
+	done = true
+
+	return
+}
+
+//
+// Delete the TCP port check job(s) for the VM.
+func (r *KubeVirt) DeletePortCheck(vm *plan.VMStatus) (err error) {
+	list := &batch.JobList{}
+	err = r.Destination.Client.List(
+		context.TODO(),
+		list,
+		&client.ListOptions{
+			LabelSelector: labels.SelectorFromSet(r.vmLabels(vm.Ref)),
+			Namespace:     r.Plan.Spec.TargetNamespace,
+		})
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	for i := range list.Items {
+		job := &list.Items[i]
+		err = r.Destination.Client.Delete(
+			context.TODO(),
+			job,
+			client.PropagationPolicy(meta.DeletePropagationBackground))
+		if err != nil {
+			if k8serr.IsNotFound(err) {
+				err = nil
+			} else {
+				err = liberr.Wrap(err)
+				return
+			}
+		}
+		r.Log.V(1).Info(
+			"Deleted (port check) job.",
+			"job",
+			path.Join(
+				job.Namespace,
+				job.Name))
+	}
+
+	return
+}
+
+//
+// Find the TCP port check job for the VM.
+func (r *KubeVirt) portCheckJob(vm *plan.VMStatus) (job *batch.Job, found bool, err error) {
+	list := &batch.JobList{}
+	err = r.Destination.Client.List(
+		context.TODO(),
+		list,
+		&client.ListOptions{
+			LabelSelector: labels.SelectorFromSet(r.vmLabels(vm.Ref)),
+			Namespace:     r.Plan.Spec.TargetNamespace,
+		})
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	if len(list.Items) > 0 {
+		job = &list.Items[0]
+		found = true
+	}
+
+	return
+}
+
+//
+// Create the TCP port check job for the VM.
+func (r *KubeVirt) createPortCheckJob(vm *plan.VMStatus, address string, ports []int) (err error) {
+	portList := []string{}
+	for _, port := range ports {
+		portList = append(portList, strconv.Itoa(port))
+	}
+	script := fmt.Sprintf(
+		`closed=""
+for port in $PORTS; do
+  timeout %d bash -c "</dev/tcp/$ADDRESS/$port" 2>/dev/null || closed="$closed $port"
+done
+echo -n "%s$closed" > /dev/termination-log`,
+		int(DialTimeout.Seconds()),
+		portsChecked)
+	backoff := int32(0)
+	deadline := int64(PortCheckDeadline.Seconds())
+	job := &batch.Job{
+		ObjectMeta: meta.ObjectMeta{
+			Namespace: r.Plan.Spec.TargetNamespace,
+			GenerateName: strings.ToLower(
+				strings.Join([]string{
+					r.Plan.Name,
+					vm.ID,
+					"verify"},
+					"-") + "-"),
+			Labels: r.vmLabels(vm.Ref),
+			Annotations: map[string]string{
+				annAddress: address,
+			},
+		},
+		Spec: batch.JobSpec{
+			BackoffLimit:          &backoff,
+			ActiveDeadlineSeconds: &deadline,
+			Template: core.PodTemplateSpec{
+				Spec: core.PodSpec{
+					RestartPolicy: core.RestartPolicyNever,
+					Containers: []core.Container{
+						{
+							Name:    "verify",
+							Image:   Settings.Migration.VerifyImage,
+							Command: []string{"/bin/bash", "-c", script},
+							Env: []core.EnvVar{
+								{
+									Name:  "ADDRESS",
+									Value: address,
+								},
+								{
+									Name:  "PORTS",
+									Value: strings.Join(portList, " "),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	err = r.Destination.Client.Create(context.TODO(), job)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+
+	r.Log.Info(
+		"Created (port check) job.",
+		"job",
+		path.Join(
+			job.Namespace,
+			job.Name))
+
+	return
+}
+
+//
+// Get the termination message of the (completed) job pod.
+// Returns whether a terminated pod was found.
+func (r *KubeVirt) terminationMessage(job *batch.Job) (message string, found bool, err error) {
+	list := &core.PodList{}
+	err = r.Destination.Client.List(
+		context.TODO(),
+		list,
+		&client.ListOptions{
+			LabelSelector: labels.SelectorFromSet(
+				map[string]string{
+					"job-name": job.Name,
+				}),
+			Namespace: job.Namespace,
+		})
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	for _, pod := range list.Items {
+		for _, status := range pod.Status.ContainerStatuses {
+			if terminated := status.State.Terminated; terminated != nil && terminated.ExitCode == 0 {
+				message = terminated.Message
+				found = true
+				return
+			}
+		}
+	}
+
+	return
+}
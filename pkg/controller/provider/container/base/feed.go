@@ -0,0 +1,280 @@
+package base
+
+import (
+	"context"
+	"github.com/go-logr/logr"
+	liberr "github.com/konveyor/controller/pkg/error"
+	libmodel "github.com/konveyor/controller/pkg/inventory/model"
+	libref "github.com/konveyor/controller/pkg/ref"
+	api "github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1"
+	model "github.com/konveyor/forklift-controller/pkg/controller/provider/model/base"
+	"github.com/konveyor/forklift-controller/pkg/settings"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+//
+// Application settings.
+var Settings = &settings.Settings
+
+//
+// Change feed.
+const (
+	// Prune interval.
+	PruneInterval = time.Minute
+	// Revisions reserved (persisted) at a time.
+	RevisionBlock = 1000
+)
+
+//
+// Inventory change feed.
+// Model events are recorded as changes in (feed) revision order.
+// The revision is persisted (in blocks) in a file next to the DB and
+// is seeded using the current time (when greater) so that revisions are
+// not reused when the DB is rebuilt. The first revision recorded in the
+// DB is the feed base. Changes older than the retention window are
+// pruned periodically.
+type ChangeFeed struct {
+	// DB.
+	DB libmodel.DB
+	// Logger.
+	Log logr.Logger
+	// Mutex.
+	mutex sync.Mutex
+	// Revision file path.
+	path string
+	// Last revision.
+	revision int64
+	// Last revision reserved.
+	reserved int64
+}
+
+//
+// New change feed.
+func NewChangeFeed(db libmodel.DB, provider *api.Provider, log logr.Logger) (r *ChangeFeed) {
+	r = &ChangeFeed{
+		DB:  db,
+		Log: log.WithName("feed"),
+		path: filepath.Join(
+			Settings.Inventory.WorkingDir,
+			provider.Namespace,
+			provider.Name+".feed"),
+	}
+	r.revision = r.load()
+	if seed := time.Now().UnixNano() / int64(time.Microsecond); seed > r.revision {
+		r.revision = seed
+	}
+	r.reserved = r.revision
+
+	return
+}
+
+//
+// Start the feed.
+// Records the feed base and prunes changes
+// periodically until the context is done.
+func (r *ChangeFeed) Start(ctx context.Context) {
+	err := r.DB.Insert(
+		&model.Feed{
+			ID:   model.FeedID,
+			Base: r.revision + 1,
+		})
+	if err != nil {
+		r.Log.Error(err, "Feed (insert) failed.")
+	}
+	go func() {
+		ticker := time.NewTicker(PruneInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				r.prune()
+			}
+		}
+	}()
+}
+
+//
+// Watch the specified models.
+// Returns the list of model watches.
+func (r *ChangeFeed) Watch(models ...libmodel.Model) (list []*libmodel.Watch) {
+	for _, m := range models {
+		w, err := r.DB.Watch(m, &ChangeEventHandler{feed: r})
+		if err != nil {
+			r.Log.Error(
+				err,
+				"create (change) watch failed.",
+				"kind",
+				libref.ToKind(m))
+		} else {
+			list = append(list, w)
+		}
+	}
+
+	return
+}
+
+//
+// Record a change.
+func (r *ChangeFeed) record(operation string, m libmodel.Model) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	change := &model.Change{
+		Revision:      r.revision + 1,
+		Kind:          libref.ToKind(m),
+		Operation:     operation,
+		ID:            m.Pk(),
+		ModelRevision: r.modelRevision(m),
+		Recorded:      time.Now().Unix(),
+	}
+	if change.Revision > r.reserved {
+		r.reserve(change.Revision)
+	}
+	err := r.DB.Insert(change)
+	if err != nil {
+		r.Log.Error(err, "Change (insert) failed.")
+		return
+	}
+
+	r.revision = change.Revision
+
+	r.Log.V(4).Info(
+		"Change recorded.",
+		"change",
+		change.String())
+}
+
+//
+// Reserve (persist) a block of revisions.
+func (r *ChangeFeed) reserve(revision int64) {
+	reserved := revision + RevisionBlock
+	err := ioutil.WriteFile(
+		r.path,
+		[]byte(strconv.FormatInt(reserved, 10)),
+		0644)
+	if err != nil {
+		r.Log.Error(liberr.Wrap(err), "Revision (persist) failed.")
+		return
+	}
+
+	r.reserved = reserved
+}
+
+//
+// Load the persisted revision.
+func (r *ChangeFeed) load() (revision int64) {
+	b, err := ioutil.ReadFile(r.path)
+	if err != nil {
+		return
+	}
+	revision, err = strconv.ParseInt(strings.TrimSpace(string(b)), 10, 64)
+	if err != nil {
+		r.Log.Error(liberr.Wrap(err), "Revision (load) failed.")
+		revision = 0
+	}
+
+	return
+}
+
+//
+// Delete changes older than the retention window.
+func (r *ChangeFeed) prune() {
+	retention := time.Minute * time.Duration(Settings.Inventory.ChangeRetention)
+	expired := time.Now().Add(-retention).Unix()
+	list := []model.Change{}
+	err := r.DB.List(
+		&list,
+		libmodel.ListOptions{
+			Predicate: libmodel.Lt("Recorded", expired),
+		})
+	if err != nil {
+		r.Log.Error(err, "Change (list) failed.")
+		return
+	}
+	if len(list) == 0 {
+		return
+	}
+	tx, err := r.DB.Begin()
+	if err != nil {
+		r.Log.Error(err, "Begin tx failed.")
+		return
+	}
+	defer func() {
+		_ = tx.End()
+	}()
+	for i := range list {
+		err = tx.Delete(&list[i])
+		if err != nil {
+			r.Log.Error(err, "Change (delete) failed.")
+			return
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		r.Log.Error(err, "Tx commit failed.")
+		return
+	}
+
+	r.Log.V(1).Info(
+		"Changes pruned.",
+		"count",
+		len(list))
+}
+
+//
+// Get the model revision.
+// Models are expected to have a `Revision` field.
+func (r *ChangeFeed) modelRevision(m libmodel.Model) (revision int64) {
+	v := reflect.ValueOf(m)
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return
+	}
+	f := v.FieldByName("Revision")
+	if f.IsValid() && f.Kind() == reflect.Int64 {
+		revision = f.Int()
+	}
+
+	return
+}
+
+//
+// Watch for model changes and record them in the feed.
+type ChangeEventHandler struct {
+	libmodel.StockEventHandler
+	// Change feed.
+	feed *ChangeFeed
+}
+
+//
+// Model created.
+func (r *ChangeEventHandler) Created(event libmodel.Event) {
+	r.feed.record(model.Created, event.Model)
+}
+
+//
+// Model updated.
+func (r *ChangeEventHandler) Updated(event libmodel.Event) {
+	r.feed.record(model.Updated, event.Updated)
+}
+
+//
+// Model deleted.
+func (r *ChangeEventHandler) Deleted(event libmodel.Event) {
+	r.feed.record(model.Deleted, event.Model)
+}
+
+//
+// Report errors.
+func (r *ChangeEventHandler) Error(err error) {
+	r.feed.Log.Error(liberr.Wrap(err), err.Error())
+}
@@ -0,0 +1,93 @@
+package base
+
+import (
+	libmodel "github.com/konveyor/controller/pkg/inventory/model"
+	"github.com/konveyor/controller/pkg/logging"
+	api "github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1"
+	model "github.com/konveyor/forklift-controller/pkg/controller/provider/model/base"
+	"github.com/onsi/gomega"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newFeed(g *gomega.GomegaWithT, dir string) (feed *ChangeFeed, db libmodel.DB) {
+	Settings.Inventory.WorkingDir = dir
+	provider := &api.Provider{}
+	provider.Namespace = "test"
+	provider.Name = "vsphere"
+	err := os.MkdirAll(filepath.Join(dir, provider.Namespace), 0755)
+	g.Expect(err).To(gomega.BeNil())
+	db = libmodel.New(
+		filepath.Join(dir, provider.Namespace, provider.Name+".db"),
+		&model.Change{},
+		&model.Feed{})
+	err = db.Open(true)
+	g.Expect(err).To(gomega.BeNil())
+	feed = NewChangeFeed(db, provider, logging.WithName("test"))
+	return
+}
+
+func TestFeedRecord(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	dir, err := ioutil.TempDir("", "feed")
+	g.Expect(err).To(gomega.BeNil())
+	defer os.RemoveAll(dir)
+
+	feed, db := newFeed(g, dir)
+	base := feed.revision
+	feed.record(model.Created, &model.Feed{ID: "1"})
+	feed.record(model.Updated, &model.Feed{ID: "1"})
+	g.Expect(feed.revision).To(gomega.Equal(base + 2))
+	list := []model.Change{}
+	err = db.List(&list, libmodel.ListOptions{Sort: []int{1}})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(list).To(gomega.HaveLen(2))
+	g.Expect(list[0].Revision).To(gomega.Equal(base + 1))
+	g.Expect(list[0].Operation).To(gomega.Equal(model.Created))
+	g.Expect(list[0].ID).To(gomega.Equal("1"))
+	g.Expect(list[1].Revision).To(gomega.Equal(base + 2))
+
+	// The reserved block is persisted.
+	g.Expect(feed.reserved).To(gomega.Equal(base + 1 + RevisionBlock))
+	g.Expect(feed.load()).To(gomega.Equal(feed.reserved))
+	_ = db.Close(true)
+
+	// Revisions are not reused after a restart
+	// even when the clock is behind.
+	err = ioutil.WriteFile(feed.path, []byte("9999999999999999"), 0644)
+	g.Expect(err).To(gomega.BeNil())
+	feed, db = newFeed(g, dir)
+	defer db.Close(true)
+	g.Expect(feed.revision).To(gomega.Equal(int64(9999999999999999)))
+}
+
+func TestFeedPrune(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	dir, err := ioutil.TempDir("", "feed")
+	g.Expect(err).To(gomega.BeNil())
+	defer os.RemoveAll(dir)
+
+	feed, db := newFeed(g, dir)
+	defer db.Close(true)
+	Settings.Inventory.ChangeRetention = 10
+	old := time.Now().Add(-time.Hour).Unix()
+	for i, recorded := range []int64{old, old, time.Now().Unix()} {
+		err = db.Insert(
+			&model.Change{
+				Revision: int64(i + 1),
+				Kind:     "Feed",
+				ID:       "1",
+				Recorded: recorded,
+			})
+		g.Expect(err).To(gomega.BeNil())
+	}
+	feed.prune()
+	list := []model.Change{}
+	err = db.List(&list, libmodel.ListOptions{})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(list).To(gomega.HaveLen(1))
+	g.Expect(list[0].Revision).To(gomega.Equal(int64(3)))
+}
@@ -10,8 +10,11 @@ import (
 	model "github.com/konveyor/forklift-controller/pkg/controller/provider/model/ocp"
 	core "k8s.io/api/core/v1"
 	storage "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	cnv "kubevirt.io/client-go/api/v1"
+	cdi "kubevirt.io/containerized-data-importer/pkg/apis/core/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 )
 
@@ -119,6 +122,231 @@ func (r *StorageClass) Generic(e event.GenericEvent) bool {
 	return false
 }
 
+//
+// CSIDriver
+type CSIDriver struct {
+	libocp.BaseCollection
+	log logr.Logger
+}
+
+//
+// Get the kubernetes object being collected.
+func (r *CSIDriver) Object() runtime.Object {
+	return &storage.CSIDriver{}
+}
+
+//
+// Reconcile.
+// Achieve initial consistency.
+func (r *CSIDriver) Reconcile(ctx context.Context) (err error) {
+	pClient := r.Reconciler.Client()
+	list := &storage.CSIDriverList{}
+	err = pClient.List(context.TODO(), list)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	db := r.Reconciler.DB()
+	tx, err := db.Begin()
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	defer tx.End()
+	for _, resource := range list.Items {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+		m := &model.CSIDriver{}
+		m.With(&resource)
+		r.Reconciler.UpdateThreshold(m)
+		r.log.Info("Create", libref.ToKind(m), m.String())
+		err = tx.Insert(m)
+		if err != nil {
+			err = liberr.Wrap(err)
+			return
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+
+	return
+}
+
+//
+// Resource created watch event.
+func (r *CSIDriver) Create(e event.CreateEvent) bool {
+	object, cast := e.Object.(*storage.CSIDriver)
+	if !cast {
+		return false
+	}
+	m := &model.CSIDriver{}
+	m.With(object)
+	r.Reconciler.Create(m)
+
+	return false
+}
+
+//
+// Resource updated watch event.
+func (r *CSIDriver) Update(e event.UpdateEvent) bool {
+	object, cast := e.ObjectNew.(*storage.CSIDriver)
+	if !cast {
+		return false
+	}
+	m := &model.CSIDriver{}
+	m.With(object)
+	r.Reconciler.Update(m)
+
+	return false
+}
+
+//
+// Resource deleted watch event.
+func (r *CSIDriver) Delete(e event.DeleteEvent) bool {
+	object, cast := e.Object.(*storage.CSIDriver)
+	if !cast {
+		return false
+	}
+	m := &model.CSIDriver{}
+	m.With(object)
+	r.Reconciler.Delete(m)
+
+	return false
+}
+
+//
+// Ignored.
+func (r *CSIDriver) Generic(e event.GenericEvent) bool {
+	return false
+}
+
+//
+// StorageProfile (CDI).
+// Collected as unstructured: not defined by the vendored CDI API.
+var StorageProfileGVK = schema.GroupVersionKind{
+	Group:   "cdi.kubevirt.io",
+	Version: "v1beta1",
+	Kind:    "StorageProfile",
+}
+
+//
+// StorageProfile
+type StorageProfile struct {
+	libocp.BaseCollection
+	log logr.Logger
+}
+
+//
+// Get the kubernetes object being collected.
+func (r *StorageProfile) Object() runtime.Object {
+	object := &unstructured.Unstructured{}
+	object.SetGroupVersionKind(StorageProfileGVK)
+	return object
+}
+
+//
+// Reconcile.
+// Achieve initial consistency.
+func (r *StorageProfile) Reconcile(ctx context.Context) (err error) {
+	pClient := r.Reconciler.Client()
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(
+		schema.GroupVersionKind{
+			Group:   StorageProfileGVK.Group,
+			Version: StorageProfileGVK.Version,
+			Kind:    StorageProfileGVK.Kind + "List",
+		})
+	err = pClient.List(context.TODO(), list)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	db := r.Reconciler.DB()
+	tx, err := db.Begin()
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	defer tx.End()
+	for _, resource := range list.Items {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+		m := &model.StorageProfile{}
+		m.With(&resource)
+		r.Reconciler.UpdateThreshold(m)
+		r.log.Info("Create", libref.ToKind(m), m.String())
+		err = tx.Insert(m)
+		if err != nil {
+			err = liberr.Wrap(err)
+			return
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+
+	return
+}
+
+//
+// Resource created watch event.
+func (r *StorageProfile) Create(e event.CreateEvent) bool {
+	object, cast := e.Object.(*unstructured.Unstructured)
+	if !cast {
+		return false
+	}
+	m := &model.StorageProfile{}
+	m.With(object)
+	r.Reconciler.Create(m)
+
+	return false
+}
+
+//
+// Resource updated watch event.
+func (r *StorageProfile) Update(e event.UpdateEvent) bool {
+	object, cast := e.ObjectNew.(*unstructured.Unstructured)
+	if !cast {
+		return false
+	}
+	m := &model.StorageProfile{}
+	m.With(object)
+	r.Reconciler.Update(m)
+
+	return false
+}
+
+//
+// Resource deleted watch event.
+func (r *StorageProfile) Delete(e event.DeleteEvent) bool {
+	object, cast := e.Object.(*unstructured.Unstructured)
+	if !cast {
+		return false
+	}
+	m := &model.StorageProfile{}
+	m.With(object)
+	r.Reconciler.Delete(m)
+
+	return false
+}
+
+//
+// Ignored.
+func (r *StorageProfile) Generic(e event.GenericEvent) bool {
+	return false
+}
+
 //
 // NetworkAttachmentDefinition
 type NetworkAttachmentDefinition struct {
@@ -430,3 +658,627 @@ func (r *VM) Delete(e event.DeleteEvent) bool {
 func (r *VM) Generic(e event.GenericEvent) bool {
 	return false
 }
+
+//
+// ResourceQuota
+type ResourceQuota struct {
+	libocp.BaseCollection
+	log logr.Logger
+}
+
+//
+// Get the kubernetes object being collected.
+func (r *ResourceQuota) Object() runtime.Object {
+	return &core.ResourceQuota{}
+}
+
+//
+// Reconcile.
+// Achieve initial consistency.
+func (r *ResourceQuota) Reconcile(ctx context.Context) (err error) {
+	pClient := r.Reconciler.Client()
+	list := &core.ResourceQuotaList{}
+	err = pClient.List(context.TODO(), list)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	db := r.Reconciler.DB()
+	tx, err := db.Begin()
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	defer tx.End()
+	for _, resource := range list.Items {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+		m := &model.ResourceQuota{}
+		m.With(&resource)
+		r.Reconciler.UpdateThreshold(m)
+		r.log.Info("Create", libref.ToKind(m), m.String())
+		err = tx.Insert(m)
+		if err != nil {
+			err = liberr.Wrap(err)
+			return
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+
+	return
+}
+
+//
+// Resource created watch event.
+func (r *ResourceQuota) Create(e event.CreateEvent) bool {
+	object, cast := e.Object.(*core.ResourceQuota)
+	if !cast {
+		return false
+	}
+	m := &model.ResourceQuota{}
+	m.With(object)
+	r.Reconciler.Create(m)
+
+	return false
+}
+
+//
+// Resource updated watch event.
+func (r *ResourceQuota) Update(e event.UpdateEvent) bool {
+	object, cast := e.ObjectNew.(*core.ResourceQuota)
+	if !cast {
+		return false
+	}
+	m := &model.ResourceQuota{}
+	m.With(object)
+	r.Reconciler.Update(m)
+
+	return false
+}
+
+//
+// Resource deleted watch event.
+func (r *ResourceQuota) Delete(e event.DeleteEvent) bool {
+	object, cast := e.Object.(*core.ResourceQuota)
+	if !cast {
+		return false
+	}
+	m := &model.ResourceQuota{}
+	m.With(object)
+	r.Reconciler.Delete(m)
+
+	return false
+}
+
+//
+// Ignored.
+func (r *ResourceQuota) Generic(e event.GenericEvent) bool {
+	return false
+}
+
+//
+// LimitRange
+type LimitRange struct {
+	libocp.BaseCollection
+	log logr.Logger
+}
+
+//
+// Get the kubernetes object being collected.
+func (r *LimitRange) Object() runtime.Object {
+	return &core.LimitRange{}
+}
+
+//
+// Reconcile.
+// Achieve initial consistency.
+func (r *LimitRange) Reconcile(ctx context.Context) (err error) {
+	pClient := r.Reconciler.Client()
+	list := &core.LimitRangeList{}
+	err = pClient.List(context.TODO(), list)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	db := r.Reconciler.DB()
+	tx, err := db.Begin()
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	defer tx.End()
+	for _, resource := range list.Items {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+		m := &model.LimitRange{}
+		m.With(&resource)
+		r.Reconciler.UpdateThreshold(m)
+		r.log.Info("Create", libref.ToKind(m), m.String())
+		err = tx.Insert(m)
+		if err != nil {
+			err = liberr.Wrap(err)
+			return
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+
+	return
+}
+
+//
+// Resource created watch event.
+func (r *LimitRange) Create(e event.CreateEvent) bool {
+	object, cast := e.Object.(*core.LimitRange)
+	if !cast {
+		return false
+	}
+	m := &model.LimitRange{}
+	m.With(object)
+	r.Reconciler.Create(m)
+
+	return false
+}
+
+//
+// Resource updated watch event.
+func (r *LimitRange) Update(e event.UpdateEvent) bool {
+	object, cast := e.ObjectNew.(*core.LimitRange)
+	if !cast {
+		return false
+	}
+	m := &model.LimitRange{}
+	m.With(object)
+	r.Reconciler.Update(m)
+
+	return false
+}
+
+//
+// Resource deleted watch event.
+func (r *LimitRange) Delete(e event.DeleteEvent) bool {
+	object, cast := e.Object.(*core.LimitRange)
+	if !cast {
+		return false
+	}
+	m := &model.LimitRange{}
+	m.With(object)
+	r.Reconciler.Delete(m)
+
+	return false
+}
+
+//
+// Ignored.
+func (r *LimitRange) Generic(e event.GenericEvent) bool {
+	return false
+}
+
+//
+// Node
+type Node struct {
+	libocp.BaseCollection
+	log logr.Logger
+}
+
+//
+// Get the kubernetes object being collected.
+func (r *Node) Object() runtime.Object {
+	return &core.Node{}
+}
+
+//
+// Reconcile.
+// Achieve initial consistency.
+func (r *Node) Reconcile(ctx context.Context) (err error) {
+	pClient := r.Reconciler.Client()
+	list := &core.NodeList{}
+	err = pClient.List(context.TODO(), list)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	db := r.Reconciler.DB()
+	tx, err := db.Begin()
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	defer tx.End()
+	for _, resource := range list.Items {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+		m := &model.Node{}
+		m.With(&resource)
+		r.Reconciler.UpdateThreshold(m)
+		r.log.Info("Create", libref.ToKind(m), m.String())
+		err = tx.Insert(m)
+		if err != nil {
+			err = liberr.Wrap(err)
+			return
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+
+	return
+}
+
+//
+// Resource created watch event.
+func (r *Node) Create(e event.CreateEvent) bool {
+	object, cast := e.Object.(*core.Node)
+	if !cast {
+		return false
+	}
+	m := &model.Node{}
+	m.With(object)
+	r.Reconciler.Create(m)
+
+	return false
+}
+
+//
+// Resource updated watch event.
+func (r *Node) Update(e event.UpdateEvent) bool {
+	object, cast := e.ObjectNew.(*core.Node)
+	if !cast {
+		return false
+	}
+	m := &model.Node{}
+	m.With(object)
+	r.Reconciler.Update(m)
+
+	return false
+}
+
+//
+// Resource deleted watch event.
+func (r *Node) Delete(e event.DeleteEvent) bool {
+	object, cast := e.Object.(*core.Node)
+	if !cast {
+		return false
+	}
+	m := &model.Node{}
+	m.With(object)
+	r.Reconciler.Delete(m)
+
+	return false
+}
+
+//
+// Ignored.
+func (r *Node) Generic(e event.GenericEvent) bool {
+	return false
+}
+
+//
+// PersistentVolumeClaim
+type PersistentVolumeClaim struct {
+	libocp.BaseCollection
+	log logr.Logger
+}
+
+//
+// Get the kubernetes object being collected.
+func (r *PersistentVolumeClaim) Object() runtime.Object {
+	return &core.PersistentVolumeClaim{}
+}
+
+//
+// Reconcile.
+// Achieve initial consistency.
+func (r *PersistentVolumeClaim) Reconcile(ctx context.Context) (err error) {
+	pClient := r.Reconciler.Client()
+	list := &core.PersistentVolumeClaimList{}
+	err = pClient.List(context.TODO(), list)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	db := r.Reconciler.DB()
+	tx, err := db.Begin()
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	defer tx.End()
+	for _, resource := range list.Items {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+		m := &model.PersistentVolumeClaim{}
+		m.With(&resource)
+		r.Reconciler.UpdateThreshold(m)
+		r.log.Info("Create", libref.ToKind(m), m.String())
+		err = tx.Insert(m)
+		if err != nil {
+			err = liberr.Wrap(err)
+			return
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+
+	return
+}
+
+//
+// Resource created watch event.
+func (r *PersistentVolumeClaim) Create(e event.CreateEvent) bool {
+	object, cast := e.Object.(*core.PersistentVolumeClaim)
+	if !cast {
+		return false
+	}
+	m := &model.PersistentVolumeClaim{}
+	m.With(object)
+	r.Reconciler.Create(m)
+
+	return false
+}
+
+//
+// Resource updated watch event.
+func (r *PersistentVolumeClaim) Update(e event.UpdateEvent) bool {
+	object, cast := e.ObjectNew.(*core.PersistentVolumeClaim)
+	if !cast {
+		return false
+	}
+	m := &model.PersistentVolumeClaim{}
+	m.With(object)
+	r.Reconciler.Update(m)
+
+	return false
+}
+
+//
+// Resource deleted watch event.
+func (r *PersistentVolumeClaim) Delete(e event.DeleteEvent) bool {
+	object, cast := e.Object.(*core.PersistentVolumeClaim)
+	if !cast {
+		return false
+	}
+	m := &model.PersistentVolumeClaim{}
+	m.With(object)
+	r.Reconciler.Delete(m)
+
+	return false
+}
+
+//
+// Ignored.
+func (r *PersistentVolumeClaim) Generic(e event.GenericEvent) bool {
+	return false
+}
+
+//
+// DataVolume
+type DataVolume struct {
+	libocp.BaseCollection
+	log logr.Logger
+}
+
+//
+// Get the kubernetes object being collected.
+func (r *DataVolume) Object() runtime.Object {
+	return &cdi.DataVolume{}
+}
+
+//
+// Reconcile.
+// Achieve initial consistency.
+func (r *DataVolume) Reconcile(ctx context.Context) (err error) {
+	pClient := r.Reconciler.Client()
+	list := &cdi.DataVolumeList{}
+	err = pClient.List(context.TODO(), list)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	db := r.Reconciler.DB()
+	tx, err := db.Begin()
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	defer tx.End()
+	for _, resource := range list.Items {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+		m := &model.DataVolume{}
+		m.With(&resource)
+		r.Reconciler.UpdateThreshold(m)
+		r.log.Info("Create", libref.ToKind(m), m.String())
+		err = tx.Insert(m)
+		if err != nil {
+			err = liberr.Wrap(err)
+			return
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+
+	return
+}
+
+//
+// Resource created watch event.
+func (r *DataVolume) Create(e event.CreateEvent) bool {
+	object, cast := e.Object.(*cdi.DataVolume)
+	if !cast {
+		return false
+	}
+	m := &model.DataVolume{}
+	m.With(object)
+	r.Reconciler.Create(m)
+
+	return false
+}
+
+//
+// Resource updated watch event.
+func (r *DataVolume) Update(e event.UpdateEvent) bool {
+	object, cast := e.ObjectNew.(*cdi.DataVolume)
+	if !cast {
+		return false
+	}
+	m := &model.DataVolume{}
+	m.With(object)
+	r.Reconciler.Update(m)
+
+	return false
+}
+
+//
+// Resource deleted watch event.
+func (r *DataVolume) Delete(e event.DeleteEvent) bool {
+	object, cast := e.Object.(*cdi.DataVolume)
+	if !cast {
+		return false
+	}
+	m := &model.DataVolume{}
+	m.With(object)
+	r.Reconciler.Delete(m)
+
+	return false
+}
+
+//
+// Ignored.
+func (r *DataVolume) Generic(e event.GenericEvent) bool {
+	return false
+}
+
+//
+// VMI
+type VMI struct {
+	libocp.BaseCollection
+	log logr.Logger
+}
+
+//
+// Get the kubernetes object being collected.
+func (r *VMI) Object() runtime.Object {
+	return &cnv.VirtualMachineInstance{}
+}
+
+//
+// Reconcile.
+// Achieve initial consistency.
+func (r *VMI) Reconcile(ctx context.Context) (err error) {
+	pClient := r.Reconciler.Client()
+	list := &cnv.VirtualMachineInstanceList{}
+	err = pClient.List(context.TODO(), list)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	db := r.Reconciler.DB()
+	tx, err := db.Begin()
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	defer tx.End()
+	for _, resource := range list.Items {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+		m := &model.VMI{}
+		m.With(&resource)
+		r.Reconciler.UpdateThreshold(m)
+		r.log.Info("Create", libref.ToKind(m), m.String())
+		err = tx.Insert(m)
+		if err != nil {
+			err = liberr.Wrap(err)
+			return
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+
+	return
+}
+
+//
+// Resource created watch event.
+func (r *VMI) Create(e event.CreateEvent) bool {
+	object, cast := e.Object.(*cnv.VirtualMachineInstance)
+	if !cast {
+		return false
+	}
+	m := &model.VMI{}
+	m.With(object)
+	r.Reconciler.Create(m)
+
+	return false
+}
+
+//
+// Resource updated watch event.
+func (r *VMI) Update(e event.UpdateEvent) bool {
+	object, cast := e.ObjectNew.(*cnv.VirtualMachineInstance)
+	if !cast {
+		return false
+	}
+	m := &model.VMI{}
+	m.With(object)
+	r.Reconciler.Update(m)
+
+	return false
+}
+
+//
+// Resource deleted watch event.
+func (r *VMI) Delete(e event.DeleteEvent) bool {
+	object, cast := e.Object.(*cnv.VirtualMachineInstance)
+	if !cast {
+		return false
+	}
+	m := &model.VMI{}
+	m.With(object)
+	r.Reconciler.Delete(m)
+
+	return false
+}
+
+//
+// Ignored.
+func (r *VMI) Generic(e event.GenericEvent) bool {
+	return false
+}
@@ -7,48 +7,137 @@ import (
 	"github.com/konveyor/controller/pkg/logging"
 	api "github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1"
 	core "k8s.io/api/core/v1"
+	"k8s.io/client-go/discovery"
 	"path"
 )
 
 //
 // New reconciler.
 func New(db libmodel.DB, provider *api.Provider, secret *core.Secret) libcontainer.Reconciler {
+	collections := []libocp.Collection{
+		&Namespace{
+			log: logging.WithName("collection|namespace").WithValues(
+				"provider",
+				path.Join(
+					provider.GetNamespace(),
+					provider.GetName())),
+		},
+		&NetworkAttachmentDefinition{
+			log: logging.WithName("collection|network").WithValues(
+				"provider",
+				path.Join(
+					provider.GetNamespace(),
+					provider.GetName())),
+		},
+		&StorageClass{
+			log: logging.WithName("collection|storageclass").WithValues(
+				"provider",
+				path.Join(
+					provider.GetNamespace(),
+					provider.GetName())),
+		},
+		&ResourceQuota{
+			log: logging.WithName("collection|quota").WithValues(
+				"provider",
+				path.Join(
+					provider.GetNamespace(),
+					provider.GetName())),
+		},
+		&LimitRange{
+			log: logging.WithName("collection|limitrange").WithValues(
+				"provider",
+				path.Join(
+					provider.GetNamespace(),
+					provider.GetName())),
+		},
+		&Node{
+			log: logging.WithName("collection|node").WithValues(
+				"provider",
+				path.Join(
+					provider.GetNamespace(),
+					provider.GetName())),
+		},
+		&PersistentVolumeClaim{
+			log: logging.WithName("collection|pvc").WithValues(
+				"provider",
+				path.Join(
+					provider.GetNamespace(),
+					provider.GetName())),
+		},
+		&DataVolume{
+			log: logging.WithName("collection|datavolume").WithValues(
+				"provider",
+				path.Join(
+					provider.GetNamespace(),
+					provider.GetName())),
+		},
+		&VMI{
+			log: logging.WithName("collection|vmi").WithValues(
+				"provider",
+				path.Join(
+					provider.GetNamespace(),
+					provider.GetName())),
+		},
+		&CSIDriver{
+			log: logging.WithName("collection|csidriver").WithValues(
+				"provider",
+				path.Join(
+					provider.GetNamespace(),
+					provider.GetName())),
+		},
+		&VM{
+			log: logging.WithName("collection|vm").WithValues(
+				"provider",
+				path.Join(
+					provider.GetNamespace(),
+					provider.GetName())),
+		},
+	}
+	if storageProfiles(provider, secret) {
+		collections = append(
+			collections,
+			&StorageProfile{
+				log: logging.WithName("collection|storageprofile").WithValues(
+					"provider",
+					path.Join(
+						provider.GetNamespace(),
+						provider.GetName())),
+			})
+	}
+
 	return &Reconciler{
 		Reconciler: libocp.New(
 			db,
 			provider,
 			secret,
-			&Namespace{
-				log: logging.WithName("collection|namespace").WithValues(
-					"provider",
-					path.Join(
-						provider.GetNamespace(),
-						provider.GetName())),
-			},
-			&NetworkAttachmentDefinition{
-				log: logging.WithName("collection|network").WithValues(
-					"provider",
-					path.Join(
-						provider.GetNamespace(),
-						provider.GetName())),
-			},
-			&StorageClass{
-				log: logging.WithName("collection|storageclass").WithValues(
-					"provider",
-					path.Join(
-						provider.GetNamespace(),
-						provider.GetName())),
-			},
-			&VM{
-				log: logging.WithName("collection|vm").WithValues(
-					"provider",
-					path.Join(
-						provider.GetNamespace(),
-						provider.GetName())),
-			}),
+			collections...),
 	}
 }
 
+//
+// The cluster serves StorageProfiles.
+// Not defined by older CDI versions and watching a resource
+// not served fails the reconciler.
+func storageProfiles(provider *api.Provider, secret *core.Secret) (served bool) {
+	client, err := discovery.NewDiscoveryClientForConfig(provider.RestCfg(secret))
+	if err != nil {
+		return
+	}
+	list, err := client.ServerResourcesForGroupVersion(
+		StorageProfileGVK.GroupVersion().String())
+	if err != nil {
+		return
+	}
+	for _, resource := range list.APIResources {
+		if resource.Kind == StorageProfileGVK.Kind {
+			served = true
+			break
+		}
+	}
+
+	return
+}
+
 //
 // OCP reconciler.
 type Reconciler struct {
@@ -821,6 +821,7 @@ func (r *VMAdapter) follow() libweb.Param {
 				"watchdogs",
 				"cdroms",
 				"nics",
+				"nics.reported_devices",
 			},
 			","),
 	}
@@ -8,6 +8,7 @@ import (
 	libweb "github.com/konveyor/controller/pkg/inventory/web"
 	"github.com/konveyor/controller/pkg/logging"
 	api "github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1"
+	"github.com/konveyor/forklift-controller/pkg/controller/provider/container/base"
 	model "github.com/konveyor/forklift-controller/pkg/controller/provider/model/ovirt"
 	core "k8s.io/api/core/v1"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -44,6 +45,8 @@ type Reconciler struct {
 	cancel func()
 	// Last event ID.
 	lastEvent int
+	// Change feed.
+	feed *base.ChangeFeed
 }
 
 //
@@ -62,6 +65,7 @@ func New(db libmodel.DB, provider *api.Provider, secret *core.Secret) (r *Reconc
 		provider: provider,
 		db:       db,
 		log:      log,
+		feed:     base.NewChangeFeed(db, provider, log),
 	}
 
 	return
@@ -114,6 +118,7 @@ func (r *Reconciler) Test() (err error) {
 func (r *Reconciler) Start() error {
 	ctx := context.Background()
 	ctx, r.cancel = context.WithCancel(ctx)
+	r.feed.Start(ctx)
 	watchList := []*libmodel.Watch{}
 	start := func() {
 		defer func() {
@@ -326,6 +331,19 @@ func (r *Reconciler) watch() (list []*libmodel.Watch) {
 	} else {
 		list = append(list, w)
 	}
+	// Change feed.
+	list = append(
+		list,
+		r.feed.Watch(
+			&model.DataCenter{},
+			&model.Cluster{},
+			&model.NICProfile{},
+			&model.DiskProfile{},
+			&model.Network{},
+			&model.StorageDomain{},
+			&model.Disk{},
+			&model.Host{},
+			&model.VM{})...)
 
 	return
 }
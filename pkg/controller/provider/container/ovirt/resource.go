@@ -198,6 +198,7 @@ type VM struct {
 	Host    Ref `json:"host"`
 	Guest   struct {
 		Distribution string `json:"distribution"`
+		Family       string `json:"family"`
 		Version      struct {
 			Full string `json:"full_version"`
 		} `json:"version"`
@@ -271,11 +272,14 @@ type VM struct {
 	} `json:"cdroms"`
 	NICs struct {
 		List []struct {
-			ID         string `json:"id"`
-			Name       string `json:"name"`
-			Interface  string `json:"interface"`
-			Plugged    string `json:"plugged"`
-			Profile    Ref    `json:"vnic_profile"`
+			ID        string `json:"id"`
+			Name      string `json:"name"`
+			Interface string `json:"interface"`
+			Plugged   string `json:"plugged"`
+			MAC       struct {
+				Address string `json:"address"`
+			} `json:"mac"`
+			Profile    Ref `json:"vnic_profile"`
 			Properties struct {
 				List []struct {
 					Name  string `json:"name"`
@@ -284,7 +288,8 @@ type VM struct {
 			} `json:"custom_properties"`
 			Devices struct {
 				List []struct {
-					IPS struct {
+					Name string `json:"name"`
+					IPS  struct {
 						IP []struct {
 							Address string `json:"address"`
 							Version string `json:"version"`
@@ -334,6 +339,7 @@ func (r *VM) ApplyTo(m *model.VM) {
 	m.Cluster = r.Cluster.ID
 	m.Host = r.Host.ID
 	m.GuestName = r.Guest.Distribution + " " + r.Guest.Version.Full
+	m.GuestFamily = r.Guest.Family
 	m.CpuSockets = r.int16(r.CPU.Topology.Sockets)
 	m.CpuCores = r.int16(r.CPU.Topology.Cores)
 	m.CpuShares = r.int16(r.CpuShares)
@@ -378,7 +384,11 @@ func (r *VM) addNICs(m *model.VM) {
 	m.NICs = []model.NIC{}
 	for _, n := range r.NICs.List {
 		ips := []model.IpAddress{}
+		guestName := ""
 		for _, d := range n.Devices.List {
+			if guestName == "" {
+				guestName = d.Name
+			}
 			for _, ip := range d.IPS.IP {
 				ips = append(
 					ips,
@@ -403,7 +413,9 @@ func (r *VM) addNICs(m *model.VM) {
 				Name:       n.Name,
 				Profile:    n.Profile.ID,
 				Interface:  n.Interface,
+				MAC:        n.MAC.Address,
 				Plugged:    r.bool(n.Plugged),
+				GuestName:  guestName,
 				IpAddress:  ips,
 				Properties: properties,
 			})
@@ -5,9 +5,17 @@ import (
 	model "github.com/konveyor/forklift-controller/pkg/controller/provider/model/vsphere"
 	"github.com/vmware/govmomi/vim25/types"
 	"sort"
+	"strconv"
 	"strings"
 )
 
+//
+// Guest filesystem free space change (percent of
+// capacity) below which the reported change is ignored.
+const (
+	GuestFreeSpaceThreshold = 1
+)
+
 //
 // Model adapter.
 // Each adapter provides provider-specific management of a model.
@@ -381,11 +389,36 @@ func (v *NetworkAdapter) Apply(u types.ObjectUpdate) {
 				}
 			case fDVSwitch:
 				v.model.DVSwitch = v.Ref(p.Val)
+			case fPortConfig:
+				v.model.VLan = v.vlan(p.Val)
 			}
 		}
 	}
 }
 
+//
+// The VLAN ID of the port group default port configuration.
+func (v *NetworkAdapter) vlan(val interface{}) (id string) {
+	var setting *types.VMwareDVSPortSetting
+	switch s := val.(type) {
+	case types.VMwareDVSPortSetting:
+		setting = &s
+	case *types.VMwareDVSPortSetting:
+		setting = s
+	}
+	if setting == nil {
+		return
+	}
+	switch spec := setting.Vlan.(type) {
+	case *types.VmwareDistributedVirtualSwitchVlanIdSpec:
+		if spec.VlanId > 0 {
+			id = strconv.Itoa(int(spec.VlanId))
+		}
+	}
+
+	return
+}
+
 //
 // DVSwitch model adapter.
 type DVSwitchAdapter struct {
@@ -500,6 +533,10 @@ func (v *VmAdapter) Model() model.Model {
 // Apply the update to the model.
 func (v *VmAdapter) Apply(u types.ObjectUpdate) {
 	v.Base.Apply(&v.model.Base, u)
+	fields := map[int32]string{}
+	for _, attribute := range v.model.CustomAttributes {
+		fields[attribute.Key] = attribute.Name
+	}
 	for _, p := range u.ChangeSet {
 		switch p.Op {
 		case Assign:
@@ -589,6 +626,38 @@ func (v *VmAdapter) Apply(u types.ObjectUpdate) {
 				}
 			case fNetwork:
 				v.model.Networks = v.RefList(p.Val)
+			case fResourcePool:
+				v.model.ResourcePool = v.Ref(p.Val).ID
+			case fAnnotation:
+				if s, cast := p.Val.(string); cast {
+					v.model.Annotation = s
+				}
+			case fAvailableField:
+				if array, cast := p.Val.(types.ArrayOfCustomFieldDef); cast {
+					for _, def := range array.CustomFieldDef {
+						fields[def.Key] = def.Name
+					}
+				}
+			case fCustomValue:
+				if array, cast := p.Val.(types.ArrayOfCustomFieldValue); cast {
+					names := map[int32]string{}
+					for _, attribute := range v.model.CustomAttributes {
+						names[attribute.Key] = attribute.Name
+					}
+					list := []model.CustomAttribute{}
+					for _, val := range array.CustomFieldValue {
+						if s, cast := val.(*types.CustomFieldStringValue); cast {
+							list = append(
+								list,
+								model.CustomAttribute{
+									Key:   s.Key,
+									Name:  names[s.Key],
+									Value: s.Value,
+								})
+						}
+					}
+					v.model.CustomAttributes = list
+				}
 			case fExtraConfig:
 				if options, cast := p.Val.(types.ArrayOfOptionValue); cast {
 					for _, val := range options.OptionValue {
@@ -601,32 +670,330 @@ func (v *VmAdapter) Apply(u types.ObjectUpdate) {
 						}
 					}
 				}
+			case fGuestID:
+				if s, cast := p.Val.(string); cast {
+					v.model.GuestID = s
+				}
+			case fGuestFamily:
+				if s, cast := p.Val.(string); cast {
+					v.model.GuestFamily = s
+				}
+			case fHostName:
+				if s, cast := p.Val.(string); cast {
+					v.model.HostName = s
+				}
+			case fToolsStatus:
+				if s, cast := p.Val.(string); cast {
+					v.model.ToolsStatus = s
+				}
+			case fToolsVersion:
+				if s, cast := p.Val.(string); cast {
+					v.model.ToolsVersion = s
+				}
+			case fToolsVersionStatus:
+				if s, cast := p.Val.(string); cast {
+					v.model.ToolsVersionStatus = s
+				}
+			case fGuestNet:
+				if array, cast := p.Val.(types.ArrayOfGuestNicInfo); cast {
+					v.updateGuestNetworks(array.GuestNicInfo)
+				}
+			case fGuestDisk:
+				if array, cast := p.Val.(types.ArrayOfGuestDiskInfo); cast {
+					v.updateGuestDisks(array.GuestDiskInfo)
+				}
+			case fGuestIpStack:
+				if array, cast := p.Val.(types.ArrayOfGuestStackInfo); cast {
+					v.updateGuestIpStack(array.GuestStackInfo)
+				}
+			case fBootOptions:
+				if options, cast := p.Val.(types.VirtualMachineBootOptions); cast {
+					b := options.EfiSecureBootEnabled
+					v.model.SecureBoot = b != nil && *b
+				}
 			case fDevices:
 				if devArray, cast := p.Val.(types.ArrayOfVirtualDevice); cast {
-					list := []model.Device{}
-					for _, dev := range devArray.VirtualDevice {
-						switch dev.(type) {
-						case *types.VirtualSriovEthernetCard,
-							*types.VirtualPCIPassthrough,
-							*types.VirtualSCSIPassthrough,
-							*types.VirtualUSBController:
-							list = append(
-								list,
-								model.Device{
-									Kind: libref.ToKind(dev),
-								})
-						}
-					}
-					v.model.Devices = list
+					v.updateDevices(&devArray)
+					v.updateControllers(&devArray)
+					v.updateNICs(&devArray)
 					v.updateDisks(&devArray)
 				}
 			}
 		}
 	}
+	// The field definitions are reported only when changed.
+	if len(fields) > 0 {
+		list := []model.CustomAttribute{}
+		for _, attribute := range v.model.CustomAttributes {
+			attribute.Name = fields[attribute.Key]
+			list = append(list, attribute)
+		}
+		v.model.CustomAttributes = list
+	}
+}
+
+//
+// Update (other) virtual devices.
+func (v *VmAdapter) updateDevices(devArray *types.ArrayOfVirtualDevice) {
+	list := []model.Device{}
+	tpm := false
+	for _, dev := range devArray.VirtualDevice {
+		switch dev.(type) {
+		case *types.VirtualSriovEthernetCard,
+			*types.VirtualPCIPassthrough,
+			*types.VirtualSCSIPassthrough,
+			*types.VirtualUSBController,
+			*types.VirtualTPM:
+			device := dev.GetVirtualDevice()
+			md := model.Device{
+				Kind:  libref.ToKind(dev),
+				Key:   device.Key,
+				Label: v.label(device),
+			}
+			switch dev.(type) {
+			case *types.VirtualPCIPassthrough:
+				if backing, cast := device.Backing.(*types.VirtualPCIPassthroughVmiopBackingInfo); cast {
+					md.VGPU = backing.Vgpu
+				}
+			case *types.VirtualTPM:
+				tpm = true
+			}
+			list = append(list, md)
+		}
+	}
+
+	v.model.Devices = list
+	v.model.TpmEnabled = tpm
+}
+
+//
+// Update virtual disk controllers.
+func (v *VmAdapter) updateControllers(devArray *types.ArrayOfVirtualDevice) {
+	list := []model.Controller{}
+	for _, dev := range devArray.VirtualDevice {
+		md := model.Controller{}
+		switch dev.(type) {
+		case *types.VirtualIDEController:
+			md.Bus = model.BusIDE
+			md.Type = "ide"
+		case *types.VirtualAHCIController:
+			md.Bus = model.BusSATA
+			md.Type = "ahci"
+		case *types.VirtualNVMEController:
+			md.Bus = model.BusNVME
+			md.Type = "nvme"
+		case *types.ParaVirtualSCSIController:
+			md.Bus = model.BusSCSI
+			md.Type = "pvscsi"
+		case *types.VirtualLsiLogicController:
+			md.Bus = model.BusSCSI
+			md.Type = "lsilogic"
+		case *types.VirtualLsiLogicSASController:
+			md.Bus = model.BusSCSI
+			md.Type = "lsilogic-sas"
+		case *types.VirtualBusLogicController:
+			md.Bus = model.BusSCSI
+			md.Type = "buslogic"
+		default:
+			continue
+		}
+		controller := dev.(types.BaseVirtualController).GetVirtualController()
+		md.Key = controller.Key
+		md.BusNumber = controller.BusNumber
+		if scsi, cast := dev.(types.BaseVirtualSCSIController); cast {
+			md.Sharing = string(scsi.GetVirtualSCSIController().SharedBus)
+		}
+		list = append(list, md)
+	}
+
+	v.model.Controllers = list
+}
+
+//
+// Update virtual NICs.
+func (v *VmAdapter) updateNICs(devArray *types.ArrayOfVirtualDevice) {
+	list := []model.NIC{}
+	for _, dev := range devArray.VirtualDevice {
+		md := model.NIC{}
+		switch dev.(type) {
+		case *types.VirtualE1000:
+			md.Type = "e1000"
+		case *types.VirtualE1000e:
+			md.Type = "e1000e"
+		case *types.VirtualPCNet32:
+			md.Type = "pcnet32"
+		case *types.VirtualVmxnet:
+			md.Type = "vmxnet"
+		case *types.VirtualVmxnet2:
+			md.Type = "vmxnet2"
+		case *types.VirtualVmxnet3:
+			md.Type = "vmxnet3"
+		case *types.VirtualVmxnet3Vrdma:
+			md.Type = "vmxnet3vrdma"
+		case *types.VirtualSriovEthernetCard:
+			md.Type = "sriov"
+		default:
+			continue
+		}
+		nic := dev.(types.BaseVirtualEthernetCard).GetVirtualEthernetCard()
+		md.Key = nic.Key
+		md.Label = v.label(&nic.VirtualDevice)
+		md.MAC = nic.MacAddress
+		md.AddressType = nic.AddressType
+		if c := nic.Connectable; c != nil {
+			md.Connected = c.Connected || c.StartConnected
+		}
+		switch backing := nic.Backing.(type) {
+		case *types.VirtualEthernetCardNetworkBackingInfo:
+			if backing.Network != nil {
+				md.Network = v.Ref(*backing.Network)
+			}
+		case *types.VirtualEthernetCardDistributedVirtualPortBackingInfo:
+			md.Network = model.Ref{
+				Kind: model.NetKind,
+				ID:   backing.Port.PortgroupKey,
+			}
+		}
+		list = append(list, md)
+	}
+
+	v.model.NICs = list
+}
+
+//
+// Update the guest default gateways and DNS servers.
+func (v *VmAdapter) updateGuestIpStack(stackList []types.GuestStackInfo) {
+	gateways := []string{}
+	dns := []string{}
+	for _, stack := range stackList {
+		if stack.DnsConfig != nil {
+			dns = append(dns, stack.DnsConfig.IpAddress...)
+		}
+		if stack.IpRouteConfig == nil {
+			continue
+		}
+		for _, route := range stack.IpRouteConfig.IpRoute {
+			if route.PrefixLength == 0 && route.Gateway.IpAddress != "" {
+				gateways = append(gateways, route.Gateway.IpAddress)
+			}
+		}
+	}
+
+	v.model.GuestGateways = gateways
+	v.model.GuestDNS = dns
+}
+
+//
+// Update guest network interfaces.
+// Prefix lengths are only available with the IP config.
+func (v *VmAdapter) updateGuestNetworks(nicList []types.GuestNicInfo) {
+	list := []model.GuestNetwork{}
+	for _, nic := range nicList {
+		md := model.GuestNetwork{
+			Device:    nic.DeviceConfigId,
+			MAC:       nic.MacAddress,
+			Network:   nic.Network,
+			Connected: nic.Connected,
+			IPs:       []model.GuestIP{},
+		}
+		if nic.IpConfig != nil {
+			for _, ip := range nic.IpConfig.IpAddress {
+				md.IPs = append(
+					md.IPs,
+					model.GuestIP{
+						Address:      ip.IpAddress,
+						PrefixLength: ip.PrefixLength,
+						Origin:       ip.Origin,
+					})
+			}
+		} else {
+			for _, ip := range nic.IpAddress {
+				md.IPs = append(
+					md.IPs,
+					model.GuestIP{
+						Address: ip,
+					})
+			}
+		}
+		list = append(list, md)
+	}
+
+	v.model.GuestNetworks = list
+}
+
+//
+// Update guest filesystems.
+// The free space changes continuously and is updated only
+// when it has changed by more than the threshold (percent
+// of capacity) so the VM is not updated on every report.
+func (v *VmAdapter) updateGuestDisks(diskList []types.GuestDiskInfo) {
+	reported := map[string]model.GuestDisk{}
+	for _, disk := range v.model.GuestDisks {
+		reported[disk.Path] = disk
+	}
+	list := []model.GuestDisk{}
+	for _, disk := range diskList {
+		md := model.GuestDisk{
+			Path:           disk.DiskPath,
+			FilesystemType: disk.FilesystemType,
+			Capacity:       disk.Capacity,
+			FreeSpace:      disk.FreeSpace,
+			Disks:          []int32{},
+		}
+		if last, found := reported[md.Path]; found && last.Capacity == md.Capacity {
+			delta := md.FreeSpace - last.FreeSpace
+			if delta < 0 {
+				delta = -delta
+			}
+			if delta*100 <= md.Capacity*GuestFreeSpaceThreshold {
+				md.FreeSpace = last.FreeSpace
+			}
+		}
+		for _, mapping := range disk.Mappings {
+			md.Disks = append(md.Disks, mapping.Key)
+		}
+		list = append(list, md)
+	}
+
+	v.model.GuestDisks = list
+}
+
+//
+// Device label.
+func (v *VmAdapter) label(device *types.VirtualDevice) (label string) {
+	if device.DeviceInfo != nil {
+		label = device.DeviceInfo.GetDescription().Label
+	}
+
+	return
+}
+
+//
+// Device unit number.
+func (v *VmAdapter) unitNumber(device *types.VirtualDevice) (n int32) {
+	if device.UnitNumber != nil {
+		n = *device.UnitNumber
+	}
+
+	return
+}
+
+//
+// Disk ID.
+// The first class disk (FCD) ID when assigned.
+func (v *VmAdapter) diskID(disk *types.VirtualDisk) (id string) {
+	if disk.VDiskId != nil {
+		id = disk.VDiskId.Id
+	} else {
+		id = disk.DiskObjectId
+	}
+
+	return
 }
 
 //
 // Update virtual disk devices.
+// Must follow the controller update.
 func (v *VmAdapter) updateDisks(devArray *types.ArrayOfVirtualDevice) {
 	disks := []model.Disk{}
 	for _, dev := range devArray.VirtualDevice {
@@ -637,8 +1004,12 @@ func (v *VmAdapter) updateDisks(devArray *types.ArrayOfVirtualDevice) {
 			case *types.VirtualDiskFlatVer1BackingInfo:
 				backing := disk.Backing.(*types.VirtualDiskFlatVer1BackingInfo)
 				md := model.Disk{
-					File:     backing.FileName,
-					Capacity: disk.CapacityInBytes,
+					Key:        disk.Key,
+					ObjectID:   v.diskID(disk),
+					Controller: disk.ControllerKey,
+					UnitNumber: v.unitNumber(&disk.VirtualDevice),
+					File:       backing.FileName,
+					Capacity:   disk.CapacityInBytes,
 					Datastore: model.Ref{
 						Kind: model.DsKind,
 						ID:   backing.Datastore.Value,
@@ -648,9 +1019,13 @@ func (v *VmAdapter) updateDisks(devArray *types.ArrayOfVirtualDevice) {
 			case *types.VirtualDiskFlatVer2BackingInfo:
 				backing := disk.Backing.(*types.VirtualDiskFlatVer2BackingInfo)
 				md := model.Disk{
-					File:     backing.FileName,
-					Capacity: disk.CapacityInBytes,
-					Shared:   backing.Sharing != "sharingNone",
+					Key:        disk.Key,
+					ObjectID:   v.diskID(disk),
+					Controller: disk.ControllerKey,
+					UnitNumber: v.unitNumber(&disk.VirtualDevice),
+					File:       backing.FileName,
+					Capacity:   disk.CapacityInBytes,
+					Shared:     backing.Sharing != "sharingNone",
 					Datastore: model.Ref{
 						Kind: model.DsKind,
 						ID:   backing.Datastore.Value,
@@ -660,9 +1035,13 @@ func (v *VmAdapter) updateDisks(devArray *types.ArrayOfVirtualDevice) {
 			case *types.VirtualDiskRawDiskMappingVer1BackingInfo:
 				backing := disk.Backing.(*types.VirtualDiskRawDiskMappingVer1BackingInfo)
 				md := model.Disk{
-					File:     backing.FileName,
-					Capacity: disk.CapacityInBytes,
-					Shared:   backing.Sharing != "sharingNone",
+					Key:        disk.Key,
+					ObjectID:   v.diskID(disk),
+					Controller: disk.ControllerKey,
+					UnitNumber: v.unitNumber(&disk.VirtualDevice),
+					File:       backing.FileName,
+					Capacity:   disk.CapacityInBytes,
+					Shared:     backing.Sharing != "sharingNone",
 					Datastore: model.Ref{
 						Kind: model.DsKind,
 						ID:   backing.Datastore.Value,
@@ -673,14 +1052,24 @@ func (v *VmAdapter) updateDisks(devArray *types.ArrayOfVirtualDevice) {
 			case *types.VirtualDiskRawDiskVer2BackingInfo:
 				backing := disk.Backing.(*types.VirtualDiskRawDiskVer2BackingInfo)
 				md := model.Disk{
-					Capacity: disk.CapacityInBytes,
-					Shared:   backing.Sharing != "sharingNone",
-					RDM:      true,
+					Key:        disk.Key,
+					ObjectID:   v.diskID(disk),
+					Controller: disk.ControllerKey,
+					UnitNumber: v.unitNumber(&disk.VirtualDevice),
+					Capacity:   disk.CapacityInBytes,
+					Shared:     backing.Sharing != "sharingNone",
+					RDM:        true,
 				}
 				disks = append(disks, md)
 			}
 		}
 	}
+	for i := range disks {
+		disk := &disks[i]
+		if controller, found := v.model.Controller(disk.Controller); found {
+			disk.Bus = controller.Bus
+		}
+	}
 
 	v.model.Disks = disks
 }
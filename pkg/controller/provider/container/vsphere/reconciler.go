@@ -7,6 +7,7 @@ import (
 	libmodel "github.com/konveyor/controller/pkg/inventory/model"
 	"github.com/konveyor/controller/pkg/logging"
 	api "github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1"
+	"github.com/konveyor/forklift-controller/pkg/controller/provider/container/base"
 	model "github.com/konveyor/forklift-controller/pkg/controller/provider/model/vsphere"
 	"github.com/vmware/govmomi"
 	"github.com/vmware/govmomi/property"
@@ -19,6 +20,8 @@ import (
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 	liburl "net/url"
 	"path"
+	"reflect"
+	"strings"
 	"time"
 )
 
@@ -82,7 +85,8 @@ const (
 	// Network
 	fTag = "tag"
 	// PortGroup
-	fDVSwitch = "config.distributedVirtualSwitch"
+	fDVSwitch   = "config.distributedVirtualSwitch"
+	fPortConfig = "config.defaultPortConfig"
 	// DV Switch
 	fDVSwitchHost = "config.host"
 	// Datastore
@@ -113,6 +117,20 @@ const (
 	fConnectionState     = "runtime.connectionState"
 	fSnapshot            = "snapshot"
 	fIsTemplate          = "config.template"
+	fResourcePool        = "resourcePool"
+	fAnnotation          = "config.annotation"
+	fCustomValue         = "customValue"
+	fAvailableField      = "availableField"
+	fBootOptions         = "config.bootOptions"
+	fGuestID             = "guest.guestId"
+	fGuestFamily         = "guest.guestFamily"
+	fHostName            = "guest.hostName"
+	fGuestNet            = "guest.net"
+	fGuestDisk           = "guest.disk"
+	fGuestIpStack        = "guest.ipStack"
+	fToolsStatus         = "guest.toolsRunningStatus"
+	fToolsVersion        = "guest.toolsVersion"
+	fToolsVersionStatus  = "guest.toolsVersionStatus2"
 )
 
 //
@@ -239,6 +257,8 @@ type Reconciler struct {
 	cancel func()
 	// has parity.
 	parity bool
+	// Change feed.
+	feed *base.ChangeFeed
 }
 
 //
@@ -255,6 +275,7 @@ func New(db libmodel.DB, provider *api.Provider, secret *core.Secret) *Reconcile
 		secret:   secret,
 		db:       db,
 		log:      nlog,
+		feed:     base.NewChangeFeed(db, provider, nlog),
 	}
 }
 
@@ -312,6 +333,7 @@ func (r *Reconciler) Test() (err error) {
 func (r *Reconciler) Start() error {
 	ctx := context.Background()
 	ctx, r.cancel = context.WithCancel(ctx)
+	r.feed.Start(ctx)
 	start := func() {
 	try:
 		for {
@@ -387,7 +409,9 @@ func (r *Reconciler) getUpdates(ctx context.Context) error {
 	}
 	var tx *libmodel.Tx
 	watchList := []*libmodel.Watch{}
+	tagCtx, tagCancel := context.WithCancel(ctx)
 	defer func() {
+		tagCancel()
 		r.parity = false
 		for _, w := range watchList {
 			w.End()
@@ -451,6 +475,11 @@ next:
 					"duration",
 					time.Since(mark))
 				watchList = r.watch()
+				tagCollector := &TagCollector{
+					Reconciler: r,
+					client:     r.client.Client,
+				}
+				go tagCollector.Run(tagCtx)
 			}
 		}
 	}
@@ -504,6 +533,17 @@ func (r *Reconciler) watch() (list []*libmodel.Watch) {
 	} else {
 		list = append(list, w)
 	}
+	// Change feed.
+	list = append(
+		list,
+		r.feed.Watch(
+			&model.Folder{},
+			&model.Datacenter{},
+			&model.Cluster{},
+			&model.Host{},
+			&model.Network{},
+			&model.Datastore{},
+			&model.VM{})...)
 
 	return
 }
@@ -677,6 +717,7 @@ func (r *Reconciler) propertySpec() []types.PropertySpec {
 			PathSet: []string{
 				fName,
 				fDVSwitch,
+				fPortConfig,
 				fTag,
 			},
 		},
@@ -729,6 +770,20 @@ func (r *Reconciler) propertySpec() []types.PropertySpec {
 				fIsTemplate,
 				fSnapshot,
 				fChangeTracking,
+				fResourcePool,
+				fAnnotation,
+				fCustomValue,
+				fAvailableField,
+				fBootOptions,
+				fGuestID,
+				fGuestFamily,
+				fHostName,
+				fGuestNet,
+				fGuestDisk,
+				fGuestIpStack,
+				fToolsStatus,
+				fToolsVersion,
+				fToolsVersionStatus,
 			},
 		},
 	}
@@ -880,7 +935,19 @@ func (r Reconciler) applyModify(tx *libmodel.Tx, u types.ObjectUpdate) error {
 	if err != nil {
 		return liberr.Wrap(err)
 	}
+	// Properties reported by VMware Tools may be reported
+	// again without changes. Skip the update so the revision
+	// is not bumped and consumers are not notified.
+	vm, guest := m.(*model.VM)
+	guest = guest && r.guestUpdate(u)
+	var before model.VM
+	if guest {
+		before = *vm
+	}
 	adapter.Apply(u)
+	if guest && reflect.DeepEqual(before, *vm) {
+		return nil
+	}
 	if mX, cast := m.(interface{ Updated() }); cast {
 		mX.Updated()
 	}
@@ -897,6 +964,20 @@ func (r Reconciler) applyModify(tx *libmodel.Tx, u types.ObjectUpdate) error {
 	return nil
 }
 
+//
+// Determine whether the update only changes
+// properties reported by VMware Tools.
+func (r Reconciler) guestUpdate(u types.ObjectUpdate) bool {
+	for _, p := range u.ChangeSet {
+		if !strings.HasPrefix(p.Name, "guest.") &&
+			!strings.HasPrefix(p.Name, "summary.guest.") {
+			return false
+		}
+	}
+
+	return len(u.ChangeSet) > 0
+}
+
 //
 // Object deleted.
 func (r Reconciler) applyLeave(tx *libmodel.Tx, u types.ObjectUpdate) error {
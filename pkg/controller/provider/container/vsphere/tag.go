@@ -0,0 +1,206 @@
+package vsphere
+
+import (
+	"context"
+	"errors"
+	liberr "github.com/konveyor/controller/pkg/error"
+	libmodel "github.com/konveyor/controller/pkg/inventory/model"
+	model "github.com/konveyor/forklift-controller/pkg/controller/provider/model/vsphere"
+	"github.com/vmware/govmomi/vapi/rest"
+	"github.com/vmware/govmomi/vapi/tags"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+	liburl "net/url"
+	"reflect"
+	"sort"
+	"time"
+)
+
+//
+// Settings
+const (
+	// Tag refresh interval.
+	TagRefreshInterval = time.Minute
+	// Max objects in each attached tags request.
+	MaxTagObjects = 1000
+)
+
+//
+// Collects vSphere tags (and categories) using the
+// tagging (REST) API and updates the VM model.
+// The tagging API is not supported by the property
+// collector so tags are refreshed periodically.
+type TagCollector struct {
+	*Reconciler
+	// vSphere client.
+	client *vim25.Client
+}
+
+//
+// Run the collector until canceled.
+func (r *TagCollector) Run(ctx context.Context) {
+	r.log.Info("Tag collector started.")
+	defer r.log.Info("Tag collector stopped.")
+	for {
+		err := r.refresh(ctx)
+		if err != nil {
+			r.log.Error(err, "Tag refresh failed.")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(TagRefreshInterval):
+		}
+	}
+}
+
+//
+// Refresh VM tags.
+func (r *TagCollector) refresh(ctx context.Context) (err error) {
+	mark := time.Now()
+	client, err := r.restClient(ctx)
+	if err != nil {
+		return
+	}
+	defer func() {
+		_ = client.Logout(context.Background())
+	}()
+	manager := tags.NewManager(client)
+	categories := map[string]string{}
+	categoryList, err := manager.GetCategories(ctx)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	for _, category := range categoryList {
+		categories[category.ID] = category.Name
+	}
+	tagMap := map[string]model.Tag{}
+	tagList, err := manager.GetTags(ctx)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	for _, tag := range tagList {
+		tagMap[tag.ID] = model.Tag{
+			ID:         tag.ID,
+			Name:       tag.Name,
+			CategoryID: tag.CategoryID,
+			Category:   categories[tag.CategoryID],
+		}
+	}
+	vmList := []model.VM{}
+	err = r.db.List(&vmList, libmodel.ListOptions{})
+	if err != nil {
+		return
+	}
+	attached := map[string][]model.Tag{}
+	for start := 0; start < len(vmList); start += MaxTagObjects {
+		end := start + MaxTagObjects
+		if end > len(vmList) {
+			end = len(vmList)
+		}
+		refs := []mo.Reference{}
+		for _, vm := range vmList[start:end] {
+			refs = append(
+				refs,
+				types.ManagedObjectReference{
+					Type:  VirtualMachine,
+					Value: vm.ID,
+				})
+		}
+		objects, tErr := manager.ListAttachedTagsOnObjects(ctx, refs)
+		if tErr != nil {
+			err = liberr.Wrap(tErr)
+			return
+		}
+		for _, object := range objects {
+			list := []model.Tag{}
+			for _, id := range object.TagIDs {
+				if tag, found := tagMap[id]; found {
+					list = append(list, tag)
+				}
+			}
+			sort.Slice(
+				list,
+				func(i, j int) bool {
+					return list[i].ID < list[j].ID
+				})
+			attached[object.ObjectID.Reference().Value] = list
+		}
+	}
+	err = r.apply(vmList, attached)
+	if err != nil {
+		return
+	}
+
+	r.log.V(1).Info(
+		"Tags refreshed.",
+		"duration",
+		time.Since(mark))
+
+	return
+}
+
+//
+// Update VMs with changed tags.
+func (r *TagCollector) apply(vmList []model.VM, attached map[string][]model.Tag) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return
+	}
+	defer func() {
+		_ = tx.End()
+	}()
+	for _, m := range vmList {
+		list := attached[m.ID]
+		vm := &model.VM{
+			Base: model.Base{ID: m.ID},
+		}
+		err = tx.Get(vm)
+		if err != nil {
+			if errors.Is(err, model.NotFound) {
+				err = nil
+				continue
+			}
+			return
+		}
+		if len(list) == 0 && len(vm.Tags) == 0 {
+			continue
+		}
+		if reflect.DeepEqual(list, vm.Tags) {
+			continue
+		}
+		vm.Tags = list
+		vm.Updated()
+		err = tx.Update(vm)
+		if err != nil {
+			return
+		}
+		r.log.V(3).Info(
+			"VM tags updated.",
+			"vm",
+			vm.ID)
+	}
+
+	err = tx.Commit()
+
+	return
+}
+
+//
+// Build a logged in REST client.
+func (r *TagCollector) restClient(ctx context.Context) (client *rest.Client, err error) {
+	client = rest.NewClient(r.client)
+	err = client.Login(
+		ctx,
+		liburl.UserPassword(
+			r.user(),
+			r.password()))
+	if err != nil {
+		err = liberr.Wrap(err)
+	}
+
+	return
+}
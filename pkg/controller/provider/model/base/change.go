@@ -0,0 +1,75 @@
+package base
+
+import (
+	"fmt"
+	"strconv"
+)
+
+//
+// Change operations.
+const (
+	Created = "created"
+	Updated = "updated"
+	Deleted = "deleted"
+)
+
+//
+// Change feed ID.
+const (
+	FeedID = "feed"
+)
+
+//
+// Inventory change.
+// Changes are recorded in (feed) revision order and
+// retained for the configured window. Only the kind and
+// ID are recorded; clients get the model as needed.
+type Change struct {
+	// Feed revision.
+	Revision int64 `sql:"pk"`
+	// The kind of model changed.
+	Kind string `sql:"d0,index(kind)"`
+	// The operation (created|updated|deleted).
+	Operation string `sql:"d0"`
+	// The ID of the model changed.
+	ID string `sql:"d0,index(id)"`
+	// The model revision (when available).
+	ModelRevision int64 `sql:"d0"`
+	// When recorded (unix seconds).
+	Recorded int64 `sql:"d0,index(recorded)"`
+}
+
+//
+// Get the PK.
+func (m *Change) Pk() string {
+	return strconv.FormatInt(m.Revision, 10)
+}
+
+//
+// String representation.
+func (m *Change) String() string {
+	return fmt.Sprintf(
+		"%d: %s %s/%s",
+		m.Revision,
+		m.Operation,
+		m.Kind,
+		m.ID)
+}
+
+//
+// Change feed.
+// The first revision recorded since the inventory
+// was (re)built. Changes with an earlier revision
+// were recorded in the previous inventory.
+type Feed struct {
+	// ID.
+	ID string `sql:"pk"`
+	// First revision.
+	Base int64 `sql:"d0"`
+}
+
+//
+// Get the PK.
+func (m *Feed) Pk() string {
+	return m.ID
+}
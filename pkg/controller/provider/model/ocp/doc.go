@@ -7,7 +7,15 @@ func All() []interface{} {
 		&Provider{},
 		&NetworkAttachmentDefinition{},
 		&StorageClass{},
+		&CSIDriver{},
+		&StorageProfile{},
 		&Namespace{},
 		&VM{},
+		&ResourceQuota{},
+		&LimitRange{},
+		&Node{},
+		&PersistentVolumeClaim{},
+		&DataVolume{},
+		&VMI{},
 	}
 }
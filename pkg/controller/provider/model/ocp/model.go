@@ -8,8 +8,10 @@ import (
 	core "k8s.io/api/core/v1"
 	storage "k8s.io/api/storage/v1"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	cnv "kubevirt.io/client-go/api/v1"
+	cdi "kubevirt.io/containerized-data-importer/pkg/apis/core/v1beta1"
 	"path"
 	"strconv"
 )
@@ -59,6 +61,7 @@ func (m *Base) With(r Resource) {
 	m.Version = r.GetResourceVersion()
 	m.Namespace = r.GetNamespace()
 	m.Name = r.GetName()
+	m.labels = r.GetLabels()
 }
 
 //
@@ -119,6 +122,31 @@ func (m *StorageClass) With(s *storage.StorageClass) {
 	m.Object = *s
 }
 
+//
+// CSIDriver
+type CSIDriver struct {
+	Base
+	Object storage.CSIDriver `sql:""`
+}
+
+func (m *CSIDriver) With(d *storage.CSIDriver) {
+	m.Base.With(d)
+	m.Object = *d
+}
+
+//
+// StorageProfile (CDI).
+// Collected as unstructured: not defined by the vendored CDI API.
+type StorageProfile struct {
+	Base
+	Object map[string]interface{} `sql:""`
+}
+
+func (m *StorageProfile) With(p *unstructured.Unstructured) {
+	m.Base.With(p)
+	m.Object = p.Object
+}
+
 //
 // NetworkAttachmentDefinition
 type NetworkAttachmentDefinition struct {
@@ -142,3 +170,75 @@ func (m *VM) With(v *cnv.VirtualMachine) {
 	m.Base.With(v)
 	m.Object = *v
 }
+
+//
+// ResourceQuota
+type ResourceQuota struct {
+	Base
+	Object core.ResourceQuota `sql:""`
+}
+
+func (m *ResourceQuota) With(r *core.ResourceQuota) {
+	m.Base.With(r)
+	m.Object = *r
+}
+
+//
+// LimitRange
+type LimitRange struct {
+	Base
+	Object core.LimitRange `sql:""`
+}
+
+func (m *LimitRange) With(r *core.LimitRange) {
+	m.Base.With(r)
+	m.Object = *r
+}
+
+//
+// Node
+type Node struct {
+	Base
+	Object core.Node `sql:""`
+}
+
+func (m *Node) With(r *core.Node) {
+	m.Base.With(r)
+	m.Object = *r
+}
+
+//
+// PersistentVolumeClaim
+type PersistentVolumeClaim struct {
+	Base
+	Object core.PersistentVolumeClaim `sql:""`
+}
+
+func (m *PersistentVolumeClaim) With(r *core.PersistentVolumeClaim) {
+	m.Base.With(r)
+	m.Object = *r
+}
+
+//
+// DataVolume
+type DataVolume struct {
+	Base
+	Object cdi.DataVolume `sql:""`
+}
+
+func (m *DataVolume) With(r *cdi.DataVolume) {
+	m.Base.With(r)
+	m.Object = *r
+}
+
+//
+// VMI
+type VMI struct {
+	Base
+	Object cnv.VirtualMachineInstance `sql:""`
+}
+
+func (m *VMI) With(r *cnv.VirtualMachineInstance) {
+	m.Base.With(r)
+	m.Object = *r
+}
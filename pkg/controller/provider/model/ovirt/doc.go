@@ -9,6 +9,8 @@ import (
 func All() []interface{} {
 	return []interface{}{
 		&ocp.Provider{},
+		&Change{},
+		&Feed{},
 		&DataCenter{},
 		&Cluster{},
 		&NICProfile{},
@@ -20,6 +20,8 @@ const (
 type Model = base.Model
 type ListOptions = base.ListOptions
 type Concern = base.Concern
+type Change = base.Change
+type Feed = base.Feed
 type Ref = base.Ref
 
 //
@@ -123,6 +125,7 @@ type VM struct {
 	RevisionValidated           int64            `sql:"d0,index(revisionValidated)" eq:"-"`
 	PolicyVersion               int              `sql:"d0,index(policyVersion)" eq:"-"`
 	GuestName                   string           `sql:""`
+	GuestFamily                 string           `sql:""`
 	CpuSockets                  int16            `sql:""`
 	CpuCores                    int16            `sql:""`
 	CpuAffinity                 []CpuPinning     `sql:""`
@@ -177,10 +180,12 @@ type NIC struct {
 	ID         string      `json:"id"`
 	Name       string      `json:"name"`
 	Interface  string      `json:"interface"`
+	MAC        string      `json:"mac"`
 	Plugged    bool        `json:"plugged"`
 	IpAddress  []IpAddress `json:"ipAddress"`
 	Profile    string      `json:"profile"`
 	Properties []Property  `json:"properties"`
+	GuestName  string      `json:"guestName"`
 }
 
 type IpAddress struct {
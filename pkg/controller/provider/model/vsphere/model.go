@@ -29,6 +29,8 @@ const (
 type Model = base.Model
 type ListOptions = base.ListOptions
 type Concern = base.Concern
+type Change = base.Change
+type Feed = base.Feed
 type Ref = base.Ref
 
 //
@@ -291,6 +293,7 @@ type Network struct {
 	Base
 	Variant  string    `sql:"d0"`
 	Tag      string    `sql:""`
+	VLan     string    `sql:""`
 	DVSwitch Ref       `sql:""`
 	Host     []DVSHost `sql:""`
 }
@@ -310,34 +313,52 @@ type Datastore struct {
 
 type VM struct {
 	Base
-	Folder                string    `sql:"d0,index(folder)"`
-	Host                  string    `sql:"d0,index(host)"`
-	RevisionValidated     int64     `sql:"d0,index(revisionValidated)"`
-	PolicyVersion         int       `sql:"d0,index(policyVersion)"`
-	UUID                  string    `sql:""`
-	Firmware              string    `sql:""`
-	PowerState            string    `sql:""`
-	ConnectionState       string    `sql:""`
-	CpuAffinity           []int32   `sql:""`
-	CpuHotAddEnabled      bool      `sql:""`
-	CpuHotRemoveEnabled   bool      `sql:""`
-	MemoryHotAddEnabled   bool      `sql:""`
-	FaultToleranceEnabled bool      `sql:""`
-	CpuCount              int32     `sql:""`
-	CoresPerSocket        int32     `sql:""`
-	MemoryMB              int32     `sql:""`
-	GuestName             string    `sql:""`
-	BalloonedMemory       int32     `sql:""`
-	IpAddress             string    `sql:""`
-	NumaNodeAffinity      []string  `sql:""`
-	StorageUsed           int64     `sql:""`
-	Snapshot              Ref       `sql:""`
-	IsTemplate            bool      `sql:""`
-	ChangeTrackingEnabled bool      `sql:""`
-	Devices               []Device  `sql:""`
-	Disks                 []Disk    `sql:""`
-	Networks              []Ref     `sql:""`
-	Concerns              []Concern `sql:""`
+	Folder                string            `sql:"d0,index(folder)"`
+	Host                  string            `sql:"d0,index(host)"`
+	RevisionValidated     int64             `sql:"d0,index(revisionValidated)"`
+	PolicyVersion         int               `sql:"d0,index(policyVersion)"`
+	UUID                  string            `sql:""`
+	Firmware              string            `sql:""`
+	PowerState            string            `sql:""`
+	ConnectionState       string            `sql:""`
+	CpuAffinity           []int32           `sql:""`
+	CpuHotAddEnabled      bool              `sql:""`
+	CpuHotRemoveEnabled   bool              `sql:""`
+	MemoryHotAddEnabled   bool              `sql:""`
+	FaultToleranceEnabled bool              `sql:""`
+	CpuCount              int32             `sql:""`
+	CoresPerSocket        int32             `sql:""`
+	MemoryMB              int32             `sql:""`
+	GuestName             string            `sql:""`
+	GuestID               string            `sql:""`
+	GuestFamily           string            `sql:""`
+	HostName              string            `sql:""`
+	ToolsStatus           string            `sql:""`
+	ToolsVersion          string            `sql:""`
+	ToolsVersionStatus    string            `sql:""`
+	BalloonedMemory       int32             `sql:""`
+	IpAddress             string            `sql:""`
+	NumaNodeAffinity      []string          `sql:""`
+	StorageUsed           int64             `sql:""`
+	Snapshot              Ref               `sql:""`
+	IsTemplate            bool              `sql:""`
+	ChangeTrackingEnabled bool              `sql:""`
+	SecureBoot            bool              `sql:""`
+	TpmEnabled            bool              `sql:""`
+	ResourcePool          string            `sql:""`
+	Annotation            string            `sql:""`
+	Tags                  []Tag             `sql:""`
+	CustomAttributes      []CustomAttribute `sql:""`
+	Devices               []Device          `sql:""`
+	Controllers           []Controller      `sql:""`
+	Disks                 []Disk            `sql:""`
+	NICs                  []NIC             `sql:""`
+	GuestNetworks         []GuestNetwork    `sql:""`
+	GuestDisks            []GuestDisk       `sql:""`
+	GuestGateways         []string          `sql:""`
+	GuestDNS              []string          `sql:""`
+	Networks              []Ref             `sql:""`
+	Concerns              []Concern         `sql:""`
 }
 
 //
@@ -346,18 +367,143 @@ func (m *VM) Validated() bool {
 	return m.RevisionValidated == m.Revision
 }
 
+//
+// Find a custom attribute by key.
+func (m *VM) CustomAttribute(key int32) (attribute *CustomAttribute, found bool) {
+	for i := range m.CustomAttributes {
+		if m.CustomAttributes[i].Key == key {
+			attribute = &m.CustomAttributes[i]
+			found = true
+			break
+		}
+	}
+
+	return
+}
+
+//
+// Storage used within the guest (bytes).
+// Reported by VMware Tools.
+func (m *VM) GuestStorageUsed() (used int64) {
+	for _, disk := range m.GuestDisks {
+		used += disk.Capacity - disk.FreeSpace
+	}
+
+	return
+}
+
+//
+// Find a disk controller by key.
+func (m *VM) Controller(key int32) (controller *Controller, found bool) {
+	for i := range m.Controllers {
+		if m.Controllers[i].Key == key {
+			controller = &m.Controllers[i]
+			found = true
+			break
+		}
+	}
+
+	return
+}
+
+//
+// vSphere tag.
+type Tag struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	CategoryID string `json:"categoryId"`
+	Category   string `json:"category"`
+}
+
+//
+// Custom attribute.
+type CustomAttribute struct {
+	Key   int32  `json:"key"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
 //
 // Virtual Disk.
 type Disk struct {
-	File      string `json:"file"`
-	Datastore Ref    `json:"datastore"`
-	Capacity  int64  `json:"capacity"`
-	Shared    bool   `json:"shared"`
-	RDM       bool   `json:"rdm"`
+	Key        int32  `json:"key"`
+	ObjectID   string `json:"objectID"`
+	File       string `json:"file"`
+	Datastore  Ref    `json:"datastore"`
+	Capacity   int64  `json:"capacity"`
+	Shared     bool   `json:"shared"`
+	RDM        bool   `json:"rdm"`
+	Controller int32  `json:"controller"`
+	Bus        string `json:"bus"`
+	UnitNumber int32  `json:"unitNumber"`
+}
+
+//
+// Disk controller buses.
+const (
+	BusIDE  = "ide"
+	BusSATA = "sata"
+	BusSCSI = "scsi"
+	BusNVME = "nvme"
+)
+
+//
+// Virtual disk controller.
+type Controller struct {
+	Key       int32  `json:"key"`
+	Bus       string `json:"bus"`
+	Type      string `json:"type"`
+	BusNumber int32  `json:"busNumber"`
+	Sharing   string `json:"sharing,omitempty"`
+}
+
+//
+// Virtual NIC.
+type NIC struct {
+	Key         int32  `json:"key"`
+	Label       string `json:"label"`
+	Type        string `json:"type"`
+	MAC         string `json:"mac"`
+	AddressType string `json:"addressType"`
+	Network     Ref    `json:"network"`
+	Connected   bool   `json:"connected"`
+}
+
+//
+// Guest network interface.
+// Reported by VMware Tools.
+type GuestNetwork struct {
+	Device    int32     `json:"device"`
+	MAC       string    `json:"mac"`
+	Network   string    `json:"network"`
+	Connected bool      `json:"connected"`
+	IPs       []GuestIP `json:"ips"`
+}
+
+//
+// Guest IP address.
+type GuestIP struct {
+	Address      string `json:"address"`
+	PrefixLength int32  `json:"prefixLength"`
+	Origin       string `json:"origin,omitempty"`
+}
+
+//
+// Guest filesystem.
+// Reported by VMware Tools.
+type GuestDisk struct {
+	Path           string  `json:"path"`
+	FilesystemType string  `json:"filesystemType"`
+	Capacity       int64   `json:"capacity"`
+	FreeSpace      int64   `json:"freeSpace"`
+	Disks          []int32 `json:"disks"`
 }
 
 //
 // Virtual Device.
 type Device struct {
-	Kind string `json:"kind"`
+	Kind  string `json:"kind"`
+	Key   int32  `json:"key"`
+	Label string `json:"label"`
+	VGPU  string `json:"vgpu,omitempty"`
 }
@@ -0,0 +1,199 @@
+package base
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	libmodel "github.com/konveyor/controller/pkg/inventory/model"
+	model "github.com/konveyor/forklift-controller/pkg/controller/provider/model/base"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+//
+// Routes.
+const (
+	ChangeCollection = "changes"
+	SinceParam       = "since"
+	KindParam        = "kind"
+)
+
+//
+// Change (feed) handler.
+// Lists inventory changes with a revision greater than the
+// revision specified by the `since` parameter. When changes
+// following the `since` revision are no longer available, the
+// client must relist and the request is rejected with: 410 (Gone).
+// The reason distinguishes an inventory rebuilt (Restarted) from
+// changes pruned (Expired).
+type ChangeHandler struct {
+	Handler
+}
+
+//
+// List resources in a REST collection.
+func (h ChangeHandler) List(ctx *gin.Context) {
+	status := h.Prepare(ctx)
+	if status != http.StatusOK {
+		ctx.Status(status)
+		return
+	}
+	if h.WatchRequest {
+		ctx.Status(http.StatusBadRequest)
+		return
+	}
+	q := ctx.Request.URL.Query()
+	since := int64(0)
+	pSince := q.Get(SinceParam)
+	if len(pSince) > 0 {
+		n, err := strconv.ParseInt(pSince, 10, 64)
+		if err != nil || n < 0 {
+			ctx.Status(http.StatusBadRequest)
+			return
+		}
+		since = n
+	}
+	db := h.Reconciler.DB()
+	gone, err := h.gone(db, since)
+	if err != nil {
+		log.Trace(
+			err,
+			"url",
+			ctx.Request.URL)
+		ctx.Status(http.StatusInternalServerError)
+		return
+	}
+	if gone != nil {
+		ctx.JSON(http.StatusGone, gone)
+		return
+	}
+	var predicate libmodel.Predicate
+	predicate = libmodel.Gt("Revision", since)
+	kind := q.Get(KindParam)
+	if len(kind) > 0 {
+		predicate = libmodel.And(
+			predicate,
+			libmodel.Eq("Kind", kind))
+	}
+	list := []model.Change{}
+	err = db.List(
+		&list,
+		libmodel.ListOptions{
+			Predicate: predicate,
+			Sort:      []int{1},
+			Page:      &h.Page,
+			Detail:    1,
+		})
+	if err != nil {
+		log.Trace(
+			err,
+			"url",
+			ctx.Request.URL)
+		ctx.Status(http.StatusInternalServerError)
+		return
+	}
+	content := []interface{}{}
+	for _, m := range list {
+		r := &Change{}
+		r.With(&m)
+		content = append(content, r)
+	}
+
+	ctx.JSON(http.StatusOK, content)
+}
+
+//
+// Get not supported.
+func (h ChangeHandler) Get(ctx *gin.Context) {
+	ctx.Status(http.StatusMethodNotAllowed)
+}
+
+//
+// Determine whether changes following the specified
+// revision are no longer available because the inventory
+// was rebuilt (restarted) or they have been pruned (expired).
+func (h ChangeHandler) gone(db libmodel.DB, since int64) (gone *Gone, err error) {
+	if since == 0 {
+		return
+	}
+	feed := &model.Feed{ID: model.FeedID}
+	err = db.Get(feed)
+	if err != nil {
+		if errors.Is(err, libmodel.NotFound) {
+			err = nil
+		} else {
+			return
+		}
+	}
+	if feed.Base > 0 && since < feed.Base-1 {
+		gone = &Gone{
+			Reason:   Restarted,
+			Revision: feed.Base,
+		}
+		return
+	}
+	list := []model.Change{}
+	err = db.List(
+		&list,
+		libmodel.ListOptions{
+			Sort: []int{1},
+			Page: &libmodel.Page{Limit: 1},
+		})
+	if err != nil {
+		return
+	}
+	if len(list) > 0 && since < list[0].Revision-1 {
+		gone = &Gone{
+			Reason:   Expired,
+			Revision: list[0].Revision,
+		}
+	}
+
+	return
+}
+
+//
+// Reasons changes are gone.
+const (
+	// The inventory was rebuilt.
+	Restarted = "Restarted"
+	// The changes have been pruned.
+	Expired = "Expired"
+)
+
+//
+// Changes no longer available.
+type Gone struct {
+	// Reason (Restarted|Expired).
+	Reason string `json:"reason"`
+	// The first revision available.
+	Revision int64 `json:"revision"`
+}
+
+//
+// REST Resource.
+type Change struct {
+	// Feed revision.
+	Revision int64 `json:"revision"`
+	// The kind of model changed.
+	Kind string `json:"kind"`
+	// The operation (created|updated|deleted).
+	Operation string `json:"operation"`
+	// The ID of the model changed.
+	ID string `json:"id"`
+	// The model revision.
+	ModelRevision int64 `json:"modelRevision"`
+	// When recorded.
+	Recorded time.Time `json:"recorded"`
+}
+
+//
+// Build the resource using the model.
+func (r *Change) With(m *model.Change) {
+	r.Revision = m.Revision
+	r.Kind = m.Kind
+	r.Operation = m.Operation
+	r.ID = m.ID
+	r.ModelRevision = m.ModelRevision
+	r.Recorded = time.Unix(m.Recorded, 0).UTC()
+}
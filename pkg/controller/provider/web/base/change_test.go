@@ -0,0 +1,66 @@
+package base
+
+import (
+	libmodel "github.com/konveyor/controller/pkg/inventory/model"
+	model "github.com/konveyor/forklift-controller/pkg/controller/provider/model/base"
+	"github.com/onsi/gomega"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestChangeGone(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	dir, err := ioutil.TempDir("", "change")
+	g.Expect(err).To(gomega.BeNil())
+	defer os.RemoveAll(dir)
+	db := libmodel.New(
+		filepath.Join(dir, "test.db"),
+		&model.Change{},
+		&model.Feed{})
+	err = db.Open(true)
+	g.Expect(err).To(gomega.BeNil())
+	defer db.Close(true)
+	err = db.Insert(&model.Feed{ID: model.FeedID, Base: 100})
+	g.Expect(err).To(gomega.BeNil())
+	for _, revision := range []int64{105, 106} {
+		err = db.Insert(&model.Change{Revision: revision})
+		g.Expect(err).To(gomega.BeNil())
+	}
+	handler := ChangeHandler{}
+
+	cases := []struct {
+		name     string
+		since    int64
+		expected *Gone
+	}{
+		{
+			name:  "list",
+			since: 0,
+		},
+		{
+			name:     "restarted",
+			since:    50,
+			expected: &Gone{Reason: Restarted, Revision: 100},
+		},
+		{
+			name:     "expired",
+			since:    100,
+			expected: &Gone{Reason: Expired, Revision: 105},
+		},
+		{
+			name:  "available",
+			since: 104,
+		},
+		{
+			name:  "current",
+			since: 106,
+		},
+	}
+	for _, c := range cases {
+		gone, err := handler.gone(db, c.since)
+		g.Expect(err).To(gomega.BeNil(), c.name)
+		g.Expect(gone).To(gomega.Equal(c.expected), c.name)
+	}
+}
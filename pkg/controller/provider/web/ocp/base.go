@@ -5,6 +5,7 @@ import (
 	libmodel "github.com/konveyor/controller/pkg/inventory/model"
 	"github.com/konveyor/controller/pkg/logging"
 	"github.com/konveyor/forklift-controller/pkg/controller/provider/web/base"
+	"strings"
 )
 
 //
@@ -17,6 +18,9 @@ const (
 	NsParam     = base.NsParam
 	NameParam   = base.NameParam
 	DetailParam = base.DetailParam
+	// Label selector (key=value).
+	// May be repeated.
+	LabelParam = "label"
 )
 
 //
@@ -42,6 +46,18 @@ func (h Handler) Predicate(ctx *gin.Context) (p libmodel.Predicate) {
 			and.Predicates,
 			libmodel.Eq(NameParam, name))
 	}
+	labels := libmodel.Labels{}
+	for _, label := range q[LabelParam] {
+		kv := strings.SplitN(label, "=", 2)
+		if len(kv) == 2 {
+			labels[kv[0]] = kv[1]
+		}
+	}
+	if len(labels) > 0 {
+		and.Predicates = append(
+			and.Predicates,
+			libmodel.Match(labels))
+	}
 	switch len(and.Predicates) {
 	case 0: // All.
 	case 1:
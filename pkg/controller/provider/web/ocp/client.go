@@ -40,6 +40,16 @@ func (r *Resolver) Path(object interface{}, id string) (path string, err error)
 		r.UID = id
 		r.Link(provider)
 		path = r.SelfLink
+	case *CSIDriver:
+		r := CSIDriver{}
+		r.UID = id
+		r.Link(provider)
+		path = r.SelfLink
+	case *StorageProfile:
+		r := StorageProfile{}
+		r.UID = id
+		r.Link(provider)
+		path = r.SelfLink
 	case *NetworkAttachmentDefinition:
 		r := NetworkAttachmentDefinition{}
 		r.UID = id
@@ -50,6 +60,36 @@ func (r *Resolver) Path(object interface{}, id string) (path string, err error)
 		r.UID = id
 		r.Link(provider)
 		path = r.SelfLink
+	case *ResourceQuota:
+		r := ResourceQuota{}
+		r.UID = id
+		r.Link(provider)
+		path = r.SelfLink
+	case *LimitRange:
+		r := LimitRange{}
+		r.UID = id
+		r.Link(provider)
+		path = r.SelfLink
+	case *Node:
+		r := Node{}
+		r.UID = id
+		r.Link(provider)
+		path = r.SelfLink
+	case *PersistentVolumeClaim:
+		r := PersistentVolumeClaim{}
+		r.UID = id
+		r.Link(provider)
+		path = r.SelfLink
+	case *DataVolume:
+		r := DataVolume{}
+		r.UID = id
+		r.Link(provider)
+		path = r.SelfLink
+	case *VMI:
+		r := VMI{}
+		r.UID = id
+		r.Link(provider)
+		path = r.SelfLink
 	default:
 		err = liberr.Wrap(
 			ResourceNotResolvedError{
@@ -192,6 +232,152 @@ func (r *Finder) ByRef(resource interface{}, ref base.Ref) (err error) {
 			}
 			*resource.(*VM) = list[0]
 		}
+	case *PersistentVolumeClaim:
+		id := ref.ID
+		if id != "" {
+			err = r.Get(resource, id)
+			return
+		}
+		name := ref.Name
+		if name != "" {
+			ns, name := path.Split(name)
+			ns = strings.TrimRight(ns, "/")
+			list := []PersistentVolumeClaim{}
+			err = r.List(
+				&list,
+				base.Param{
+					Key:   DetailParam,
+					Value: "1",
+				},
+				base.Param{
+					Key:   NsParam,
+					Value: ns,
+				},
+				base.Param{
+					Key:   NameParam,
+					Value: name,
+				})
+			if err != nil {
+				break
+			}
+			if len(list) == 0 {
+				err = liberr.Wrap(NotFoundError{Ref: ref})
+				break
+			}
+			if len(list) > 1 {
+				err = liberr.Wrap(RefNotUniqueError{Ref: ref})
+				break
+			}
+			*resource.(*PersistentVolumeClaim) = list[0]
+		}
+	case *DataVolume:
+		id := ref.ID
+		if id != "" {
+			err = r.Get(resource, id)
+			return
+		}
+		name := ref.Name
+		if name != "" {
+			ns, name := path.Split(name)
+			ns = strings.TrimRight(ns, "/")
+			list := []DataVolume{}
+			err = r.List(
+				&list,
+				base.Param{
+					Key:   DetailParam,
+					Value: "1",
+				},
+				base.Param{
+					Key:   NsParam,
+					Value: ns,
+				},
+				base.Param{
+					Key:   NameParam,
+					Value: name,
+				})
+			if err != nil {
+				break
+			}
+			if len(list) == 0 {
+				err = liberr.Wrap(NotFoundError{Ref: ref})
+				break
+			}
+			if len(list) > 1 {
+				err = liberr.Wrap(RefNotUniqueError{Ref: ref})
+				break
+			}
+			*resource.(*DataVolume) = list[0]
+		}
+	case *VMI:
+		id := ref.ID
+		if id != "" {
+			err = r.Get(resource, id)
+			return
+		}
+		name := ref.Name
+		if name != "" {
+			ns, name := path.Split(name)
+			ns = strings.TrimRight(ns, "/")
+			list := []VMI{}
+			err = r.List(
+				&list,
+				base.Param{
+					Key:   DetailParam,
+					Value: "1",
+				},
+				base.Param{
+					Key:   NsParam,
+					Value: ns,
+				},
+				base.Param{
+					Key:   NameParam,
+					Value: name,
+				})
+			if err != nil {
+				break
+			}
+			if len(list) == 0 {
+				err = liberr.Wrap(NotFoundError{Ref: ref})
+				break
+			}
+			if len(list) > 1 {
+				err = liberr.Wrap(RefNotUniqueError{Ref: ref})
+				break
+			}
+			*resource.(*VMI) = list[0]
+		}
+	case *Node:
+		id := ref.ID
+		if id != "" {
+			err = r.Get(resource, id)
+			return
+		}
+		name := ref.Name
+		if name != "" {
+			list := []Node{}
+			err = r.List(
+				&list,
+				base.Param{
+					Key:   DetailParam,
+					Value: "1",
+				},
+				base.Param{
+					Key:   NameParam,
+					Value: name,
+				})
+			if err != nil {
+				break
+			}
+			if len(list) == 0 {
+				err = liberr.Wrap(NotFoundError{Ref: ref})
+				break
+			}
+			if len(list) > 1 {
+				err = liberr.Wrap(RefNotUniqueError{Ref: ref})
+				break
+			}
+			*resource.(*Node) = list[0]
+		}
 	}
 
 	return
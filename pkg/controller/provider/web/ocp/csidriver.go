@@ -0,0 +1,166 @@
+package ocp
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	libmodel "github.com/konveyor/controller/pkg/inventory/model"
+	api "github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1"
+	model "github.com/konveyor/forklift-controller/pkg/controller/provider/model/ocp"
+	"github.com/konveyor/forklift-controller/pkg/controller/provider/web/base"
+	storage "k8s.io/api/storage/v1"
+	"net/http"
+)
+
+//
+// Routes.
+const (
+	CSIDriverParam = "csidriver"
+	CSIDriversRoot = ProviderRoot + "/csidrivers"
+	CSIDriverRoot  = CSIDriversRoot + "/:" + CSIDriverParam
+)
+
+//
+// CSIDriver handler.
+type CSIDriverHandler struct {
+	Handler
+}
+
+//
+// Add routes to the `gin` router.
+func (h *CSIDriverHandler) AddRoutes(e *gin.Engine) {
+	e.GET(CSIDriversRoot, h.List)
+	e.GET(CSIDriversRoot+"/", h.List)
+	e.GET(CSIDriverRoot, h.Get)
+}
+
+//
+// List resources in a REST collection.
+// A GET onn the collection that includes the `X-Watch`
+// header will negotiate an upgrade of the connection
+// to a websocket and push watch events.
+func (h CSIDriverHandler) List(ctx *gin.Context) {
+	status := h.Prepare(ctx)
+	if status != http.StatusOK {
+		ctx.Status(status)
+		return
+	}
+	if h.WatchRequest {
+		h.watch(ctx)
+		return
+	}
+	db := h.Reconciler.DB()
+	list := []model.CSIDriver{}
+	err := db.List(&list, h.ListOptions(ctx))
+	if err != nil {
+		log.Trace(
+			err,
+			"url",
+			ctx.Request.URL)
+		ctx.Status(http.StatusInternalServerError)
+		return
+	}
+	content := []interface{}{}
+	for _, m := range list {
+		r := &CSIDriver{}
+		r.With(&m)
+		r.Link(h.Provider)
+		content = append(content, r.Content(h.Detail))
+	}
+
+	ctx.JSON(http.StatusOK, content)
+}
+
+//
+// Get a specific REST resource.
+func (h CSIDriverHandler) Get(ctx *gin.Context) {
+	status := h.Prepare(ctx)
+	if status != http.StatusOK {
+		ctx.Status(status)
+		return
+	}
+	m := &model.CSIDriver{
+		Base: model.Base{
+			UID: ctx.Param(CSIDriverParam),
+		},
+	}
+	db := h.Reconciler.DB()
+	err := db.Get(m)
+	if errors.Is(err, model.NotFound) {
+		ctx.Status(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Trace(
+			err,
+			"url",
+			ctx.Request.URL)
+		ctx.Status(http.StatusInternalServerError)
+		return
+	}
+	r := &CSIDriver{}
+	r.With(m)
+	r.Link(h.Provider)
+	content := r.Content(true)
+
+	ctx.JSON(http.StatusOK, content)
+}
+
+//
+// Watch.
+func (h CSIDriverHandler) watch(ctx *gin.Context) {
+	db := h.Reconciler.DB()
+	err := h.Watch(
+		ctx,
+		db,
+		&model.CSIDriver{},
+		func(in libmodel.Model) (r interface{}) {
+			m := in.(*model.CSIDriver)
+			driver := &CSIDriver{}
+			driver.With(m)
+			driver.Link(h.Provider)
+			r = driver
+			return
+		})
+	if err != nil {
+		log.Trace(
+			err,
+			"url",
+			ctx.Request.URL)
+		ctx.Status(http.StatusInternalServerError)
+	}
+}
+
+//
+// REST Resource.
+type CSIDriver struct {
+	Resource
+	Object storage.CSIDriver `json:"object"`
+}
+
+//
+// Set fields with the specified object.
+func (r *CSIDriver) With(m *model.CSIDriver) {
+	r.Resource.With(&m.Base)
+	r.Object = m.Object
+}
+
+//
+// Build self link (URI).
+func (r *CSIDriver) Link(p *api.Provider) {
+	r.SelfLink = base.Link(
+		CSIDriverRoot,
+		base.Params{
+			base.ProviderParam: string(p.UID),
+			CSIDriverParam:     r.UID,
+		})
+}
+
+//
+// As content.
+func (r *CSIDriver) Content(detail bool) interface{} {
+	if !detail {
+		return r.Resource
+	}
+
+	return r
+}
@@ -0,0 +1,166 @@
+package ocp
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	libmodel "github.com/konveyor/controller/pkg/inventory/model"
+	api "github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1"
+	model "github.com/konveyor/forklift-controller/pkg/controller/provider/model/ocp"
+	"github.com/konveyor/forklift-controller/pkg/controller/provider/web/base"
+	cdi "kubevirt.io/containerized-data-importer/pkg/apis/core/v1beta1"
+	"net/http"
+)
+
+//
+// Routes.
+const (
+	DvParam = "dv"
+	DvsRoot = ProviderRoot + "/datavolumes"
+	DvRoot  = DvsRoot + "/:" + DvParam
+)
+
+//
+// DataVolume handler.
+type DvHandler struct {
+	Handler
+}
+
+//
+// Add routes to the `gin` router.
+func (h *DvHandler) AddRoutes(e *gin.Engine) {
+	e.GET(DvsRoot, h.List)
+	e.GET(DvsRoot+"/", h.List)
+	e.GET(DvRoot, h.Get)
+}
+
+//
+// List resources in a REST collection.
+// A GET onn the collection that includes the `X-Watch`
+// header will negotiate an upgrade of the connection
+// to a websocket and push watch events.
+func (h DvHandler) List(ctx *gin.Context) {
+	status := h.Prepare(ctx)
+	if status != http.StatusOK {
+		ctx.Status(status)
+		return
+	}
+	if h.WatchRequest {
+		h.watch(ctx)
+		return
+	}
+	db := h.Reconciler.DB()
+	list := []model.DataVolume{}
+	err := db.List(&list, h.ListOptions(ctx))
+	if err != nil {
+		log.Trace(
+			err,
+			"url",
+			ctx.Request.URL)
+		ctx.Status(http.StatusInternalServerError)
+		return
+	}
+	content := []interface{}{}
+	for _, m := range list {
+		r := &DataVolume{}
+		r.With(&m)
+		r.Link(h.Provider)
+		content = append(content, r.Content(h.Detail))
+	}
+
+	ctx.JSON(http.StatusOK, content)
+}
+
+//
+// Get a specific REST resource.
+func (h DvHandler) Get(ctx *gin.Context) {
+	status := h.Prepare(ctx)
+	if status != http.StatusOK {
+		ctx.Status(status)
+		return
+	}
+	m := &model.DataVolume{
+		Base: model.Base{
+			UID: ctx.Param(DvParam),
+		},
+	}
+	db := h.Reconciler.DB()
+	err := db.Get(m)
+	if errors.Is(err, model.NotFound) {
+		ctx.Status(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Trace(
+			err,
+			"url",
+			ctx.Request.URL)
+		ctx.Status(http.StatusInternalServerError)
+		return
+	}
+	r := &DataVolume{}
+	r.With(m)
+	r.Link(h.Provider)
+	content := r.Content(true)
+
+	ctx.JSON(http.StatusOK, content)
+}
+
+//
+// Watch.
+func (h DvHandler) watch(ctx *gin.Context) {
+	db := h.Reconciler.DB()
+	err := h.Watch(
+		ctx,
+		db,
+		&model.DataVolume{},
+		func(in libmodel.Model) (r interface{}) {
+			m := in.(*model.DataVolume)
+			dv := &DataVolume{}
+			dv.With(m)
+			dv.Link(h.Provider)
+			r = dv
+			return
+		})
+	if err != nil {
+		log.Trace(
+			err,
+			"url",
+			ctx.Request.URL)
+		ctx.Status(http.StatusInternalServerError)
+	}
+}
+
+//
+// REST Resource.
+type DataVolume struct {
+	Resource
+	Object cdi.DataVolume `json:"object"`
+}
+
+//
+// Set fields with the specified object.
+func (r *DataVolume) With(m *model.DataVolume) {
+	r.Resource.With(&m.Base)
+	r.Object = m.Object
+}
+
+//
+// Build self link (URI).
+func (r *DataVolume) Link(p *api.Provider) {
+	r.SelfLink = base.Link(
+		DvRoot,
+		base.Params{
+			base.ProviderParam: string(p.UID),
+			DvParam:            r.UID,
+		})
+}
+
+//
+// As content.
+func (r *DataVolume) Content(detail bool) interface{} {
+	if !detail {
+		return r.Resource
+	}
+
+	return r
+}
@@ -32,6 +32,16 @@ func Handlers(container *container.Container) []libweb.RequestHandler {
 				base.Handler{Container: container},
 			},
 		},
+		&CSIDriverHandler{
+			Handler: Handler{
+				base.Handler{Container: container},
+			},
+		},
+		&StorageProfileHandler{
+			Handler: Handler{
+				base.Handler{Container: container},
+			},
+		},
 		&NadHandler{
 			Handler: Handler{
 				base.Handler{Container: container},
@@ -42,5 +52,35 @@ func Handlers(container *container.Container) []libweb.RequestHandler {
 				base.Handler{Container: container},
 			},
 		},
+		&QuotaHandler{
+			Handler: Handler{
+				base.Handler{Container: container},
+			},
+		},
+		&LimitRangeHandler{
+			Handler: Handler{
+				base.Handler{Container: container},
+			},
+		},
+		&NodeHandler{
+			Handler: Handler{
+				base.Handler{Container: container},
+			},
+		},
+		&PvcHandler{
+			Handler: Handler{
+				base.Handler{Container: container},
+			},
+		},
+		&DvHandler{
+			Handler: Handler{
+				base.Handler{Container: container},
+			},
+		},
+		&VmiHandler{
+			Handler: Handler{
+				base.Handler{Container: container},
+			},
+		},
 	}
 }
@@ -0,0 +1,166 @@
+package ocp
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	libmodel "github.com/konveyor/controller/pkg/inventory/model"
+	api "github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1"
+	model "github.com/konveyor/forklift-controller/pkg/controller/provider/model/ocp"
+	"github.com/konveyor/forklift-controller/pkg/controller/provider/web/base"
+	core "k8s.io/api/core/v1"
+	"net/http"
+)
+
+//
+// Routes.
+const (
+	LimitRangeParam = "limitrange"
+	LimitRangesRoot = ProviderRoot + "/limitranges"
+	LimitRangeRoot  = LimitRangesRoot + "/:" + LimitRangeParam
+)
+
+//
+// LimitRange handler.
+type LimitRangeHandler struct {
+	Handler
+}
+
+//
+// Add routes to the `gin` router.
+func (h *LimitRangeHandler) AddRoutes(e *gin.Engine) {
+	e.GET(LimitRangesRoot, h.List)
+	e.GET(LimitRangesRoot+"/", h.List)
+	e.GET(LimitRangeRoot, h.Get)
+}
+
+//
+// List resources in a REST collection.
+// A GET onn the collection that includes the `X-Watch`
+// header will negotiate an upgrade of the connection
+// to a websocket and push watch events.
+func (h LimitRangeHandler) List(ctx *gin.Context) {
+	status := h.Prepare(ctx)
+	if status != http.StatusOK {
+		ctx.Status(status)
+		return
+	}
+	if h.WatchRequest {
+		h.watch(ctx)
+		return
+	}
+	db := h.Reconciler.DB()
+	list := []model.LimitRange{}
+	err := db.List(&list, h.ListOptions(ctx))
+	if err != nil {
+		log.Trace(
+			err,
+			"url",
+			ctx.Request.URL)
+		ctx.Status(http.StatusInternalServerError)
+		return
+	}
+	content := []interface{}{}
+	for _, m := range list {
+		r := &LimitRange{}
+		r.With(&m)
+		r.Link(h.Provider)
+		content = append(content, r.Content(h.Detail))
+	}
+
+	ctx.JSON(http.StatusOK, content)
+}
+
+//
+// Get a specific REST resource.
+func (h LimitRangeHandler) Get(ctx *gin.Context) {
+	status := h.Prepare(ctx)
+	if status != http.StatusOK {
+		ctx.Status(status)
+		return
+	}
+	m := &model.LimitRange{
+		Base: model.Base{
+			UID: ctx.Param(LimitRangeParam),
+		},
+	}
+	db := h.Reconciler.DB()
+	err := db.Get(m)
+	if errors.Is(err, model.NotFound) {
+		ctx.Status(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Trace(
+			err,
+			"url",
+			ctx.Request.URL)
+		ctx.Status(http.StatusInternalServerError)
+		return
+	}
+	r := &LimitRange{}
+	r.With(m)
+	r.Link(h.Provider)
+	content := r.Content(true)
+
+	ctx.JSON(http.StatusOK, content)
+}
+
+//
+// Watch.
+func (h LimitRangeHandler) watch(ctx *gin.Context) {
+	db := h.Reconciler.DB()
+	err := h.Watch(
+		ctx,
+		db,
+		&model.LimitRange{},
+		func(in libmodel.Model) (r interface{}) {
+			m := in.(*model.LimitRange)
+			lr := &LimitRange{}
+			lr.With(m)
+			lr.Link(h.Provider)
+			r = lr
+			return
+		})
+	if err != nil {
+		log.Trace(
+			err,
+			"url",
+			ctx.Request.URL)
+		ctx.Status(http.StatusInternalServerError)
+	}
+}
+
+//
+// REST Resource.
+type LimitRange struct {
+	Resource
+	Object core.LimitRange `json:"object"`
+}
+
+//
+// Set fields with the specified object.
+func (r *LimitRange) With(m *model.LimitRange) {
+	r.Resource.With(&m.Base)
+	r.Object = m.Object
+}
+
+//
+// Build self link (URI).
+func (r *LimitRange) Link(p *api.Provider) {
+	r.SelfLink = base.Link(
+		LimitRangeRoot,
+		base.Params{
+			base.ProviderParam: string(p.UID),
+			LimitRangeParam:    r.UID,
+		})
+}
+
+//
+// As content.
+func (r *LimitRange) Content(detail bool) interface{} {
+	if !detail {
+		return r.Resource
+	}
+
+	return r
+}
@@ -0,0 +1,200 @@
+package ocp
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	libmodel "github.com/konveyor/controller/pkg/inventory/model"
+	api "github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1"
+	model "github.com/konveyor/forklift-controller/pkg/controller/provider/model/ocp"
+	"github.com/konveyor/forklift-controller/pkg/controller/provider/web/base"
+	core "k8s.io/api/core/v1"
+	"net/http"
+)
+
+//
+// Routes.
+const (
+	NodeParam = "node"
+	NodesRoot = ProviderRoot + "/nodes"
+	NodeRoot  = NodesRoot + "/:" + NodeParam
+)
+
+//
+// KubeVirt schedulable node label.
+const (
+	KubeVirtSchedulable = "kubevirt.io/schedulable"
+)
+
+//
+// Node handler.
+type NodeHandler struct {
+	Handler
+}
+
+//
+// Add routes to the `gin` router.
+func (h *NodeHandler) AddRoutes(e *gin.Engine) {
+	e.GET(NodesRoot, h.List)
+	e.GET(NodesRoot+"/", h.List)
+	e.GET(NodeRoot, h.Get)
+}
+
+//
+// List resources in a REST collection.
+// A GET onn the collection that includes the `X-Watch`
+// header will negotiate an upgrade of the connection
+// to a websocket and push watch events.
+func (h NodeHandler) List(ctx *gin.Context) {
+	status := h.Prepare(ctx)
+	if status != http.StatusOK {
+		ctx.Status(status)
+		return
+	}
+	if h.WatchRequest {
+		h.watch(ctx)
+		return
+	}
+	db := h.Reconciler.DB()
+	list := []model.Node{}
+	err := db.List(&list, h.ListOptions(ctx))
+	if err != nil {
+		log.Trace(
+			err,
+			"url",
+			ctx.Request.URL)
+		ctx.Status(http.StatusInternalServerError)
+		return
+	}
+	content := []interface{}{}
+	for _, m := range list {
+		r := &Node{}
+		r.With(&m)
+		r.Link(h.Provider)
+		content = append(content, r.Content(h.Detail))
+	}
+
+	ctx.JSON(http.StatusOK, content)
+}
+
+//
+// Get a specific REST resource.
+func (h NodeHandler) Get(ctx *gin.Context) {
+	status := h.Prepare(ctx)
+	if status != http.StatusOK {
+		ctx.Status(status)
+		return
+	}
+	m := &model.Node{
+		Base: model.Base{
+			UID: ctx.Param(NodeParam),
+		},
+	}
+	db := h.Reconciler.DB()
+	err := db.Get(m)
+	if errors.Is(err, model.NotFound) {
+		ctx.Status(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Trace(
+			err,
+			"url",
+			ctx.Request.URL)
+		ctx.Status(http.StatusInternalServerError)
+		return
+	}
+	r := &Node{}
+	r.With(m)
+	r.Link(h.Provider)
+	content := r.Content(true)
+
+	ctx.JSON(http.StatusOK, content)
+}
+
+//
+// Watch.
+func (h NodeHandler) watch(ctx *gin.Context) {
+	db := h.Reconciler.DB()
+	err := h.Watch(
+		ctx,
+		db,
+		&model.Node{},
+		func(in libmodel.Model) (r interface{}) {
+			m := in.(*model.Node)
+			node := &Node{}
+			node.With(m)
+			node.Link(h.Provider)
+			r = node
+			return
+		})
+	if err != nil {
+		log.Trace(
+			err,
+			"url",
+			ctx.Request.URL)
+		ctx.Status(http.StatusInternalServerError)
+	}
+}
+
+//
+// REST Resource.
+type Node struct {
+	Resource
+	// Labels.
+	Labels map[string]string `json:"labels"`
+	// Allocatable resources.
+	Allocatable core.ResourceList `json:"allocatable"`
+	// Ready and schedulable for VMs (KubeVirt).
+	Schedulable bool      `json:"schedulable"`
+	Object      core.Node `json:"object"`
+}
+
+//
+// Set fields with the specified object.
+func (r *Node) With(m *model.Node) {
+	r.Resource.With(&m.Base)
+	r.Labels = m.Object.Labels
+	r.Allocatable = m.Object.Status.Allocatable
+	r.Schedulable = r.schedulable(&m.Object)
+	r.Object = m.Object
+}
+
+//
+// Whether the node is ready and schedulable. KubeVirt
+// labels the nodes on which VMs may be scheduled.
+func (r *Node) schedulable(node *core.Node) bool {
+	if node.Spec.Unschedulable {
+		return false
+	}
+	if node.Labels[KubeVirtSchedulable] != "true" {
+		return false
+	}
+	for _, cnd := range node.Status.Conditions {
+		if cnd.Type == core.NodeReady {
+			return cnd.Status == core.ConditionTrue
+		}
+	}
+
+	return false
+}
+
+//
+// Build self link (URI).
+func (r *Node) Link(p *api.Provider) {
+	r.SelfLink = base.Link(
+		NodeRoot,
+		base.Params{
+			base.ProviderParam: string(p.UID),
+			NodeParam:          r.UID,
+		})
+}
+
+//
+// As content.
+func (r *Node) Content(detail bool) interface{} {
+	if !detail {
+		return r.Resource
+	}
+
+	return r
+}
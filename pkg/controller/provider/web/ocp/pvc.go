@@ -0,0 +1,166 @@
+package ocp
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	libmodel "github.com/konveyor/controller/pkg/inventory/model"
+	api "github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1"
+	model "github.com/konveyor/forklift-controller/pkg/controller/provider/model/ocp"
+	"github.com/konveyor/forklift-controller/pkg/controller/provider/web/base"
+	core "k8s.io/api/core/v1"
+	"net/http"
+)
+
+//
+// Routes.
+const (
+	PvcParam = "pvc"
+	PvcsRoot = ProviderRoot + "/persistentvolumeclaims"
+	PvcRoot  = PvcsRoot + "/:" + PvcParam
+)
+
+//
+// PersistentVolumeClaim handler.
+type PvcHandler struct {
+	Handler
+}
+
+//
+// Add routes to the `gin` router.
+func (h *PvcHandler) AddRoutes(e *gin.Engine) {
+	e.GET(PvcsRoot, h.List)
+	e.GET(PvcsRoot+"/", h.List)
+	e.GET(PvcRoot, h.Get)
+}
+
+//
+// List resources in a REST collection.
+// A GET onn the collection that includes the `X-Watch`
+// header will negotiate an upgrade of the connection
+// to a websocket and push watch events.
+func (h PvcHandler) List(ctx *gin.Context) {
+	status := h.Prepare(ctx)
+	if status != http.StatusOK {
+		ctx.Status(status)
+		return
+	}
+	if h.WatchRequest {
+		h.watch(ctx)
+		return
+	}
+	db := h.Reconciler.DB()
+	list := []model.PersistentVolumeClaim{}
+	err := db.List(&list, h.ListOptions(ctx))
+	if err != nil {
+		log.Trace(
+			err,
+			"url",
+			ctx.Request.URL)
+		ctx.Status(http.StatusInternalServerError)
+		return
+	}
+	content := []interface{}{}
+	for _, m := range list {
+		r := &PersistentVolumeClaim{}
+		r.With(&m)
+		r.Link(h.Provider)
+		content = append(content, r.Content(h.Detail))
+	}
+
+	ctx.JSON(http.StatusOK, content)
+}
+
+//
+// Get a specific REST resource.
+func (h PvcHandler) Get(ctx *gin.Context) {
+	status := h.Prepare(ctx)
+	if status != http.StatusOK {
+		ctx.Status(status)
+		return
+	}
+	m := &model.PersistentVolumeClaim{
+		Base: model.Base{
+			UID: ctx.Param(PvcParam),
+		},
+	}
+	db := h.Reconciler.DB()
+	err := db.Get(m)
+	if errors.Is(err, model.NotFound) {
+		ctx.Status(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Trace(
+			err,
+			"url",
+			ctx.Request.URL)
+		ctx.Status(http.StatusInternalServerError)
+		return
+	}
+	r := &PersistentVolumeClaim{}
+	r.With(m)
+	r.Link(h.Provider)
+	content := r.Content(true)
+
+	ctx.JSON(http.StatusOK, content)
+}
+
+//
+// Watch.
+func (h PvcHandler) watch(ctx *gin.Context) {
+	db := h.Reconciler.DB()
+	err := h.Watch(
+		ctx,
+		db,
+		&model.PersistentVolumeClaim{},
+		func(in libmodel.Model) (r interface{}) {
+			m := in.(*model.PersistentVolumeClaim)
+			pvc := &PersistentVolumeClaim{}
+			pvc.With(m)
+			pvc.Link(h.Provider)
+			r = pvc
+			return
+		})
+	if err != nil {
+		log.Trace(
+			err,
+			"url",
+			ctx.Request.URL)
+		ctx.Status(http.StatusInternalServerError)
+	}
+}
+
+//
+// REST Resource.
+type PersistentVolumeClaim struct {
+	Resource
+	Object core.PersistentVolumeClaim `json:"object"`
+}
+
+//
+// Set fields with the specified object.
+func (r *PersistentVolumeClaim) With(m *model.PersistentVolumeClaim) {
+	r.Resource.With(&m.Base)
+	r.Object = m.Object
+}
+
+//
+// Build self link (URI).
+func (r *PersistentVolumeClaim) Link(p *api.Provider) {
+	r.SelfLink = base.Link(
+		PvcRoot,
+		base.Params{
+			base.ProviderParam: string(p.UID),
+			PvcParam:           r.UID,
+		})
+}
+
+//
+// As content.
+func (r *PersistentVolumeClaim) Content(detail bool) interface{} {
+	if !detail {
+		return r.Resource
+	}
+
+	return r
+}
@@ -0,0 +1,166 @@
+package ocp
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	libmodel "github.com/konveyor/controller/pkg/inventory/model"
+	api "github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1"
+	model "github.com/konveyor/forklift-controller/pkg/controller/provider/model/ocp"
+	"github.com/konveyor/forklift-controller/pkg/controller/provider/web/base"
+	core "k8s.io/api/core/v1"
+	"net/http"
+)
+
+//
+// Routes.
+const (
+	QuotaParam = "quota"
+	QuotasRoot = ProviderRoot + "/quotas"
+	QuotaRoot  = QuotasRoot + "/:" + QuotaParam
+)
+
+//
+// ResourceQuota handler.
+type QuotaHandler struct {
+	Handler
+}
+
+//
+// Add routes to the `gin` router.
+func (h *QuotaHandler) AddRoutes(e *gin.Engine) {
+	e.GET(QuotasRoot, h.List)
+	e.GET(QuotasRoot+"/", h.List)
+	e.GET(QuotaRoot, h.Get)
+}
+
+//
+// List resources in a REST collection.
+// A GET onn the collection that includes the `X-Watch`
+// header will negotiate an upgrade of the connection
+// to a websocket and push watch events.
+func (h QuotaHandler) List(ctx *gin.Context) {
+	status := h.Prepare(ctx)
+	if status != http.StatusOK {
+		ctx.Status(status)
+		return
+	}
+	if h.WatchRequest {
+		h.watch(ctx)
+		return
+	}
+	db := h.Reconciler.DB()
+	list := []model.ResourceQuota{}
+	err := db.List(&list, h.ListOptions(ctx))
+	if err != nil {
+		log.Trace(
+			err,
+			"url",
+			ctx.Request.URL)
+		ctx.Status(http.StatusInternalServerError)
+		return
+	}
+	content := []interface{}{}
+	for _, m := range list {
+		r := &ResourceQuota{}
+		r.With(&m)
+		r.Link(h.Provider)
+		content = append(content, r.Content(h.Detail))
+	}
+
+	ctx.JSON(http.StatusOK, content)
+}
+
+//
+// Get a specific REST resource.
+func (h QuotaHandler) Get(ctx *gin.Context) {
+	status := h.Prepare(ctx)
+	if status != http.StatusOK {
+		ctx.Status(status)
+		return
+	}
+	m := &model.ResourceQuota{
+		Base: model.Base{
+			UID: ctx.Param(QuotaParam),
+		},
+	}
+	db := h.Reconciler.DB()
+	err := db.Get(m)
+	if errors.Is(err, model.NotFound) {
+		ctx.Status(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Trace(
+			err,
+			"url",
+			ctx.Request.URL)
+		ctx.Status(http.StatusInternalServerError)
+		return
+	}
+	r := &ResourceQuota{}
+	r.With(m)
+	r.Link(h.Provider)
+	content := r.Content(true)
+
+	ctx.JSON(http.StatusOK, content)
+}
+
+//
+// Watch.
+func (h QuotaHandler) watch(ctx *gin.Context) {
+	db := h.Reconciler.DB()
+	err := h.Watch(
+		ctx,
+		db,
+		&model.ResourceQuota{},
+		func(in libmodel.Model) (r interface{}) {
+			m := in.(*model.ResourceQuota)
+			quota := &ResourceQuota{}
+			quota.With(m)
+			quota.Link(h.Provider)
+			r = quota
+			return
+		})
+	if err != nil {
+		log.Trace(
+			err,
+			"url",
+			ctx.Request.URL)
+		ctx.Status(http.StatusInternalServerError)
+	}
+}
+
+//
+// REST Resource.
+type ResourceQuota struct {
+	Resource
+	Object core.ResourceQuota `json:"object"`
+}
+
+//
+// Set fields with the specified object.
+func (r *ResourceQuota) With(m *model.ResourceQuota) {
+	r.Resource.With(&m.Base)
+	r.Object = m.Object
+}
+
+//
+// Build self link (URI).
+func (r *ResourceQuota) Link(p *api.Provider) {
+	r.SelfLink = base.Link(
+		QuotaRoot,
+		base.Params{
+			base.ProviderParam: string(p.UID),
+			QuotaParam:         r.UID,
+		})
+}
+
+//
+// As content.
+func (r *ResourceQuota) Content(detail bool) interface{} {
+	if !detail {
+		return r.Resource
+	}
+
+	return r
+}
@@ -0,0 +1,206 @@
+package ocp
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	libmodel "github.com/konveyor/controller/pkg/inventory/model"
+	api "github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1"
+	model "github.com/konveyor/forklift-controller/pkg/controller/provider/model/ocp"
+	"github.com/konveyor/forklift-controller/pkg/controller/provider/web/base"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"net/http"
+)
+
+//
+// Routes.
+const (
+	StorageProfileParam = "storageprofile"
+	StorageProfilesRoot = ProviderRoot + "/storageprofiles"
+	StorageProfileRoot  = StorageProfilesRoot + "/:" + StorageProfileParam
+)
+
+//
+// StorageProfile handler.
+type StorageProfileHandler struct {
+	Handler
+}
+
+//
+// Add routes to the `gin` router.
+func (h *StorageProfileHandler) AddRoutes(e *gin.Engine) {
+	e.GET(StorageProfilesRoot, h.List)
+	e.GET(StorageProfilesRoot+"/", h.List)
+	e.GET(StorageProfileRoot, h.Get)
+}
+
+//
+// List resources in a REST collection.
+// A GET onn the collection that includes the `X-Watch`
+// header will negotiate an upgrade of the connection
+// to a websocket and push watch events.
+func (h StorageProfileHandler) List(ctx *gin.Context) {
+	status := h.Prepare(ctx)
+	if status != http.StatusOK {
+		ctx.Status(status)
+		return
+	}
+	if h.WatchRequest {
+		h.watch(ctx)
+		return
+	}
+	db := h.Reconciler.DB()
+	list := []model.StorageProfile{}
+	err := db.List(&list, h.ListOptions(ctx))
+	if err != nil {
+		log.Trace(
+			err,
+			"url",
+			ctx.Request.URL)
+		ctx.Status(http.StatusInternalServerError)
+		return
+	}
+	content := []interface{}{}
+	for _, m := range list {
+		r := &StorageProfile{}
+		r.With(&m)
+		r.Link(h.Provider)
+		content = append(content, r.Content(h.Detail))
+	}
+
+	ctx.JSON(http.StatusOK, content)
+}
+
+//
+// Get a specific REST resource.
+func (h StorageProfileHandler) Get(ctx *gin.Context) {
+	status := h.Prepare(ctx)
+	if status != http.StatusOK {
+		ctx.Status(status)
+		return
+	}
+	m := &model.StorageProfile{
+		Base: model.Base{
+			UID: ctx.Param(StorageProfileParam),
+		},
+	}
+	db := h.Reconciler.DB()
+	err := db.Get(m)
+	if errors.Is(err, model.NotFound) {
+		ctx.Status(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Trace(
+			err,
+			"url",
+			ctx.Request.URL)
+		ctx.Status(http.StatusInternalServerError)
+		return
+	}
+	r := &StorageProfile{}
+	r.With(m)
+	r.Link(h.Provider)
+	content := r.Content(true)
+
+	ctx.JSON(http.StatusOK, content)
+}
+
+//
+// Watch.
+func (h StorageProfileHandler) watch(ctx *gin.Context) {
+	db := h.Reconciler.DB()
+	err := h.Watch(
+		ctx,
+		db,
+		&model.StorageProfile{},
+		func(in libmodel.Model) (r interface{}) {
+			m := in.(*model.StorageProfile)
+			profile := &StorageProfile{}
+			profile.With(m)
+			profile.Link(h.Provider)
+			r = profile
+			return
+		})
+	if err != nil {
+		log.Trace(
+			err,
+			"url",
+			ctx.Request.URL)
+		ctx.Status(http.StatusInternalServerError)
+	}
+}
+
+//
+// REST Resource.
+type StorageProfile struct {
+	Resource
+	// Storage class.
+	StorageClass string `json:"storageClass"`
+	// Provisioner.
+	Provisioner string `json:"provisioner"`
+	// Claim property sets reported by CDI.
+	// Listed in order of preference.
+	ClaimPropertySets []ClaimPropertySet     `json:"claimPropertySets"`
+	Object            map[string]interface{} `json:"object"`
+}
+
+//
+// Claim property set.
+type ClaimPropertySet struct {
+	// Volume mode.
+	VolumeMode core.PersistentVolumeMode `json:"volumeMode"`
+	// Access modes.
+	AccessModes []core.PersistentVolumeAccessMode `json:"accessModes"`
+}
+
+//
+// Set fields with the specified object.
+// Claim property sets without a volume mode are Filesystem.
+func (r *StorageProfile) With(m *model.StorageProfile) {
+	r.Resource.With(&m.Base)
+	r.Object = m.Object
+	r.StorageClass, _, _ = unstructured.NestedString(m.Object, "status", "storageClass")
+	r.Provisioner, _, _ = unstructured.NestedString(m.Object, "status", "provisioner")
+	r.ClaimPropertySets = []ClaimPropertySet{}
+	list, _, _ := unstructured.NestedSlice(m.Object, "status", "claimPropertySets")
+	for _, item := range list {
+		object, cast := item.(map[string]interface{})
+		if !cast {
+			continue
+		}
+		set := ClaimPropertySet{
+			VolumeMode:  core.PersistentVolumeFilesystem,
+			AccessModes: []core.PersistentVolumeAccessMode{},
+		}
+		if mode, found, _ := unstructured.NestedString(object, "volumeMode"); found && mode != "" {
+			set.VolumeMode = core.PersistentVolumeMode(mode)
+		}
+		modes, _, _ := unstructured.NestedStringSlice(object, "accessModes")
+		for _, mode := range modes {
+			set.AccessModes = append(set.AccessModes, core.PersistentVolumeAccessMode(mode))
+		}
+		r.ClaimPropertySets = append(r.ClaimPropertySets, set)
+	}
+}
+
+//
+// Build self link (URI).
+func (r *StorageProfile) Link(p *api.Provider) {
+	r.SelfLink = base.Link(
+		StorageProfileRoot,
+		base.Params{
+			base.ProviderParam:  string(p.UID),
+			StorageProfileParam: r.UID,
+		})
+}
+
+//
+// As content.
+func (r *StorageProfile) Content(detail bool) interface{} {
+	if !detail {
+		return r.Resource
+	}
+
+	return r
+}
@@ -0,0 +1,166 @@
+package ocp
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	libmodel "github.com/konveyor/controller/pkg/inventory/model"
+	api "github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1"
+	model "github.com/konveyor/forklift-controller/pkg/controller/provider/model/ocp"
+	"github.com/konveyor/forklift-controller/pkg/controller/provider/web/base"
+	cnv "kubevirt.io/client-go/api/v1"
+	"net/http"
+)
+
+//
+// Routes.
+const (
+	VmiParam = "vmi"
+	VmisRoot = ProviderRoot + "/vmis"
+	VmiRoot  = VmisRoot + "/:" + VmiParam
+)
+
+//
+// VMI handler.
+type VmiHandler struct {
+	Handler
+}
+
+//
+// Add routes to the `gin` router.
+func (h *VmiHandler) AddRoutes(e *gin.Engine) {
+	e.GET(VmisRoot, h.List)
+	e.GET(VmisRoot+"/", h.List)
+	e.GET(VmiRoot, h.Get)
+}
+
+//
+// List resources in a REST collection.
+// A GET onn the collection that includes the `X-Watch`
+// header will negotiate an upgrade of the connection
+// to a websocket and push watch events.
+func (h VmiHandler) List(ctx *gin.Context) {
+	status := h.Prepare(ctx)
+	if status != http.StatusOK {
+		ctx.Status(status)
+		return
+	}
+	if h.WatchRequest {
+		h.watch(ctx)
+		return
+	}
+	db := h.Reconciler.DB()
+	list := []model.VMI{}
+	err := db.List(&list, h.ListOptions(ctx))
+	if err != nil {
+		log.Trace(
+			err,
+			"url",
+			ctx.Request.URL)
+		ctx.Status(http.StatusInternalServerError)
+		return
+	}
+	content := []interface{}{}
+	for _, m := range list {
+		r := &VMI{}
+		r.With(&m)
+		r.Link(h.Provider)
+		content = append(content, r.Content(h.Detail))
+	}
+
+	ctx.JSON(http.StatusOK, content)
+}
+
+//
+// Get a specific REST resource.
+func (h VmiHandler) Get(ctx *gin.Context) {
+	status := h.Prepare(ctx)
+	if status != http.StatusOK {
+		ctx.Status(status)
+		return
+	}
+	m := &model.VMI{
+		Base: model.Base{
+			UID: ctx.Param(VmiParam),
+		},
+	}
+	db := h.Reconciler.DB()
+	err := db.Get(m)
+	if errors.Is(err, model.NotFound) {
+		ctx.Status(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Trace(
+			err,
+			"url",
+			ctx.Request.URL)
+		ctx.Status(http.StatusInternalServerError)
+		return
+	}
+	r := &VMI{}
+	r.With(m)
+	r.Link(h.Provider)
+	content := r.Content(true)
+
+	ctx.JSON(http.StatusOK, content)
+}
+
+//
+// Watch.
+func (h VmiHandler) watch(ctx *gin.Context) {
+	db := h.Reconciler.DB()
+	err := h.Watch(
+		ctx,
+		db,
+		&model.VMI{},
+		func(in libmodel.Model) (r interface{}) {
+			m := in.(*model.VMI)
+			vmi := &VMI{}
+			vmi.With(m)
+			vmi.Link(h.Provider)
+			r = vmi
+			return
+		})
+	if err != nil {
+		log.Trace(
+			err,
+			"url",
+			ctx.Request.URL)
+		ctx.Status(http.StatusInternalServerError)
+	}
+}
+
+//
+// REST Resource.
+type VMI struct {
+	Resource
+	Object cnv.VirtualMachineInstance `json:"object"`
+}
+
+//
+// Set fields with the specified object.
+func (r *VMI) With(m *model.VMI) {
+	r.Resource.With(&m.Base)
+	r.Object = m.Object
+}
+
+//
+// Build self link (URI).
+func (r *VMI) Link(p *api.Provider) {
+	r.SelfLink = base.Link(
+		VmiRoot,
+		base.Params{
+			base.ProviderParam: string(p.UID),
+			VmiParam:           r.UID,
+		})
+}
+
+//
+// As content.
+func (r *VMI) Content(detail bool) interface{} {
+	if !detail {
+		return r.Resource
+	}
+
+	return r
+}
@@ -4,6 +4,7 @@ import (
 	liberr "github.com/konveyor/controller/pkg/error"
 	api "github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1"
 	"github.com/konveyor/forklift-controller/pkg/controller/provider/web/base"
+	"github.com/konveyor/forklift-controller/pkg/controller/provider/web/suggest"
 	"strings"
 )
 
@@ -59,6 +60,18 @@ func (r *Resolver) Path(resource interface{}, id string) (path string, err error
 		r.ID = id
 		r.Link(provider)
 		path = r.SelfLink
+	case *suggest.NetworkSuggestion:
+		path = base.Link(
+			NetworkSuggestionsRoot,
+			base.Params{
+				base.ProviderParam: string(provider.UID),
+			})
+	case *suggest.StorageSuggestion:
+		path = base.Link(
+			StorageSuggestionsRoot,
+			base.Params{
+				base.ProviderParam: string(provider.UID),
+			})
 	default:
 		err = liberr.Wrap(
 			base.ResourceNotResolvedError{
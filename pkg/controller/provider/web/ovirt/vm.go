@@ -167,6 +167,7 @@ type VM struct {
 	Host                        string           `json:"host"`
 	RevisionValidated           int64            `json:"revisionValidated"`
 	GuestName                   string           `json:"guestName"`
+	GuestFamily                 string           `json:"guestFamily"`
 	CpuSockets                  int16            `json:"cpuSockets"`
 	CpuCores                    int16            `json:"cpuCores"`
 	CpuShares                   int16            `json:"cpuShares"`
@@ -226,6 +227,7 @@ func (r *VM) With(m *model.VM) {
 	r.Host = m.Host
 	r.RevisionValidated = m.RevisionValidated
 	r.GuestName = m.GuestName
+	r.GuestFamily = m.GuestFamily
 	r.CpuSockets = m.CpuSockets
 	r.CpuCores = m.CpuCores
 	r.CpuShares = m.CpuShares
@@ -0,0 +1,143 @@
+package suggest
+
+import (
+	"context"
+	liberr "github.com/konveyor/controller/pkg/error"
+	libcontainer "github.com/konveyor/controller/pkg/inventory/container"
+	libmodel "github.com/konveyor/controller/pkg/inventory/model"
+	api "github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1"
+	model "github.com/konveyor/forklift-controller/pkg/controller/provider/model/ocp"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+	"sync"
+)
+
+//
+// k8s API reader.
+// Used to list Provisioner CRs.
+var reader struct {
+	client.Reader
+	mutex sync.Mutex
+}
+
+//
+// Destination (OpenShift) provider inventory.
+type Destination struct {
+	// Network attachment definitions.
+	NADs []NAD
+	// Storage classes.
+	StorageClasses []StorageClass
+}
+
+//
+// Load the destination inventory.
+// The namespace (when specified) limits the NADs to those
+// in the namespace and the `default` namespace.
+func (r *Destination) Load(container *libcontainer.Container, uid, namespace string) (found bool, err error) {
+	provider := &api.Provider{
+		ObjectMeta: meta.ObjectMeta{
+			UID: types.UID(uid),
+		},
+	}
+	reconciler, found := container.Get(provider)
+	if !found {
+		return
+	}
+	provider = reconciler.Owner().(*api.Provider)
+	if provider.Type() != api.OpenShift {
+		found = false
+		return
+	}
+	db := reconciler.DB()
+	nadList := []model.NetworkAttachmentDefinition{}
+	err = db.List(&nadList, libmodel.ListOptions{Detail: 1})
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	r.NADs = []NAD{}
+	for _, m := range nadList {
+		if namespace != "" && m.Namespace != namespace && m.Namespace != "default" {
+			continue
+		}
+		r.NADs = append(
+			r.NADs,
+			NADWith(m.Namespace, m.Name, m.Object.Spec.Config))
+	}
+	classList := []model.StorageClass{}
+	err = db.List(&classList, libmodel.ListOptions{Detail: 1})
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	r.StorageClasses = []StorageClass{}
+	for _, m := range classList {
+		r.StorageClasses = append(
+			r.StorageClasses,
+			StorageClassWith(
+				m.Name,
+				m.Object.Provisioner,
+				m.Object.Annotations))
+	}
+
+	return
+}
+
+//
+// Load the Provisioner CRs in the namespace.
+// Returns a map keyed by provisioner name.
+func Provisioners(namespace string) (provisioners map[string]*api.Provisioner, err error) {
+	r, err := newReader()
+	if err != nil {
+		return
+	}
+	list := &api.ProvisionerList{}
+	err = r.List(
+		context.TODO(),
+		list,
+		&client.ListOptions{
+			Namespace: namespace,
+		})
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	provisioners = map[string]*api.Provisioner{}
+	for i := range list.Items {
+		p := &list.Items[i]
+		provisioners[p.Spec.Name] = p
+	}
+
+	return
+}
+
+//
+// Build (or get cached) API reader.
+func newReader() (r client.Reader, err error) {
+	reader.mutex.Lock()
+	defer reader.mutex.Unlock()
+	if reader.Reader != nil {
+		r = reader.Reader
+		return
+	}
+	cfg, err := config.GetConfig()
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	r, err = client.New(
+		cfg,
+		client.Options{
+			Scheme: scheme.Scheme,
+		})
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	reader.Reader = r
+
+	return
+}
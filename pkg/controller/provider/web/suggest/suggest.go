@@ -0,0 +1,338 @@
+package suggest
+
+import (
+	"encoding/json"
+	api "github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1"
+	"github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1/ref"
+	core "k8s.io/api/core/v1"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+//
+// Params.
+const (
+	// Destination provider UID.
+	DestinationParam = "destination"
+	// Source VM ID (repeated).
+	VMParam = "vm"
+	// Target namespace.
+	NamespaceParam = "namespace"
+)
+
+//
+// Confidence scores (0-100).
+const (
+	// Names are equal.
+	NameEqual = 40
+	// Names are similar.
+	NameSimilar = 20
+	// VLAN IDs are equal.
+	VLanEqual = 60
+	// Storage (file|block) matches the provisioner.
+	StorageType = 30
+	// The provisioner supports ReadWriteMany.
+	SharedAccess = 10
+	// The default storage class.
+	DefaultClass = 10
+	// Maximum.
+	MaxConfidence = 100
+)
+
+//
+// Annotations
+const (
+	// Default storage class.
+	annDefaultClass = "storageclass.kubernetes.io/is-default-class"
+)
+
+//
+// Storage types.
+const (
+	File  = "file"
+	Block = "block"
+)
+
+//
+// Source network.
+type SourceNetwork struct {
+	ID   string
+	Name string
+	VLan string
+}
+
+//
+// Source storage (datastore|storage domain).
+type SourceStorage struct {
+	ID   string
+	Name string
+	// Provider specific type.
+	Type string
+}
+
+//
+// Destination network attachment definition.
+type NAD struct {
+	Namespace string
+	Name      string
+	VLan      string
+}
+
+//
+// Destination storage class.
+type StorageClass struct {
+	Name        string
+	Provisioner string
+	Default     bool
+}
+
+//
+// Suggested network mapping.
+type NetworkSuggestion struct {
+	// Source network.
+	Source ref.Ref `json:"source"`
+	// Destination network.
+	Destination api.DestinationNetwork `json:"destination"`
+	// Confidence (0-100).
+	Confidence int `json:"confidence"`
+}
+
+//
+// Suggested storage mapping.
+type StorageSuggestion struct {
+	// Source storage.
+	Source ref.Ref `json:"source"`
+	// Destination storage.
+	Destination api.DestinationStorage `json:"destination"`
+	// Confidence (0-100).
+	Confidence int `json:"confidence"`
+}
+
+//
+// Suggest network mappings.
+// Each source network is matched with the NAD with the highest
+// score based on the VLAN ID and the name. Networks without a
+// match are mapped to the pod network with zero confidence.
+func Networks(source []SourceNetwork, destination []NAD) (list []NetworkSuggestion) {
+	for _, network := range source {
+		suggestion := NetworkSuggestion{
+			Source: ref.Ref{
+				ID:   network.ID,
+				Name: network.Name,
+			},
+			Destination: api.DestinationNetwork{
+				Type: "pod",
+			},
+		}
+		for _, nad := range destination {
+			score := 0
+			if network.VLan != "" && network.VLan == nad.VLan {
+				score += VLanEqual
+			}
+			score += compareNames(network.Name, nad.Name)
+			if score > suggestion.Confidence {
+				suggestion.Confidence = score
+				suggestion.Destination = api.DestinationNetwork{
+					Type:      "multus",
+					Namespace: nad.Namespace,
+					Name:      nad.Name,
+				}
+			}
+		}
+		suggestion.Confidence = capped(suggestion.Confidence)
+		list = append(list, suggestion)
+	}
+
+	return
+}
+
+//
+// Suggest storage mappings.
+// Each source storage is matched with the storage class with the
+// highest score based on the name, the storage type and the features
+// of the provisioner described by the Provisioner CRs.
+func Storage(source []SourceStorage, destination []StorageClass, provisioners map[string]*api.Provisioner) (list []StorageSuggestion) {
+	for _, storage := range source {
+		suggestion := StorageSuggestion{
+			Source: ref.Ref{
+				ID:   storage.ID,
+				Name: storage.Name,
+			},
+		}
+		matched := -1
+		for _, class := range destination {
+			score := compareNames(storage.Name, class.Name)
+			provisioner := provisioners[class.Provisioner]
+			switch storageType(storage.Type) {
+			case File:
+				if strings.Contains(strings.ToLower(class.Provisioner), "nfs") {
+					score += StorageType
+				}
+			case Block:
+				if provisioner != nil && hasVolumeMode(provisioner, core.PersistentVolumeBlock) {
+					score += StorageType
+				}
+			}
+			if provisioner != nil && hasAccessMode(provisioner, core.ReadWriteMany) {
+				score += SharedAccess
+			}
+			if class.Default {
+				score += DefaultClass
+			}
+			if score > matched {
+				matched = score
+				suggestion.Confidence = score
+				suggestion.Destination = api.DestinationStorage{
+					StorageClass: class.Name,
+				}
+			}
+		}
+		suggestion.Confidence = capped(suggestion.Confidence)
+		list = append(list, suggestion)
+	}
+
+	return
+}
+
+//
+// Build a NAD from the CNI configuration.
+func NADWith(namespace, name, config string) (nad NAD) {
+	nad = NAD{
+		Namespace: namespace,
+		Name:      name,
+	}
+	type Plugin struct {
+		VLan *int `json:"vlan"`
+	}
+	cni := struct {
+		Plugin
+		Plugins []Plugin `json:"plugins"`
+	}{}
+	err := json.Unmarshal([]byte(config), &cni)
+	if err != nil {
+		return
+	}
+	plugins := append([]Plugin{cni.Plugin}, cni.Plugins...)
+	for _, p := range plugins {
+		if p.VLan != nil && *p.VLan > 0 {
+			nad.VLan = strconv.Itoa(*p.VLan)
+			break
+		}
+	}
+
+	return
+}
+
+//
+// Build a storage class.
+func StorageClassWith(name, provisioner string, annotations map[string]string) StorageClass {
+	return StorageClass{
+		Name:        name,
+		Provisioner: provisioner,
+		Default:     annotations[annDefaultClass] == "true",
+	}
+}
+
+//
+// Sort suggestions by source name.
+func SortNetworks(list []NetworkSuggestion) {
+	sort.Slice(
+		list,
+		func(i, j int) bool {
+			return list[i].Source.Name < list[j].Source.Name
+		})
+}
+
+//
+// Sort suggestions by source name.
+func SortStorage(list []StorageSuggestion) {
+	sort.Slice(
+		list,
+		func(i, j int) bool {
+			return list[i].Source.Name < list[j].Source.Name
+		})
+}
+
+//
+// Compare names.
+// Names are compared case insensitive and
+// ignoring non-alphanumeric characters.
+func compareNames(a, b string) (score int) {
+	a = normalized(a)
+	b = normalized(b)
+	if a == "" || b == "" {
+		return
+	}
+	switch {
+	case a == b:
+		score = NameEqual
+	case strings.Contains(a, b), strings.Contains(b, a):
+		score = NameSimilar
+	}
+
+	return
+}
+
+//
+// Normalize a name.
+func normalized(name string) string {
+	return strings.Map(
+		func(r rune) rune {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				return unicode.ToLower(r)
+			}
+			return -1
+		},
+		name)
+}
+
+//
+// Classify the provider specific storage type.
+func storageType(kind string) string {
+	switch strings.ToLower(kind) {
+	case "nfs", "nfs41", "glusterfs", "posixfs":
+		return File
+	case "vmfs", "vsan", "vvol", "iscsi", "fcp":
+		return Block
+	}
+
+	return ""
+}
+
+//
+// Determine whether the provisioner supports the volume mode.
+func hasVolumeMode(provisioner *api.Provisioner, mode core.PersistentVolumeMode) bool {
+	for _, vm := range provisioner.Spec.VolumeModes {
+		if vm.Name == mode {
+			return true
+		}
+	}
+
+	return false
+}
+
+//
+// Determine whether the provisioner supports the access mode.
+func hasAccessMode(provisioner *api.Provisioner, mode core.PersistentVolumeAccessMode) bool {
+	for _, vm := range provisioner.Spec.VolumeModes {
+		for _, am := range vm.AccessModes {
+			if am.Name == mode {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+//
+// Cap the confidence.
+func capped(n int) int {
+	if n > MaxConfidence {
+		n = MaxConfidence
+	}
+
+	return n
+}
@@ -0,0 +1,87 @@
+package suggest
+
+import (
+	api "github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1"
+	"github.com/onsi/gomega"
+	core "k8s.io/api/core/v1"
+	"testing"
+)
+
+func TestNetworks(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	nads := []NAD{
+		NADWith("ns", "prod", `{"type":"cnv-bridge","bridge":"br1","vlan":100}`),
+		NADWith("ns", "vlan-200", `{"plugins":[{"type":"cnv-bridge","vlan":200}]}`),
+		NADWith("ns", "Backup-Net", `{"type":"cnv-bridge"}`),
+	}
+	g.Expect(nads[0].VLan).To(gomega.Equal("100"))
+	g.Expect(nads[1].VLan).To(gomega.Equal("200"))
+
+	list := Networks(
+		[]SourceNetwork{
+			{ID: "n1", Name: "VM Network", VLan: "200"},
+			{ID: "n2", Name: "backup_net"},
+			{ID: "n3", Name: "prod", VLan: "100"},
+			{ID: "n4", Name: "other"},
+		},
+		nads)
+	g.Expect(len(list)).To(gomega.Equal(4))
+	// VLAN.
+	g.Expect(list[0].Destination.Name).To(gomega.Equal("vlan-200"))
+	g.Expect(list[0].Confidence).To(gomega.Equal(VLanEqual))
+	// Name.
+	g.Expect(list[1].Destination.Name).To(gomega.Equal("Backup-Net"))
+	g.Expect(list[1].Confidence).To(gomega.Equal(NameEqual))
+	// VLAN and name.
+	g.Expect(list[2].Destination.Name).To(gomega.Equal("prod"))
+	g.Expect(list[2].Confidence).To(gomega.Equal(MaxConfidence))
+	// No match.
+	g.Expect(list[3].Destination.Type).To(gomega.Equal("pod"))
+	g.Expect(list[3].Confidence).To(gomega.Equal(0))
+}
+
+func TestStorage(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	classes := []StorageClass{
+		StorageClassWith("nfs", "example.com/nfs", nil),
+		StorageClassWith("ceph-rbd", "rbd.csi.ceph.com", nil),
+		StorageClassWith(
+			"standard",
+			"kubernetes.io/no-provisioner",
+			map[string]string{
+				annDefaultClass: "true",
+			}),
+	}
+	provisioners := map[string]*api.Provisioner{
+		"rbd.csi.ceph.com": {
+			Spec: api.ProvisionerSpec{
+				Name: "rbd.csi.ceph.com",
+				VolumeModes: []api.VolumeMode{
+					{
+						Name: core.PersistentVolumeBlock,
+						AccessModes: []api.AccessMode{
+							{Name: core.ReadWriteMany},
+						},
+					},
+				},
+			},
+		},
+	}
+	list := Storage(
+		[]SourceStorage{
+			{ID: "d1", Name: "datastore1", Type: "VMFS"},
+			{ID: "d2", Name: "nfs-share", Type: "NFS"},
+			{ID: "d3", Name: "local", Type: "other"},
+		},
+		classes,
+		provisioners)
+	g.Expect(len(list)).To(gomega.Equal(3))
+	g.Expect(list[0].Destination.StorageClass).To(gomega.Equal("ceph-rbd"))
+	g.Expect(list[0].Confidence).To(gomega.Equal(StorageType + SharedAccess))
+	g.Expect(list[1].Destination.StorageClass).To(gomega.Equal("nfs"))
+	g.Expect(list[1].Confidence).To(gomega.Equal(NameSimilar + StorageType))
+	g.Expect(list[2].Destination.StorageClass).To(gomega.Equal("ceph-rbd"))
+	g.Expect(list[2].Confidence).To(gomega.Equal(SharedAccess))
+}
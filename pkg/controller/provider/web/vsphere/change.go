@@ -0,0 +1,25 @@
+package vsphere
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/konveyor/forklift-controller/pkg/controller/provider/web/base"
+)
+
+//
+// Routes.
+const (
+	ChangesRoot = ProviderRoot + "/" + base.ChangeCollection
+)
+
+//
+// Change (feed) handler.
+type ChangeHandler struct {
+	base.ChangeHandler
+}
+
+//
+// Add routes to the `gin` router.
+func (h *ChangeHandler) AddRoutes(e *gin.Engine) {
+	e.GET(ChangesRoot, h.List)
+	e.GET(ChangesRoot+"/", h.List)
+}
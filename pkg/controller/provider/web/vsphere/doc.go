@@ -67,5 +67,15 @@ func Handlers(container *container.Container) []libweb.RequestHandler {
 				base.Handler{Container: container},
 			},
 		},
+		&SuggestionHandler{
+			Handler: Handler{
+				base.Handler{Container: container},
+			},
+		},
+		&ChangeHandler{
+			ChangeHandler: base.ChangeHandler{
+				Handler: base.Handler{Container: container},
+			},
+		},
 	}
 }
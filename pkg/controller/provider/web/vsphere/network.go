@@ -191,6 +191,7 @@ type Network struct {
 	DVSwitch *model.Ref      `json:"dvSwitch,omitempty"`
 	Host     []model.DVSHost `json:"host"`
 	Tag      string          `json:"tag,omitempty"`
+	VLan     string          `json:"vlan,omitempty"`
 }
 
 //
@@ -203,6 +204,7 @@ func (r *Network) With(m *model.Network) {
 		r.Tag = m.Tag
 	case model.NetDvPortGroup:
 		r.DVSwitch = &m.DVSwitch
+		r.VLan = m.VLan
 	case model.NetDvSwitch:
 		r.Host = m.Host
 	}
@@ -0,0 +1,220 @@
+package vsphere
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	libmodel "github.com/konveyor/controller/pkg/inventory/model"
+	model "github.com/konveyor/forklift-controller/pkg/controller/provider/model/vsphere"
+	"github.com/konveyor/forklift-controller/pkg/controller/provider/web/suggest"
+	"net/http"
+)
+
+//
+// Routes.
+const (
+	SuggestionCollection   = "suggestions"
+	SuggestionsRoot        = ProviderRoot + "/" + SuggestionCollection
+	NetworkSuggestionsRoot = SuggestionsRoot + "/" + NetworkCollection
+	StorageSuggestionsRoot = SuggestionsRoot + "/storage"
+)
+
+//
+// Mapping suggestion handler.
+// Mappings are suggested for the networks and datastores
+// used by the VMs specified by the `vm` parameter (default: all)
+// to the destination provider specified by the `destination` parameter.
+type SuggestionHandler struct {
+	Handler
+}
+
+//
+// Add routes to the `gin` router.
+func (h *SuggestionHandler) AddRoutes(e *gin.Engine) {
+	e.GET(NetworkSuggestionsRoot, h.Networks)
+	e.GET(StorageSuggestionsRoot, h.Storage)
+}
+
+//
+// List not supported.
+func (h SuggestionHandler) List(ctx *gin.Context) {
+	ctx.Status(http.StatusMethodNotAllowed)
+}
+
+//
+// Get not supported.
+func (h SuggestionHandler) Get(ctx *gin.Context) {
+	ctx.Status(http.StatusMethodNotAllowed)
+}
+
+//
+// Suggest network mappings.
+func (h SuggestionHandler) Networks(ctx *gin.Context) {
+	destination, status := h.prepare(ctx)
+	if status != http.StatusOK {
+		ctx.Status(status)
+		return
+	}
+	used, err := h.used(ctx)
+	if err != nil {
+		log.Trace(
+			err,
+			"url",
+			ctx.Request.URL)
+		ctx.Status(http.StatusInternalServerError)
+		return
+	}
+	db := h.Reconciler.DB()
+	list := []model.Network{}
+	err = db.List(&list, libmodel.ListOptions{Detail: 1})
+	if err != nil {
+		log.Trace(
+			err,
+			"url",
+			ctx.Request.URL)
+		ctx.Status(http.StatusInternalServerError)
+		return
+	}
+	source := []suggest.SourceNetwork{}
+	for _, m := range list {
+		if m.Variant == model.NetDvSwitch {
+			continue
+		}
+		if used != nil && !used[m.ID] {
+			continue
+		}
+		source = append(
+			source,
+			suggest.SourceNetwork{
+				ID:   m.ID,
+				Name: m.Name,
+				VLan: m.VLan,
+			})
+	}
+	content := suggest.Networks(source, destination.NADs)
+	suggest.SortNetworks(content)
+
+	ctx.JSON(http.StatusOK, content)
+}
+
+//
+// Suggest storage mappings.
+func (h SuggestionHandler) Storage(ctx *gin.Context) {
+	destination, status := h.prepare(ctx)
+	if status != http.StatusOK {
+		ctx.Status(status)
+		return
+	}
+	used, err := h.used(ctx)
+	if err != nil {
+		log.Trace(
+			err,
+			"url",
+			ctx.Request.URL)
+		ctx.Status(http.StatusInternalServerError)
+		return
+	}
+	provisioners, err := suggest.Provisioners(h.Provider.Namespace)
+	if err != nil {
+		log.Trace(
+			err,
+			"url",
+			ctx.Request.URL)
+		ctx.Status(http.StatusInternalServerError)
+		return
+	}
+	db := h.Reconciler.DB()
+	list := []model.Datastore{}
+	err = db.List(&list, libmodel.ListOptions{Detail: 1})
+	if err != nil {
+		log.Trace(
+			err,
+			"url",
+			ctx.Request.URL)
+		ctx.Status(http.StatusInternalServerError)
+		return
+	}
+	source := []suggest.SourceStorage{}
+	for _, m := range list {
+		if used != nil && !used[m.ID] {
+			continue
+		}
+		source = append(
+			source,
+			suggest.SourceStorage{
+				ID:   m.ID,
+				Name: m.Name,
+				Type: m.Type,
+			})
+	}
+	content := suggest.Storage(source, destination.StorageClasses, provisioners)
+	suggest.SortStorage(content)
+
+	ctx.JSON(http.StatusOK, content)
+}
+
+//
+// Prepare the request and load the destination inventory.
+func (h *SuggestionHandler) prepare(ctx *gin.Context) (destination *suggest.Destination, status int) {
+	status = h.Prepare(ctx)
+	if status != http.StatusOK {
+		return
+	}
+	q := ctx.Request.URL.Query()
+	uid := q.Get(suggest.DestinationParam)
+	if uid == "" {
+		status = http.StatusBadRequest
+		return
+	}
+	destination = &suggest.Destination{}
+	found, err := destination.Load(h.Container, uid, q.Get(suggest.NamespaceParam))
+	if err != nil {
+		log.Trace(
+			err,
+			"url",
+			ctx.Request.URL)
+		status = http.StatusInternalServerError
+		return
+	}
+	if !found {
+		status = http.StatusNotFound
+	}
+
+	return
+}
+
+//
+// Networks and datastores (by ID) used by the VMs
+// specified by the `vm` parameter.
+// Returns nil when not specified.
+func (h *SuggestionHandler) used(ctx *gin.Context) (used map[string]bool, err error) {
+	q := ctx.Request.URL.Query()
+	ids := q[suggest.VMParam]
+	if len(ids) == 0 {
+		return
+	}
+	used = map[string]bool{}
+	db := h.Reconciler.DB()
+	for _, id := range ids {
+		vm := &model.VM{
+			Base: model.Base{
+				ID: id,
+			},
+		}
+		err = db.Get(vm)
+		if err != nil {
+			if errors.Is(err, model.NotFound) {
+				err = nil
+				continue
+			}
+			return
+		}
+		for _, network := range vm.Networks {
+			used[network.ID] = true
+		}
+		for _, disk := range vm.Disks {
+			used[disk.Datastore.ID] = true
+		}
+	}
+
+	return
+}
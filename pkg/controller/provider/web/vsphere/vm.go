@@ -8,6 +8,8 @@ import (
 	api "github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1"
 	model "github.com/konveyor/forklift-controller/pkg/controller/provider/model/vsphere"
 	"github.com/konveyor/forklift-controller/pkg/controller/provider/web/base"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
 	"net/http"
 	"strings"
 )
@@ -188,34 +190,53 @@ func (h VMHandler) filter(ctx *gin.Context, list *[]model.VM) (err error) {
 // REST Resource.
 type VM struct {
 	Resource
-	Folder                string          `json:"folder"`
-	Host                  string          `json:"host"`
-	PolicyVersion         int             `json:"policyVersion"`
-	RevisionValidated     int64           `json:"revisionValidated"`
-	UUID                  string          `json:"uuid"`
-	Firmware              string          `json:"firmware"`
-	PowerState            string          `json:"powerState"`
-	ConnectionState       string          `json:"connectionState"`
-	Snapshot              model.Ref       `json:"snapshot"`
-	IsTemplate            bool            `json:"isTemplate"`
-	ChangeTrackingEnabled bool            `json:"changeTrackingEnabled"`
-	CpuAffinity           []int32         `json:"cpuAffinity"`
-	CpuHotAddEnabled      bool            `json:"cpuHotAddEnabled"`
-	CpuHotRemoveEnabled   bool            `json:"cpuHotRemoveEnabled"`
-	MemoryHotAddEnabled   bool            `json:"memoryHotAddEnabled"`
-	FaultToleranceEnabled bool            `json:"faultToleranceEnabled"`
-	CpuCount              int32           `json:"cpuCount"`
-	CoresPerSocket        int32           `json:"coresPerSocket"`
-	MemoryMB              int32           `json:"memoryMB"`
-	GuestName             string          `json:"guestName"`
-	BalloonedMemory       int32           `json:"balloonedMemory"`
-	IpAddress             string          `json:"ipAddress"`
-	StorageUsed           int64           `json:"storageUsed"`
-	NumaNodeAffinity      []string        `json:"numaNodeAffinity"`
-	Devices               []model.Device  `json:"devices"`
-	Networks              []model.Ref     `json:"networks"`
-	Disks                 []model.Disk    `json:"disks"`
-	Concerns              []model.Concern `json:"concerns"`
+	Folder                string                  `json:"folder"`
+	Host                  string                  `json:"host"`
+	PolicyVersion         int                     `json:"policyVersion"`
+	RevisionValidated     int64                   `json:"revisionValidated"`
+	UUID                  string                  `json:"uuid"`
+	Firmware              string                  `json:"firmware"`
+	PowerState            string                  `json:"powerState"`
+	ConnectionState       string                  `json:"connectionState"`
+	Snapshot              model.Ref               `json:"snapshot"`
+	IsTemplate            bool                    `json:"isTemplate"`
+	ChangeTrackingEnabled bool                    `json:"changeTrackingEnabled"`
+	SecureBoot            bool                    `json:"secureBoot"`
+	TpmEnabled            bool                    `json:"tpmEnabled"`
+	CpuAffinity           []int32                 `json:"cpuAffinity"`
+	CpuHotAddEnabled      bool                    `json:"cpuHotAddEnabled"`
+	CpuHotRemoveEnabled   bool                    `json:"cpuHotRemoveEnabled"`
+	MemoryHotAddEnabled   bool                    `json:"memoryHotAddEnabled"`
+	FaultToleranceEnabled bool                    `json:"faultToleranceEnabled"`
+	CpuCount              int32                   `json:"cpuCount"`
+	CoresPerSocket        int32                   `json:"coresPerSocket"`
+	MemoryMB              int32                   `json:"memoryMB"`
+	GuestName             string                  `json:"guestName"`
+	GuestID               string                  `json:"guestId"`
+	GuestFamily           string                  `json:"guestFamily"`
+	HostName              string                  `json:"hostName"`
+	ToolsStatus           string                  `json:"toolsStatus"`
+	ToolsVersion          string                  `json:"toolsVersion"`
+	ToolsVersionStatus    string                  `json:"toolsVersionStatus"`
+	BalloonedMemory       int32                   `json:"balloonedMemory"`
+	IpAddress             string                  `json:"ipAddress"`
+	StorageUsed           int64                   `json:"storageUsed"`
+	GuestStorageUsed      int64                   `json:"guestStorageUsed"`
+	NumaNodeAffinity      []string                `json:"numaNodeAffinity"`
+	ResourcePool          string                  `json:"resourcePool"`
+	Annotation            string                  `json:"annotation"`
+	Tags                  []model.Tag             `json:"tags"`
+	CustomAttributes      []model.CustomAttribute `json:"customAttributes"`
+	Devices               []model.Device          `json:"devices"`
+	Controllers           []model.Controller      `json:"controllers"`
+	NICs                  []model.NIC             `json:"nics"`
+	GuestNetworks         []model.GuestNetwork    `json:"guestNetworks"`
+	GuestDisks            []model.GuestDisk       `json:"guestDisks"`
+	GuestGateways         []string                `json:"guestGateways"`
+	GuestDNS              []string                `json:"guestDNS"`
+	Networks              []model.Ref             `json:"networks"`
+	Disks                 []model.Disk            `json:"disks"`
+	Concerns              []model.Concern         `json:"concerns"`
 }
 
 //
@@ -233,6 +254,8 @@ func (r *VM) With(m *model.VM) {
 	r.Snapshot = m.Snapshot
 	r.IsTemplate = m.IsTemplate
 	r.ChangeTrackingEnabled = m.ChangeTrackingEnabled
+	r.SecureBoot = m.SecureBoot
+	r.TpmEnabled = m.TpmEnabled
 	r.CpuAffinity = m.CpuAffinity
 	r.CpuHotAddEnabled = m.CpuHotAddEnabled
 	r.CpuHotRemoveEnabled = m.CpuHotRemoveEnabled
@@ -241,17 +264,91 @@ func (r *VM) With(m *model.VM) {
 	r.CoresPerSocket = m.CoresPerSocket
 	r.MemoryMB = m.MemoryMB
 	r.GuestName = m.GuestName
+	r.GuestID = m.GuestID
+	r.GuestFamily = m.GuestFamily
+	r.HostName = m.HostName
+	r.ToolsStatus = m.ToolsStatus
+	r.ToolsVersion = m.ToolsVersion
+	r.ToolsVersionStatus = m.ToolsVersionStatus
 	r.BalloonedMemory = m.BalloonedMemory
 	r.IpAddress = m.IpAddress
 	r.StorageUsed = m.StorageUsed
+	r.GuestStorageUsed = m.GuestStorageUsed()
 	r.FaultToleranceEnabled = m.FaultToleranceEnabled
+	r.ResourcePool = m.ResourcePool
+	r.Annotation = m.Annotation
+	r.Tags = m.Tags
+	r.CustomAttributes = m.CustomAttributes
 	r.Devices = m.Devices
+	r.Controllers = m.Controllers
+	r.NICs = m.NICs
+	r.GuestNetworks = m.GuestNetworks
+	r.GuestDisks = m.GuestDisks
+	r.GuestGateways = m.GuestGateways
+	r.GuestDNS = m.GuestDNS
 	r.NumaNodeAffinity = m.NumaNodeAffinity
 	r.Networks = m.Networks
 	r.Disks = m.Disks
 	r.Concerns = m.Concerns
 }
 
+//
+// Labels.
+// Tags are mapped as: category=name and custom
+// attributes as: name=value. A label may have multiple
+// values when a (multiple cardinality) category has more
+// than one tag or a category and attribute share a name.
+func (r *VM) Labels() (labels map[string][]string) {
+	labels = map[string][]string{}
+	for _, attribute := range r.CustomAttributes {
+		labels[attribute.Name] = append(labels[attribute.Name], attribute.Value)
+	}
+	for _, tag := range r.Tags {
+		labels[tag.Category] = append(labels[tag.Category], tag.Name)
+	}
+
+	return
+}
+
+//
+// Match the label selector.
+// A (positive) requirement is met when any of the label
+// values match. A negative (!=, notin, !) requirement is met
+// when all of the label values match.
+func (r *VM) Match(selector labels.Selector) bool {
+	requirements, selectable := selector.Requirements()
+	if !selectable {
+		return false
+	}
+	vmLabels := r.Labels()
+	for _, requirement := range requirements {
+		key := requirement.Key()
+		matched := false
+		switch requirement.Operator() {
+		case selection.NotIn, selection.NotEquals, selection.DoesNotExist:
+			matched = true
+			for _, value := range vmLabels[key] {
+				if !requirement.Matches(labels.Set{key: value}) {
+					matched = false
+					break
+				}
+			}
+		default:
+			for _, value := range vmLabels[key] {
+				if requirement.Matches(labels.Set{key: value}) {
+					matched = true
+					break
+				}
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	return true
+}
+
 //
 // Build self link (URI).
 func (r *VM) Link(p *api.Provider) {
@@ -0,0 +1,47 @@
+package vsphere
+
+import (
+	model "github.com/konveyor/forklift-controller/pkg/controller/provider/model/vsphere"
+	"github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/labels"
+	"testing"
+)
+
+func TestVMMatch(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	vm := &VM{}
+	vm.Tags = []model.Tag{
+		{Category: "app", Name: "web"},
+		{Category: "app", Name: "db"},
+		{Category: "tier", Name: "1"},
+	}
+	vm.CustomAttributes = []model.CustomAttribute{
+		{Name: "tier", Value: "gold"},
+		{Name: "owner", Value: "ops"},
+	}
+	cases := []struct {
+		selector string
+		expected bool
+	}{
+		{selector: "app=web", expected: true},
+		{selector: "app=db", expected: true},
+		{selector: "app in (api,db)", expected: true},
+		{selector: "app=api", expected: false},
+		{selector: "app!=api", expected: true},
+		{selector: "app!=db", expected: false},
+		{selector: "app notin (db)", expected: false},
+		{selector: "tier=1", expected: true},
+		{selector: "tier=gold", expected: true},
+		{selector: "owner=ops,app=web", expected: true},
+		{selector: "owner=ops,app=api", expected: false},
+		{selector: "wave", expected: false},
+		{selector: "!wave", expected: true},
+		{selector: "!app", expected: false},
+	}
+	for _, c := range cases {
+		selector, err := labels.Parse(c.selector)
+		g.Expect(err).To(gomega.BeNil(), c.selector)
+		g.Expect(vm.Match(selector)).To(gomega.Equal(c.expected), c.selector)
+	}
+}
@@ -0,0 +1,127 @@
+package provisioner
+
+import (
+	api "github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1"
+	"github.com/konveyor/forklift-controller/pkg/controller/provider/web/ocp"
+	core "k8s.io/api/core/v1"
+)
+
+//
+// Volume/access mode shorthand.
+const (
+	Block      = core.PersistentVolumeBlock
+	Filesystem = core.PersistentVolumeFilesystem
+	RWO        = core.ReadWriteOnce
+	RWX        = core.ReadWriteMany
+)
+
+//
+// Storage capability.
+type Capability struct {
+	VolumeMode core.PersistentVolumeMode
+	AccessMode core.PersistentVolumeAccessMode
+}
+
+//
+// Known provisioner capabilities.
+// Listed in order of preference.
+var Capabilities = map[string][]Capability{
+	// Ceph RBD.
+	"kubernetes.io/rbd":                  {{Block, RWX}, {Block, RWO}, {Filesystem, RWO}},
+	"rbd.csi.ceph.com":                   {{Block, RWX}, {Block, RWO}, {Filesystem, RWO}},
+	"openshift-storage.rbd.csi.ceph.com": {{Block, RWX}, {Block, RWO}, {Filesystem, RWO}},
+	// CephFS.
+	"cephfs.csi.ceph.com":                   {{Filesystem, RWX}},
+	"openshift-storage.cephfs.csi.ceph.com": {{Filesystem, RWX}},
+	// NFS.
+	"nfs.csi.k8s.io":          {{Filesystem, RWX}},
+	"kubernetes.io/nfs":       {{Filesystem, RWX}},
+	"kubernetes.io/glusterfs": {{Filesystem, RWX}},
+	// AWS.
+	"kubernetes.io/aws-ebs": {{Block, RWO}, {Filesystem, RWO}},
+	"ebs.csi.aws.com":       {{Block, RWO}, {Filesystem, RWO}},
+	"efs.csi.aws.com":       {{Filesystem, RWX}},
+	// Azure.
+	"kubernetes.io/azure-disk": {{Block, RWO}, {Filesystem, RWO}},
+	"disk.csi.azure.com":       {{Block, RWO}, {Filesystem, RWO}},
+	"kubernetes.io/azure-file": {{Filesystem, RWX}},
+	"file.csi.azure.com":       {{Filesystem, RWX}},
+	// GCE.
+	"kubernetes.io/gce-pd":  {{Block, RWO}, {Filesystem, RWO}},
+	"pd.csi.storage.gke.io": {{Block, RWO}, {Filesystem, RWO}},
+	// OpenStack.
+	"kubernetes.io/cinder":     {{Block, RWO}, {Filesystem, RWO}},
+	"cinder.csi.openstack.org": {{Block, RWO}, {Filesystem, RWO}},
+	// vSphere.
+	"kubernetes.io/vsphere-volume": {{Filesystem, RWO}},
+	"csi.vsphere.vmware.com":       {{Filesystem, RWO}, {Block, RWO}},
+	// oVirt.
+	"csi.ovirt.org": {{Block, RWO}, {Filesystem, RWO}},
+	// LVM.
+	"topolvm.cybozu.com": {{Block, RWO}, {Filesystem, RWO}},
+	"topolvm.io":         {{Block, RWO}, {Filesystem, RWO}},
+	// Host path and local.
+	"kubevirt.io/hostpath-provisioner": {{Filesystem, RWO}},
+	"kubevirt.io.hostpath-provisioner": {{Filesystem, RWO}},
+	"kubernetes.io/no-provisioner":     {{Filesystem, RWO}},
+	"kubernetes.io/host-path":          {{Filesystem, RWO}},
+	"rancher.io/local-path":            {{Filesystem, RWO}},
+}
+
+//
+// Capabilities of CSI drivers not listed.
+var DefaultCSICapabilities = []Capability{
+	{Filesystem, RWO},
+}
+
+//
+// Capabilities reported by the claim property sets of a
+// CDI storage profile. Listed in order of preference.
+func ProfileCapabilities(sets []ocp.ClaimPropertySet) (capabilities []Capability) {
+	for _, set := range sets {
+		for _, accessMode := range set.AccessModes {
+			capabilities = append(
+				capabilities,
+				Capability{
+					VolumeMode: set.VolumeMode,
+					AccessMode: accessMode,
+				})
+		}
+	}
+
+	return
+}
+
+//
+// Build the provisioner spec.
+// The priority of the volume and access modes follows the
+// order of the capabilities (the first is the default).
+func Spec(name string, capabilities []Capability) (spec api.ProvisionerSpec) {
+	spec.Name = name
+	for _, capability := range capabilities {
+		var volumeMode *api.VolumeMode
+		for i := range spec.VolumeModes {
+			if spec.VolumeModes[i].Name == capability.VolumeMode {
+				volumeMode = &spec.VolumeModes[i]
+				break
+			}
+		}
+		if volumeMode == nil {
+			spec.VolumeModes = append(
+				spec.VolumeModes,
+				api.VolumeMode{
+					Name:     capability.VolumeMode,
+					Priority: len(spec.VolumeModes),
+				})
+			volumeMode = &spec.VolumeModes[len(spec.VolumeModes)-1]
+		}
+		volumeMode.AccessModes = append(
+			volumeMode.AccessModes,
+			api.AccessMode{
+				Name:     capability.AccessMode,
+				Priority: len(volumeMode.AccessModes),
+			})
+	}
+
+	return
+}
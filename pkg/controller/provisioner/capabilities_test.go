@@ -0,0 +1,22 @@
+package provisioner
+
+import (
+	"github.com/onsi/gomega"
+	"testing"
+)
+
+func TestSpec(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	spec := Spec("rbd.csi.ceph.com", Capabilities["rbd.csi.ceph.com"])
+	g.Expect(spec.Name).To(gomega.Equal("rbd.csi.ceph.com"))
+	g.Expect(len(spec.VolumeModes)).To(gomega.Equal(2))
+	// Default volume mode.
+	g.Expect(spec.VolumeModes[0].Name).To(gomega.Equal(Block))
+	g.Expect(spec.VolumeModes[0].Priority).To(gomega.Equal(0))
+	g.Expect(len(spec.VolumeModes[0].AccessModes)).To(gomega.Equal(2))
+	g.Expect(spec.VolumeModes[0].AccessModes[0].Name).To(gomega.Equal(RWX))
+	g.Expect(spec.VolumeModes[1].Name).To(gomega.Equal(Filesystem))
+	g.Expect(spec.VolumeModes[1].Priority).To(gomega.Equal(1))
+	g.Expect(spec.VolumeModes[1].AccessModes[0].Name).To(gomega.Equal(RWO))
+}
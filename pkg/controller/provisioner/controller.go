@@ -0,0 +1,320 @@
+/*
+Copyright 2019 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provisioner
+
+import (
+	"context"
+	libcnd "github.com/konveyor/controller/pkg/condition"
+	liberr "github.com/konveyor/controller/pkg/error"
+	"github.com/konveyor/controller/pkg/logging"
+	api "github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1"
+	"github.com/konveyor/forklift-controller/pkg/controller/base"
+	"github.com/konveyor/forklift-controller/pkg/controller/provider/web"
+	"github.com/konveyor/forklift-controller/pkg/controller/provider/web/ocp"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/storage/names"
+	"reflect"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+	"strings"
+	"time"
+)
+
+const (
+	// Name.
+	Name = "provisioner"
+	// Generated provisioner annotation.
+	// Provisioners without this annotation (user authored)
+	// are never updated.
+	AnnGenerated = "forklift.konveyor.io/generated"
+	// Periodic reconcile (inventory changes).
+	ReQ = time.Minute * 5
+)
+
+//
+// Package logger.
+var log = logging.WithName(Name)
+
+//
+// Creates a new Provisioner Controller and adds it to the Manager.
+// Provisioners are derived from the storage classes and CSI
+// drivers found in the inventory of OpenShift providers.
+func Add(mgr manager.Manager) error {
+	reconciler := &Reconciler{
+		Reconciler: base.Reconciler{
+			EventRecorder: mgr.GetEventRecorderFor(Name),
+			Client:        mgr.GetClient(),
+			Log:           log,
+		},
+	}
+	cnt, err := controller.New(
+		Name,
+		mgr,
+		controller.Options{
+			Reconciler: reconciler,
+		})
+	if err != nil {
+		log.Trace(err)
+		return err
+	}
+	// Primary CR.
+	err = cnt.Watch(
+		&source.Kind{Type: &api.Provider{}},
+		&handler.EnqueueRequestForObject{},
+		&ProviderPredicate{})
+	if err != nil {
+		log.Trace(err)
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &Reconciler{}
+
+//
+// Reconciles provisioners for a Provider.
+type Reconciler struct {
+	base.Reconciler
+}
+
+//
+// Reconcile the provisioners for an OpenShift provider.
+// Note: Must not a pointer receiver to ensure that the
+// logger and other state is not shared.
+func (r Reconciler) Reconcile(request reconcile.Request) (result reconcile.Result, err error) {
+	r.Log = logging.WithName(
+		names.SimpleNameGenerator.GenerateName(Name+"|"),
+		"provider",
+		request)
+	r.Started()
+	defer func() {
+		result.RequeueAfter = r.Ended(
+			result.RequeueAfter,
+			err)
+		err = nil
+	}()
+
+	// Fetch the provider.
+	provider := &api.Provider{}
+	err = r.Get(context.TODO(), request.NamespacedName, provider)
+	if err != nil {
+		if k8serr.IsNotFound(err) {
+			r.Log.Info("Provider deleted.")
+			err = nil
+		}
+		return
+	}
+	if provider.Type() != api.OpenShift {
+		return
+	}
+	if !provider.Status.HasCondition(libcnd.Ready) {
+		r.Log.Info("Waiting for provider to be ready.")
+		result.RequeueAfter = base.SlowReQ
+		return
+	}
+
+	// Derive the provisioners.
+	inventory, err := web.NewClient(provider)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	desired, err := r.derive(inventory)
+	if err != nil {
+		return
+	}
+
+	// Apply.
+	err = r.apply(provider, desired)
+	if err != nil {
+		return
+	}
+
+	result.RequeueAfter = ReQ
+
+	// Done
+	return
+}
+
+//
+// Derive the provisioner specs using the storage classes,
+// storage profiles and CSI drivers found in the provider
+// inventory. The claim property sets reported by the CDI
+// storage profiles take precedence over the capabilities of
+// well known provisioners. CSI drivers not listed get the
+// default CSI capabilities. Other provisioners are ignored.
+func (r *Reconciler) derive(inventory web.Client) (desired []api.ProvisionerSpec, err error) {
+	storageClasses := []ocp.StorageClass{}
+	err = inventory.List(
+		&storageClasses,
+		web.Param{
+			Key:   ocp.DetailParam,
+			Value: "1",
+		})
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	profiles := []ocp.StorageProfile{}
+	err = inventory.List(
+		&profiles,
+		web.Param{
+			Key:   ocp.DetailParam,
+			Value: "1",
+		})
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	drivers := []ocp.CSIDriver{}
+	err = inventory.List(&drivers)
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	profileCapabilities := map[string][]Capability{}
+	for _, profile := range profiles {
+		capabilities := ProfileCapabilities(profile.ClaimPropertySets)
+		if len(capabilities) > 0 {
+			profileCapabilities[profile.StorageClass] = capabilities
+		}
+	}
+	csi := map[string]bool{}
+	for _, driver := range drivers {
+		csi[driver.Name] = true
+	}
+	names := []string{}
+	derived := map[string][]Capability{}
+	profiled := map[string]bool{}
+	for _, sc := range storageClasses {
+		name := sc.Object.Provisioner
+		if profiled[name] {
+			continue
+		}
+		capabilities, found := profileCapabilities[sc.Name]
+		if found {
+			profiled[name] = true
+		} else {
+			if _, found := derived[name]; found {
+				continue
+			}
+			capabilities, found = Capabilities[name]
+			if !found {
+				if !csi[name] {
+					continue
+				}
+				capabilities = DefaultCSICapabilities
+			}
+		}
+		if _, found := derived[name]; !found {
+			names = append(names, name)
+		}
+		derived[name] = capabilities
+	}
+	for _, name := range names {
+		desired = append(desired, Spec(name, derived[name]))
+	}
+
+	return
+}
+
+//
+// Create or update the provisioners in the provider namespace.
+// User authored provisioners take precedence.
+func (r *Reconciler) apply(provider *api.Provider, desired []api.ProvisionerSpec) (err error) {
+	list := &api.ProvisionerList{}
+	err = r.List(
+		context.TODO(),
+		list,
+		&client.ListOptions{
+			Namespace: provider.Namespace,
+		})
+	if err != nil {
+		err = liberr.Wrap(err)
+		return
+	}
+	found := map[string]*api.Provisioner{}
+	for i := range list.Items {
+		p := &list.Items[i]
+		found[p.Spec.Name] = p
+	}
+	for _, spec := range desired {
+		current, exists := found[spec.Name]
+		if !exists {
+			object := &api.Provisioner{
+				ObjectMeta: meta.ObjectMeta{
+					Namespace: provider.Namespace,
+					Name:      r.objectName(spec.Name),
+					Annotations: map[string]string{
+						AnnGenerated: "true",
+					},
+				},
+				Spec: spec,
+			}
+			err = r.Create(context.TODO(), object)
+			if err != nil {
+				if k8serr.IsAlreadyExists(err) {
+					err = nil
+					continue
+				}
+				err = liberr.Wrap(err)
+				return
+			}
+			r.Log.Info(
+				"Provisioner created.",
+				"name",
+				object.Name,
+				"provisioner",
+				spec.Name)
+			continue
+		}
+		if current.Annotations[AnnGenerated] != "true" {
+			continue
+		}
+		if reflect.DeepEqual(current.Spec, spec) {
+			continue
+		}
+		current.Spec = spec
+		err = r.Update(context.TODO(), current)
+		if err != nil {
+			err = liberr.Wrap(err)
+			return
+		}
+		r.Log.Info(
+			"Provisioner updated.",
+			"name",
+			current.Name,
+			"provisioner",
+			spec.Name)
+	}
+
+	return
+}
+
+//
+// Provisioner (CR) name.
+func (r *Reconciler) objectName(provisioner string) string {
+	name := strings.ToLower(provisioner)
+	name = strings.NewReplacer("/", "-", "_", "-").Replace(name)
+	return strings.Trim(name, "-.")
+}
@@ -0,0 +1,180 @@
+package provisioner
+
+import (
+	"context"
+	api "github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1"
+	"github.com/konveyor/forklift-controller/pkg/controller/base"
+	"github.com/konveyor/forklift-controller/pkg/controller/provider/web"
+	"github.com/konveyor/forklift-controller/pkg/controller/provider/web/ocp"
+	"github.com/onsi/gomega"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+)
+
+//
+// Provider inventory client.
+type fakeInventory struct {
+	web.Client
+	storageClasses []ocp.StorageClass
+	profiles       []ocp.StorageProfile
+	drivers        []ocp.CSIDriver
+}
+
+func (r *fakeInventory) List(list interface{}, param ...web.Param) (err error) {
+	switch list := list.(type) {
+	case *[]ocp.StorageClass:
+		*list = r.storageClasses
+	case *[]ocp.StorageProfile:
+		*list = r.profiles
+	case *[]ocp.CSIDriver:
+		*list = r.drivers
+	}
+
+	return
+}
+
+func storageClass(name, provisioner string) (sc ocp.StorageClass) {
+	sc.Name = name
+	sc.Object.Name = name
+	sc.Object.Provisioner = provisioner
+	return
+}
+
+func newReconciler(g *gomega.GomegaWithT, objects ...runtime.Object) *Reconciler {
+	scheme := runtime.NewScheme()
+	err := api.SchemeBuilder.AddToScheme(scheme)
+	g.Expect(err).To(gomega.BeNil())
+	return &Reconciler{
+		Reconciler: base.Reconciler{
+			Client: fake.NewFakeClientWithScheme(scheme, objects...),
+			Log:    log,
+		},
+	}
+}
+
+func TestDerive(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	profile := ocp.StorageProfile{
+		StorageClass: "nfs",
+		ClaimPropertySets: []ocp.ClaimPropertySet{
+			{
+				VolumeMode:  Block,
+				AccessModes: []core.PersistentVolumeAccessMode{RWX},
+			},
+			{
+				VolumeMode:  Filesystem,
+				AccessModes: []core.PersistentVolumeAccessMode{RWX, RWO},
+			},
+		},
+	}
+	inventory := &fakeInventory{
+		storageClasses: []ocp.StorageClass{
+			storageClass("nfs-default", "nfs.csi.k8s.io"),
+			storageClass("nfs", "nfs.csi.k8s.io"),
+			storageClass("ebs", "ebs.csi.aws.com"),
+			storageClass("custom", "custom.csi.io"),
+			storageClass("unknown", "example.com/unknown"),
+		},
+		profiles: []ocp.StorageProfile{profile},
+		drivers: []ocp.CSIDriver{
+			{Resource: ocp.Resource{Name: "custom.csi.io"}},
+		},
+	}
+	reconciler := newReconciler(g)
+	desired, err := reconciler.derive(inventory)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(desired).To(
+		gomega.Equal(
+			[]api.ProvisionerSpec{
+				// Storage profile.
+				Spec("nfs.csi.k8s.io", ProfileCapabilities(profile.ClaimPropertySets)),
+				// Known provisioner.
+				Spec("ebs.csi.aws.com", Capabilities["ebs.csi.aws.com"]),
+				// CSI driver.
+				Spec("custom.csi.io", DefaultCSICapabilities),
+			}))
+	g.Expect(desired[0].VolumeModes[0].Name).To(gomega.Equal(Block))
+	g.Expect(desired[0].VolumeModes[1].AccessModes[1].Name).To(gomega.Equal(RWO))
+}
+
+func TestApply(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	provider := &api.Provider{}
+	provider.Namespace = "test"
+	rbd := Spec("rbd.csi.ceph.com", Capabilities["rbd.csi.ceph.com"])
+	nfs := Spec("nfs.csi.k8s.io", Capabilities["nfs.csi.k8s.io"])
+	stale := Spec("nfs.csi.k8s.io", DefaultCSICapabilities)
+	user := Spec("rbd.csi.ceph.com", DefaultCSICapabilities)
+	provisioner := func(name string, spec api.ProvisionerSpec, generated bool) *api.Provisioner {
+		object := &api.Provisioner{
+			ObjectMeta: meta.ObjectMeta{
+				Namespace: provider.Namespace,
+				Name:      name,
+			},
+			Spec: spec,
+		}
+		if generated {
+			object.Annotations = map[string]string{
+				AnnGenerated: "true",
+			}
+		}
+		return object
+	}
+
+	cases := []struct {
+		name     string
+		existing []runtime.Object
+		expected map[string]api.ProvisionerSpec
+	}{
+		{
+			name: "create",
+			expected: map[string]api.ProvisionerSpec{
+				"rbd.csi.ceph.com": rbd,
+				"nfs.csi.k8s.io":   nfs,
+			},
+		},
+		{
+			name: "update",
+			existing: []runtime.Object{
+				provisioner("nfs", stale, true),
+			},
+			expected: map[string]api.ProvisionerSpec{
+				"rbd.csi.ceph.com": rbd,
+				"nfs":              nfs,
+			},
+		},
+		{
+			name: "user authored",
+			existing: []runtime.Object{
+				provisioner("ceph", user, false),
+			},
+			expected: map[string]api.ProvisionerSpec{
+				"ceph":           user,
+				"nfs.csi.k8s.io": nfs,
+			},
+		},
+	}
+
+	for _, c := range cases {
+		reconciler := newReconciler(g, c.existing...)
+		err := reconciler.apply(provider, []api.ProvisionerSpec{rbd, nfs})
+		g.Expect(err).To(gomega.BeNil(), c.name)
+		list := &api.ProvisionerList{}
+		err = reconciler.List(
+			context.TODO(),
+			list,
+			&client.ListOptions{
+				Namespace: provider.Namespace,
+			})
+		g.Expect(err).To(gomega.BeNil(), c.name)
+		found := map[string]api.ProvisionerSpec{}
+		for _, p := range list.Items {
+			found[p.Name] = p.Spec
+		}
+		g.Expect(found).To(gomega.Equal(c.expected), c.name)
+	}
+}
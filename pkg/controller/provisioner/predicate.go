@@ -0,0 +1,45 @@
+package provisioner
+
+import (
+	api "github.com/konveyor/forklift-controller/pkg/apis/forklift/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+//
+// Provider watch predicate.
+// Only (reconciled) OpenShift providers.
+type ProviderPredicate struct {
+	predicate.Funcs
+}
+
+//
+// Provider created event.
+func (r ProviderPredicate) Create(e event.CreateEvent) bool {
+	p, cast := e.Object.(*api.Provider)
+	if cast {
+		return p.Type() == api.OpenShift &&
+			p.Status.ObservedGeneration == p.Generation
+	}
+
+	return false
+}
+
+//
+// Provider updated event.
+func (r ProviderPredicate) Update(e event.UpdateEvent) bool {
+	p, cast := e.ObjectNew.(*api.Provider)
+	if cast {
+		return p.Type() == api.OpenShift &&
+			p.Status.ObservedGeneration == p.Generation
+	}
+
+	return false
+}
+
+//
+// Provider deleted event.
+// Generated provisioners are preserved.
+func (r ProviderPredicate) Delete(e event.DeleteEvent) bool {
+	return false
+}
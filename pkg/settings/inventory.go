@@ -24,6 +24,7 @@ const (
 	TLSCertificate = "API_TLS_CERTIFICATE"
 	TLSKey         = "API_TLS_KEY"
 	TLSCa          = "API_TLS_CA"
+	FeedRetention  = "CHANGE_FEED_RETENTION"
 )
 
 //
@@ -57,11 +58,13 @@ type Inventory struct {
 		// CA path
 		CA string
 	}
+	// Change feed retention (minutes).
+	ChangeRetention int
 }
 
 //
 // Load settings.
-func (r *Inventory) Load() error {
+func (r *Inventory) Load() (err error) {
 	r.CORS = CORS{
 		AllowedOrigins: []string{},
 	}
@@ -109,6 +112,11 @@ func (r *Inventory) Load() error {
 			r.TLS.CA = ServiceCAFile
 		}
 	}
+	// Change feed.
+	r.ChangeRetention, err = getEnvLimit(FeedRetention, 1440)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
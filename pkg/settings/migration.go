@@ -1,6 +1,9 @@
 package settings
 
-import liberr "github.com/konveyor/controller/pkg/error"
+import (
+	liberr "github.com/konveyor/controller/pkg/error"
+	"os"
+)
 
 //
 // Environment variables.
@@ -8,6 +11,7 @@ const (
 	MaxVmInFlight = "MAX_VM_INFLIGHT"
 	HookDeadline  = "HOOK_DEADLINE"
 	HookRetry     = "HOOK_RETRY"
+	VerifyImage   = "VERIFY_IMAGE"
 )
 
 //
@@ -19,6 +23,8 @@ type Migration struct {
 	HookRetry int
 	// Hook completion deadline.
 	HookDeadline int
+	// Image used to verify migrated VMs.
+	VerifyImage string
 }
 
 //
@@ -36,6 +42,11 @@ func (r *Migration) Load() (err error) {
 	if err != nil {
 		err = liberr.Wrap(err)
 	}
+	if s, found := os.LookupEnv(VerifyImage); found {
+		r.VerifyImage = s
+	} else {
+		r.VerifyImage = "registry.access.redhat.com/ubi8/ubi-minimal"
+	}
 
 	return
 }